@@ -0,0 +1,91 @@
+package challenger
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/the-web3/contracts-caller/api"
+	"github.com/the-web3/contracts-caller/caller"
+)
+
+// startAdminAPI serves the admin API on --admin-listen, taking withdrawals
+// when --withdrawals-db and --withdrawal-policy are set and reward grants
+// when --rewards-db is. The returned func stops it.
+func startAdminAPI(ctx context.Context, cfg Config, cCaller *caller.ContractCaller) (func(), error) {
+	var closers []io.Closer
+	closeAll := func() {
+		for i := len(closers) - 1; i >= 0; i-- {
+			closers[i].Close()
+		}
+	}
+	fail := func(err error) (func(), error) {
+		closeAll()
+		return nil, err
+	}
+
+	if cfg.AdminTokens == "" {
+		return nil, errors.New("--admin-listen needs --admin-tokens")
+	}
+	tokens, err := api.LoadTokens(cfg.AdminTokens)
+	if err != nil {
+		return nil, err
+	}
+	operatorConfig := caller.OperatorConfig{}
+	if cfg.OperationsDB == "" {
+		log.Warn("Contract caller admin api operations kept in memory, set --operations-db to keep them")
+		operatorConfig.Store = caller.NewMemoryOperationStore()
+	} else {
+		store, err := caller.NewLevelDBOperationStore(cfg.OperationsDB)
+		if err != nil {
+			return fail(err)
+		}
+		closers = append(closers, store)
+		operatorConfig.Store = store
+	}
+	if cfg.WithdrawalsDB != "" && cfg.WithdrawalPolicy != "" {
+		if operatorConfig.WithdrawalPolicy, err = loadWithdrawalPolicy(cfg); err != nil {
+			return fail(err)
+		}
+		store, err := openWithdrawalStore(cfg)
+		if err != nil {
+			return fail(err)
+		}
+		closers = append(closers, store)
+		operatorConfig.Withdrawals = store
+	} else {
+		log.Warn("Contract caller admin api refuses withdrawals without --withdrawals-db and --withdrawal-policy")
+	}
+	if cfg.RewardsDB != "" {
+		store, err := openRewardStore(cfg, true)
+		if err != nil {
+			return fail(err)
+		}
+		closers = append(closers, store)
+		operatorConfig.Rewards = store
+	} else {
+		log.Warn("Contract caller admin api refuses reward grants without --rewards-db")
+	}
+
+	operator := caller.NewOperator(cCaller, operatorConfig)
+	server, err := api.New(api.Config{
+		Operator: operator,
+		Listen:   cfg.AdminListen,
+		Tokens:   tokens,
+	})
+	if err != nil {
+		return fail(err)
+	}
+	operator.Start(ctx)
+	if err := server.Start(); err != nil {
+		operator.Stop()
+		return fail(err)
+	}
+	return func() {
+		server.Stop()
+		operator.Stop()
+		closeAll()
+	}, nil
+}
@@ -0,0 +1,237 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+
+	ethc "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/the-web3/contracts-caller/caller"
+	common2 "github.com/the-web3/contracts-caller/common"
+)
+
+// Operator is the part of caller.Operator the API drives.
+type Operator interface {
+	SubmitWithdrawal(ctx context.Context, submittedBy string, req *caller.WithdrawalRequest) (*caller.Operation, error)
+	SubmitReward(ctx context.Context, submittedBy string, entry *caller.RewardEntry) (*caller.Operation, error)
+	Get(id string) (*caller.Operation, error)
+	List() ([]*caller.Operation, error)
+	Cancel(id, by string) (*caller.Operation, error)
+	ApproveWithdrawal(id, approvedBy string) (*caller.Withdrawal, error)
+	RejectWithdrawal(id, by, reason string) (*caller.Withdrawal, error)
+	EthAddress(ctx context.Context) (ethc.Address, error)
+	JobStatuses() []caller.JobStatus
+}
+
+// WithdrawalArgs asks for a withdrawal under the withdrawal policy. Token is
+// ETH or a token address, Amount an integer in the smallest unit.
+type WithdrawalArgs struct {
+	Token  string `json:"token"`
+	To     string `json:"to"`
+	Amount string `json:"amount"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// RewardArgs asks for the grant of one reward schedule entry.
+type RewardArgs struct {
+	Token   string `json:"token"`
+	Granter string `json:"granter"`
+	Amount  string `json:"amount"`
+	Epoch   uint64 `json:"epoch"`
+	// ID is the idempotency key, epoch:token:granter when empty.
+	ID string `json:"id,omitempty"`
+}
+
+// RejectArgs gives the reason a withdrawal is rejected.
+type RejectArgs struct {
+	Reason string `json:"reason,omitempty"`
+}
+
+// ListFilter narrows a listing down to a status or kind, all when empty.
+type ListFilter struct {
+	Status caller.OperationStatus `json:"status,omitempty"`
+	Kind   caller.OperationKind   `json:"kind,omitempty"`
+}
+
+// Error carries the HTTP status of a failed call, and its JSON-RPC code.
+type Error struct {
+	Status int
+	Err    error
+}
+
+var _ rpc.Error = (*Error)(nil)
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// ErrorCode is the JSON-RPC error code of e.
+func (e *Error) ErrorCode() int {
+	if e.Status == http.StatusBadRequest {
+		return -32602
+	}
+	return -32000
+}
+
+func invalidArgs(format string, args ...interface{}) error {
+	return &Error{Status: http.StatusBadRequest, Err: fmt.Errorf(format, args...)}
+}
+
+// apiError gives the errors of the operator their HTTP status.
+func apiError(err error) error {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, caller.ErrOperationNotFound), errors.Is(err, caller.ErrWithdrawalNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, caller.ErrNotCancellable):
+		status = http.StatusConflict
+	case errors.Is(err, caller.ErrOperationRefused), errors.Is(err, caller.ErrWithdrawalPolicy),
+		errors.Is(err, caller.ErrApprovalRefused):
+		status = http.StatusUnprocessableEntity
+	}
+	return &Error{Status: status, Err: err}
+}
+
+// AdminAPI is served under the admin namespace over JSON-RPC, e.g.
+// admin_submitWithdrawal, and backs the REST routes. Every method acts as
+// the client the request was authenticated as.
+type AdminAPI struct {
+	op Operator
+}
+
+func (a *AdminAPI) SubmitWithdrawal(ctx context.Context, args WithdrawalArgs) (*caller.Operation, error) {
+	var token ethc.Address
+	var err error
+	if strings.EqualFold(args.Token, caller.WithdrawalPolicyETH) {
+		if token, err = a.op.EthAddress(ctx); err != nil {
+			return nil, apiError(err)
+		}
+	} else if token, err = common2.ParseAddress(args.Token); err != nil {
+		return nil, invalidArgs("token: %v", err)
+	}
+	to, err := common2.ParseAddress(args.To)
+	if err != nil {
+		return nil, invalidArgs("to: %v", err)
+	}
+	amount, err := parseAmount(args.Amount)
+	if err != nil {
+		return nil, err
+	}
+	op, err := a.op.SubmitWithdrawal(ctx, clientName(ctx), &caller.WithdrawalRequest{
+		Token:  token,
+		To:     to,
+		Amount: amount,
+		Reason: args.Reason,
+	})
+	if err != nil {
+		return nil, apiError(err)
+	}
+	return op, nil
+}
+
+func (a *AdminAPI) SubmitReward(ctx context.Context, args RewardArgs) (*caller.Operation, error) {
+	token, err := common2.ParseAddress(args.Token)
+	if err != nil {
+		return nil, invalidArgs("token: %v", err)
+	}
+	granter, err := common2.ParseAddress(args.Granter)
+	if err != nil {
+		return nil, invalidArgs("granter: %v", err)
+	}
+	amount, err := parseAmount(args.Amount)
+	if err != nil {
+		return nil, err
+	}
+	op, err := a.op.SubmitReward(ctx, clientName(ctx), &caller.RewardEntry{
+		Token:   token,
+		Granter: granter,
+		Amount:  amount,
+		Epoch:   args.Epoch,
+		ID:      args.ID,
+	})
+	if err != nil {
+		return nil, apiError(err)
+	}
+	return op, nil
+}
+
+func (a *AdminAPI) GetOperation(ctx context.Context, id string) (*caller.Operation, error) {
+	op, err := a.op.Get(id)
+	if err != nil {
+		return nil, apiError(err)
+	}
+	return op, nil
+}
+
+// ListOperations returns the history of operations, oldest first.
+func (a *AdminAPI) ListOperations(ctx context.Context, filter *ListFilter) ([]*caller.Operation, error) {
+	ops, err := a.op.List()
+	if err != nil {
+		return nil, apiError(err)
+	}
+	listed := []*caller.Operation{}
+	for _, op := range ops {
+		if filter != nil && filter.Status != "" && op.Status != filter.Status {
+			continue
+		}
+		if filter != nil && filter.Kind != "" && op.Kind != filter.Kind {
+			continue
+		}
+		listed = append(listed, op)
+	}
+	return listed, nil
+}
+
+// CancelOperation drops an operation that is still queued.
+func (a *AdminAPI) CancelOperation(ctx context.Context, id string) (*caller.Operation, error) {
+	op, err := a.op.Cancel(id, clientName(ctx))
+	if err != nil {
+		return nil, apiError(err)
+	}
+	return op, nil
+}
+
+// ApproveWithdrawal approves the withdrawal id, the recordId of its
+// operation, as the client. The client must not be its requester.
+func (a *AdminAPI) ApproveWithdrawal(ctx context.Context, id string) (*caller.Withdrawal, error) {
+	w, err := a.op.ApproveWithdrawal(id, clientName(ctx))
+	if err != nil {
+		return nil, apiError(err)
+	}
+	return w, nil
+}
+
+// RejectWithdrawal rejects the withdrawal id as the client, cancelling its
+// operation.
+func (a *AdminAPI) RejectWithdrawal(ctx context.Context, id string, args *RejectArgs) (*caller.Withdrawal, error) {
+	reason := ""
+	if args != nil {
+		reason = args.Reason
+	}
+	w, err := a.op.RejectWithdrawal(id, clientName(ctx), reason)
+	if err != nil {
+		return nil, apiError(err)
+	}
+	return w, nil
+}
+
+func (a *AdminAPI) JobStatuses(ctx context.Context) []caller.JobStatus {
+	return a.op.JobStatuses()
+}
+
+func parseAmount(s string) (*big.Int, error) {
+	amount, ok := new(big.Int).SetString(s, 0)
+	if !ok || amount.Sign() <= 0 {
+		return nil, invalidArgs("invalid amount: %v", s)
+	}
+	return amount, nil
+}
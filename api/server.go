@@ -0,0 +1,234 @@
+package api
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/the-web3/contracts-caller/caller"
+)
+
+const maxBodySize = 1 << 20
+
+type Config struct {
+	Operator Operator
+	// Listen is the address the API is served on, e.g. 127.0.0.1:8545.
+	Listen string
+	// Tokens are the bearer tokens of the clients, by client name.
+	Tokens map[string]string
+}
+
+// Server serves AdminAPI as REST under /v1 and as JSON-RPC on /rpc, to
+// clients holding one of the configured bearer tokens.
+type Server struct {
+	cfg     Config
+	tokens  map[[sha256.Size]byte]string
+	rpc     *rpc.Server
+	handler http.Handler
+	http    *http.Server
+}
+
+type clientKey struct{}
+
+// clientName is the client a request was authenticated as.
+func clientName(ctx context.Context) string {
+	name, _ := ctx.Value(clientKey{}).(string)
+	return name
+}
+
+// LoadTokens reads a YAML map of client names to their bearer tokens.
+func LoadTokens(path string) (map[string]string, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var tokens map[string]string
+	if err := yaml.Unmarshal(raw, &tokens); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return tokens, nil
+}
+
+func New(cfg Config) (*Server, error) {
+	if len(cfg.Tokens) == 0 {
+		return nil, errors.New("api: no client tokens configured")
+	}
+	s := &Server{
+		cfg:    cfg,
+		tokens: make(map[[sha256.Size]byte]string, len(cfg.Tokens)),
+		rpc:    rpc.NewServer(),
+	}
+	for name, token := range cfg.Tokens {
+		if name == "" || len(token) < 16 {
+			return nil, fmt.Errorf("api: token of client %q must be at least 16 characters", name)
+		}
+		hash := sha256.Sum256([]byte(token))
+		if other, ok := s.tokens[hash]; ok {
+			return nil, fmt.Errorf("api: clients %s and %s share a token", other, name)
+		}
+		s.tokens[hash] = name
+	}
+
+	admin := &AdminAPI{op: cfg.Operator}
+	if err := s.rpc.RegisterName("admin", admin); err != nil {
+		return nil, err
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/withdrawals", func(w http.ResponseWriter, r *http.Request) {
+		var args WithdrawalArgs
+		if !decodeBody(w, r, &args) {
+			return
+		}
+		op, err := admin.SubmitWithdrawal(r.Context(), args)
+		writeResult(w, http.StatusAccepted, op, err)
+	})
+	mux.HandleFunc("POST /v1/withdrawals/{id}/approve", func(w http.ResponseWriter, r *http.Request) {
+		withdrawal, err := admin.ApproveWithdrawal(r.Context(), r.PathValue("id"))
+		writeResult(w, http.StatusOK, withdrawal, err)
+	})
+	mux.HandleFunc("POST /v1/withdrawals/{id}/reject", func(w http.ResponseWriter, r *http.Request) {
+		var args RejectArgs
+		if r.ContentLength != 0 && !decodeBody(w, r, &args) {
+			return
+		}
+		withdrawal, err := admin.RejectWithdrawal(r.Context(), r.PathValue("id"), &args)
+		writeResult(w, http.StatusOK, withdrawal, err)
+	})
+	mux.HandleFunc("POST /v1/rewards", func(w http.ResponseWriter, r *http.Request) {
+		var args RewardArgs
+		if !decodeBody(w, r, &args) {
+			return
+		}
+		op, err := admin.SubmitReward(r.Context(), args)
+		writeResult(w, http.StatusAccepted, op, err)
+	})
+	mux.HandleFunc("GET /v1/operations", func(w http.ResponseWriter, r *http.Request) {
+		ops, err := admin.ListOperations(r.Context(), &ListFilter{
+			Status: caller.OperationStatus(r.URL.Query().Get("status")),
+			Kind:   caller.OperationKind(r.URL.Query().Get("kind")),
+		})
+		writeResult(w, http.StatusOK, ops, err)
+	})
+	mux.HandleFunc("GET /v1/operations/{id}", func(w http.ResponseWriter, r *http.Request) {
+		op, err := admin.GetOperation(r.Context(), r.PathValue("id"))
+		writeResult(w, http.StatusOK, op, err)
+	})
+	mux.HandleFunc("POST /v1/operations/{id}/cancel", func(w http.ResponseWriter, r *http.Request) {
+		op, err := admin.CancelOperation(r.Context(), r.PathValue("id"))
+		writeResult(w, http.StatusOK, op, err)
+	})
+	mux.HandleFunc("GET /v1/jobs", func(w http.ResponseWriter, r *http.Request) {
+		writeResult(w, http.StatusOK, admin.JobStatuses(r.Context()), nil)
+	})
+	mux.Handle("POST /rpc", s.rpc)
+	s.handler = s.authenticate(mux)
+	return s, nil
+}
+
+// Handler is the authenticated handler of every route.
+func (s *Server) Handler() http.Handler {
+	return s.handler
+}
+
+// authenticate lets a request through with the client name of its bearer
+// token in its context.
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		name := ""
+		if ok {
+			hash := sha256.Sum256([]byte(token))
+			for known, client := range s.tokens {
+				if subtle.ConstantTimeCompare(hash[:], known[:]) == 1 {
+					name = client
+				}
+			}
+		}
+		if name == "" {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			writeError(w, http.StatusUnauthorized, errors.New("missing or unknown bearer token"))
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), clientKey{}, name)))
+	})
+}
+
+func (s *Server) Start() error {
+	listener, err := net.Listen("tcp", s.cfg.Listen)
+	if err != nil {
+		return err
+	}
+	s.http = &http.Server{
+		Handler:           s.handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		if err := s.http.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Error("Contract caller admin api stopped", "err", err)
+		}
+	}()
+	log.Info("Contract caller admin api listening", "addr", listener.Addr(), "clients", len(s.tokens))
+	return nil
+}
+
+func (s *Server) Stop() {
+	if s.http != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := s.http.Shutdown(ctx); err != nil {
+			log.Error("Contract caller admin api shutdown", "err", err)
+		}
+	}
+	s.rpc.Stop()
+}
+
+func decodeBody(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodySize))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return false
+	}
+	return true
+}
+
+func writeResult(w http.ResponseWriter, status int, result interface{}, err error) {
+	if err != nil {
+		var apiErr *Error
+		if errors.As(err, &apiErr) {
+			status = apiErr.Status
+		} else {
+			status = http.StatusInternalServerError
+		}
+		writeError(w, status, err)
+		return
+	}
+	writeJSON(w, status, result)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, struct {
+		Error string `json:"error"`
+	}{err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Warn("Contract caller admin api unable to write response", "err", err)
+	}
+}
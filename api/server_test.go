@@ -0,0 +1,308 @@
+package api_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/the-web3/contracts-caller/api"
+	"github.com/the-web3/contracts-caller/caller"
+)
+
+var (
+	ethAddr = common.HexToAddress("0xEeeeeEeeeEeEeeEeEeEeeEEEeeeeEeeeeeeeEEeE")
+	usdt    = common.HexToAddress("0xdAC17F958D2ee523a2206206994597C13D831ec7")
+	alice   = common.HexToAddress("0x70997970C51812dc3A010C7d01b54e8b0b6FA4d6")
+)
+
+const (
+	payoutsToken = "payouts-0123456789abcdef"
+	rewardsToken = "rewards-0123456789abcdef"
+)
+
+// fakeOperator queues operations in memory, refusing withdrawals of more
+// than 1000 and holding those of more than 100 for approval.
+type fakeOperator struct {
+	mu          sync.Mutex
+	ops         []*caller.Operation
+	withdrawals caller.WithdrawalStore
+}
+
+func (f *fakeOperator) add(op *caller.Operation) *caller.Operation {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	op.ID = fmt.Sprintf("op-%d", len(f.ops))
+	op.Status = caller.OperationQueued
+	f.ops = append(f.ops, op)
+	return op
+}
+
+func (f *fakeOperator) SubmitWithdrawal(ctx context.Context, submittedBy string, req *caller.WithdrawalRequest) (*caller.Operation, error) {
+	if req.Amount.Cmp(big.NewInt(1000)) > 0 {
+		return nil, errors.Wrap(caller.ErrWithdrawalPolicy, "over the daily limit")
+	}
+	req.RequestedBy = submittedBy
+	w := &caller.Withdrawal{
+		ID:          fmt.Sprintf("w-%d", len(f.ops)),
+		Token:       req.Token,
+		To:          req.To,
+		Amount:      req.Amount,
+		RequestedBy: submittedBy,
+		Status:      caller.WithdrawalApproved,
+	}
+	if req.Amount.Cmp(big.NewInt(100)) > 0 {
+		w.Status = caller.WithdrawalAwaitingApproval
+	}
+	if err := f.withdrawals.Put(w); err != nil {
+		return nil, err
+	}
+	return f.add(&caller.Operation{Kind: caller.OperationWithdrawal, SubmittedBy: submittedBy, Withdrawal: req, RecordID: w.ID}), nil
+}
+
+func (f *fakeOperator) ApproveWithdrawal(id, approvedBy string) (*caller.Withdrawal, error) {
+	return caller.ApproveWithdrawal(f.withdrawals, id, approvedBy)
+}
+
+func (f *fakeOperator) RejectWithdrawal(id, by, reason string) (*caller.Withdrawal, error) {
+	return caller.RejectWithdrawal(f.withdrawals, id, by, reason)
+}
+
+func (f *fakeOperator) SubmitReward(ctx context.Context, submittedBy string, entry *caller.RewardEntry) (*caller.Operation, error) {
+	return f.add(&caller.Operation{Kind: caller.OperationReward, SubmittedBy: submittedBy, Reward: entry}), nil
+}
+
+func (f *fakeOperator) Get(id string) (*caller.Operation, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, op := range f.ops {
+		if op.ID == id {
+			return op, nil
+		}
+	}
+	return nil, caller.ErrOperationNotFound
+}
+
+func (f *fakeOperator) List() ([]*caller.Operation, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]*caller.Operation{}, f.ops...), nil
+}
+
+func (f *fakeOperator) Cancel(id, by string) (*caller.Operation, error) {
+	op, err := f.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if op.Status != caller.OperationQueued {
+		return nil, errors.Wrapf(caller.ErrNotCancellable, "%s is %s", id, op.Status)
+	}
+	op.Status = caller.OperationCancelled
+	op.Error = "cancelled by " + by
+	return op, nil
+}
+
+func (f *fakeOperator) EthAddress(ctx context.Context) (common.Address, error) {
+	return ethAddr, nil
+}
+
+func (f *fakeOperator) JobStatuses() []caller.JobStatus {
+	return []caller.JobStatus{{Name: "deposit", Runs: 3}}
+}
+
+func newTestServer(t *testing.T) (*httptest.Server, *fakeOperator) {
+	op := &fakeOperator{withdrawals: caller.NewMemoryWithdrawalStore()}
+	s, err := api.New(api.Config{
+		Operator: op,
+		Tokens:   map[string]string{"payouts": payoutsToken, "rewards": rewardsToken},
+	})
+	require.Nil(t, err)
+	ts := httptest.NewServer(s.Handler())
+	t.Cleanup(ts.Close)
+	return ts, op
+}
+
+func do(t *testing.T, ts *httptest.Server, token, method, path string, body interface{}, out interface{}) int {
+	var reader bytes.Buffer
+	if body != nil {
+		require.Nil(t, json.NewEncoder(&reader).Encode(body))
+	}
+	req, err := http.NewRequest(method, ts.URL+path, &reader)
+	require.Nil(t, err)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	if out != nil {
+		require.Nil(t, json.NewDecoder(resp.Body).Decode(out))
+	}
+	return resp.StatusCode
+}
+
+func TestNewRefusesWeakTokens(t *testing.T) {
+	_, err := api.New(api.Config{Operator: &fakeOperator{}})
+	require.Error(t, err)
+	_, err = api.New(api.Config{Operator: &fakeOperator{}, Tokens: map[string]string{"short": "secret"}})
+	require.Error(t, err)
+	_, err = api.New(api.Config{Operator: &fakeOperator{}, Tokens: map[string]string{"a": payoutsToken, "b": payoutsToken}})
+	require.Error(t, err)
+}
+
+func TestUnauthenticated(t *testing.T) {
+	ts, _ := newTestServer(t)
+
+	require.Equal(t, http.StatusUnauthorized, do(t, ts, "", "GET", "/v1/operations", nil, nil))
+	require.Equal(t, http.StatusUnauthorized, do(t, ts, "not-a-client-token", "GET", "/v1/operations", nil, nil))
+	require.Equal(t, http.StatusUnauthorized, do(t, ts, "", "POST", "/rpc", nil, nil))
+}
+
+func TestRESTOperations(t *testing.T) {
+	ts, _ := newTestServer(t)
+
+	var op caller.Operation
+	status := do(t, ts, payoutsToken, "POST", "/v1/withdrawals", api.WithdrawalArgs{
+		Token: "eth", To: alice.Hex(), Amount: "500", Reason: "payout",
+	}, &op)
+	require.Equal(t, http.StatusAccepted, status)
+	require.Equal(t, "payouts", op.SubmittedBy)
+	require.Equal(t, ethAddr, op.Withdrawal.Token)
+	require.Equal(t, "payouts", op.Withdrawal.RequestedBy)
+	require.Equal(t, caller.OperationQueued, op.Status)
+
+	var apiErr struct{ Error string }
+	status = do(t, ts, payoutsToken, "POST", "/v1/withdrawals", api.WithdrawalArgs{
+		Token: usdt.Hex(), To: alice.Hex(), Amount: "5000",
+	}, &apiErr)
+	require.Equal(t, http.StatusUnprocessableEntity, status)
+	require.Contains(t, apiErr.Error, "over the daily limit")
+	status = do(t, ts, payoutsToken, "POST", "/v1/withdrawals", api.WithdrawalArgs{
+		Token: usdt.Hex(), To: "bob", Amount: "5",
+	}, nil)
+	require.Equal(t, http.StatusBadRequest, status)
+	status = do(t, ts, payoutsToken, "POST", "/v1/withdrawals", map[string]string{"recipient": alice.Hex()}, nil)
+	require.Equal(t, http.StatusBadRequest, status)
+
+	status = do(t, ts, rewardsToken, "POST", "/v1/rewards", api.RewardArgs{
+		Token: usdt.Hex(), Granter: alice.Hex(), Amount: "0x10", Epoch: 7,
+	}, &op)
+	require.Equal(t, http.StatusAccepted, status)
+	require.Equal(t, "rewards", op.SubmittedBy)
+	require.Equal(t, int64(16), op.Reward.Amount.Int64())
+	rewardID := op.ID
+
+	var cancelled caller.Operation
+	require.Equal(t, http.StatusOK, do(t, ts, payoutsToken, "POST", "/v1/operations/"+rewardID+"/cancel", nil, &cancelled))
+	require.Equal(t, caller.OperationCancelled, cancelled.Status)
+	require.Equal(t, "cancelled by payouts", cancelled.Error)
+	require.Equal(t, http.StatusConflict, do(t, ts, payoutsToken, "POST", "/v1/operations/"+rewardID+"/cancel", nil, nil))
+
+	var got caller.Operation
+	require.Equal(t, http.StatusOK, do(t, ts, payoutsToken, "GET", "/v1/operations/"+rewardID, nil, &got))
+	require.Equal(t, caller.OperationCancelled, got.Status)
+	require.Equal(t, http.StatusNotFound, do(t, ts, payoutsToken, "GET", "/v1/operations/op-9", nil, nil))
+
+	var ops []*caller.Operation
+	require.Equal(t, http.StatusOK, do(t, ts, payoutsToken, "GET", "/v1/operations", nil, &ops))
+	require.Len(t, ops, 2)
+	require.Equal(t, http.StatusOK, do(t, ts, payoutsToken, "GET", "/v1/operations?status=queued", nil, &ops))
+	require.Len(t, ops, 1)
+	require.Equal(t, caller.OperationWithdrawal, ops[0].Kind)
+	require.Equal(t, http.StatusOK, do(t, ts, payoutsToken, "GET", "/v1/operations?kind=reward", nil, &ops))
+	require.Len(t, ops, 1)
+	require.Equal(t, rewardID, ops[0].ID)
+
+	var jobs []caller.JobStatus
+	require.Equal(t, http.StatusOK, do(t, ts, payoutsToken, "GET", "/v1/jobs", nil, &jobs))
+	require.Equal(t, uint64(3), jobs[0].Runs)
+}
+
+func TestJSONRPCOperations(t *testing.T) {
+	ts, _ := newTestServer(t)
+	ctx := context.Background()
+
+	client, err := rpc.DialOptions(ctx, ts.URL+"/rpc", rpc.WithHeader("Authorization", "Bearer "+rewardsToken))
+	require.Nil(t, err)
+	defer client.Close()
+
+	var op caller.Operation
+	require.Nil(t, client.CallContext(ctx, &op, "admin_submitReward", api.RewardArgs{
+		Token: usdt.Hex(), Granter: alice.Hex(), Amount: "100", Epoch: 7, ID: "epoch-7-alice",
+	}))
+	require.Equal(t, "rewards", op.SubmittedBy)
+	require.Equal(t, "epoch-7-alice", op.Reward.ID)
+
+	var ops []*caller.Operation
+	require.Nil(t, client.CallContext(ctx, &ops, "admin_listOperations"))
+	require.Len(t, ops, 1)
+	require.Nil(t, client.CallContext(ctx, &ops, "admin_listOperations", api.ListFilter{Kind: caller.OperationWithdrawal}))
+	require.Len(t, ops, 0)
+
+	var got caller.Operation
+	require.Nil(t, client.CallContext(ctx, &got, "admin_getOperation", op.ID))
+	require.Equal(t, op.ID, got.ID)
+
+	err = client.CallContext(ctx, &op, "admin_submitWithdrawal", api.WithdrawalArgs{Token: "ETH", To: alice.Hex(), Amount: "-1"})
+	var rpcErr rpc.Error
+	require.True(t, errors.As(err, &rpcErr))
+	require.Equal(t, -32602, rpcErr.ErrorCode())
+	err = client.CallContext(ctx, &got, "admin_cancelOperation", "op-9")
+	require.ErrorContains(t, err, caller.ErrOperationNotFound.Error())
+
+	unauthenticated, err := rpc.DialOptions(ctx, ts.URL+"/rpc")
+	require.Nil(t, err)
+	defer unauthenticated.Close()
+	require.Error(t, unauthenticated.CallContext(ctx, &ops, "admin_listOperations"))
+}
+
+func TestApproveWithdrawal(t *testing.T) {
+	ts, _ := newTestServer(t)
+	ctx := context.Background()
+
+	var op caller.Operation
+	require.Equal(t, http.StatusAccepted, do(t, ts, payoutsToken, "POST", "/v1/withdrawals", api.WithdrawalArgs{
+		Token: "ETH", To: alice.Hex(), Amount: "500",
+	}, &op))
+	path := "/v1/withdrawals/" + op.RecordID
+
+	// the submitter cannot approve its own withdrawal
+	var apiErr struct{ Error string }
+	require.Equal(t, http.StatusUnprocessableEntity, do(t, ts, payoutsToken, "POST", path+"/approve", nil, &apiErr))
+	require.Contains(t, apiErr.Error, "someone other than its requester payouts")
+	require.Equal(t, http.StatusNotFound, do(t, ts, rewardsToken, "POST", "/v1/withdrawals/w-9/approve", nil, nil))
+
+	var w caller.Withdrawal
+	require.Equal(t, http.StatusOK, do(t, ts, rewardsToken, "POST", path+"/approve", nil, &w))
+	require.Equal(t, caller.WithdrawalApproved, w.Status)
+	require.Equal(t, "payouts", w.RequestedBy)
+	require.Equal(t, "rewards", w.ApprovedBy)
+	require.Equal(t, http.StatusUnprocessableEntity, do(t, ts, rewardsToken, "POST", path+"/approve", nil, nil))
+
+	client, err := rpc.DialOptions(ctx, ts.URL+"/rpc", rpc.WithHeader("Authorization", "Bearer "+payoutsToken))
+	require.Nil(t, err)
+	defer client.Close()
+	require.Nil(t, client.CallContext(ctx, &op, "admin_submitWithdrawal", api.WithdrawalArgs{Token: "ETH", To: alice.Hex(), Amount: "200"}))
+	err = client.CallContext(ctx, &w, "admin_approveWithdrawal", op.RecordID)
+	require.ErrorContains(t, err, "someone other than its requester payouts")
+	require.Nil(t, client.CallContext(ctx, &w, "admin_rejectWithdrawal", op.RecordID, api.RejectArgs{Reason: "duplicate"}))
+	require.Equal(t, caller.WithdrawalRejected, w.Status)
+	require.Equal(t, "payouts", w.RejectedBy)
+	require.Equal(t, "duplicate", w.Error)
+
+	require.Equal(t, http.StatusOK, do(t, ts, rewardsToken, "POST", path+"/reject", nil, &w))
+	require.Equal(t, caller.WithdrawalRejected, w.Status)
+	require.Equal(t, "rewards", w.RejectedBy)
+	require.Equal(t, http.StatusUnprocessableEntity, do(t, ts, rewardsToken, "POST", path+"/reject", api.RejectArgs{Reason: "again"}, nil))
+}
@@ -2,7 +2,6 @@ package caller
 
 import (
 	"context"
-	"crypto/ecdsa"
 	"math/big"
 	"strings"
 	"sync"
@@ -14,37 +13,49 @@ import (
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	ethc "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/the-web3/contracts-caller/bindings"
 	common2 "github.com/the-web3/contracts-caller/common"
+	"github.com/the-web3/contracts-caller/signer"
 	"github.com/the-web3/contracts-caller/txmgr"
 )
 
-type SignerFn func(context.Context, ethc.Address, *types.Transaction) (*types.Transaction, error)
-
-var (
-	errMaxPriorityFeePerGasNotFound = errors.New(
-		"Method eth_maxPriorityFeePerGas not found",
-	)
-	FallbackGasTipCap = big.NewInt(1500000000)
-)
-
 type ContractCallerConfig struct {
 	ChainClient               *ethclient.Client
 	ChainID                   *big.Int
 	TreasureManagerAddr       ethc.Address
 	WithdrawManageAddr        string
-	PrivateKey                *ecdsa.PrivateKey
+	Signer                    signer.Signer
 	LoopInterval              time.Duration
-	SignerFn                  SignerFn
 	NumConfirmations          uint64
 	SafeAbortNonceTooLowCount uint64
-	EnableHsm                 bool
-	HsmAPIName                string
-	HsmCreden                 string
-	HsmAddress                string
+	Journal                   txmgr.Journal
+	FeePolicy                 txmgr.FeePolicy
+	// ForceSend skips the pre-flight simulation of every transaction.
+	ForceSend bool
+	// Jobs run on their own schedules once started, DefaultJobs of
+	// LoopInterval when nil.
+	Jobs []JobSpec
+	// TokenWhitelist is the file or URL of the desired token whitelist,
+	// not managed when empty.
+	TokenWhitelist string
+	// ObserveOnly makes reconcilers report drift instead of correcting it.
+	ObserveOnly bool
+	// ApprovalThreshold is how many of Approvers must sign a proposal of
+	// one of ProposalMethods before it is sent, those methods are sent
+	// directly when 0.
+	ApprovalThreshold int
+	Approvers         []ethc.Address
+	// ProposalTTL is how long a proposal can collect approvals,
+	// DefaultProposalTTL when 0.
+	ProposalTTL time.Duration
+	// SafeAddress is the Safe owning the TreasureManager, every write to
+	// it is then executed by the Safe once enough owners signed it.
+	SafeAddress ethc.Address
+	// SafeTxStore keeps Safe transactions collecting signatures, a memory
+	// store when nil.
+	SafeTxStore SafeTxStore
 }
 
 type ContractCaller struct {
@@ -54,15 +65,30 @@ type ContractCaller struct {
 	RawTreasureManagerContract *bind.BoundContract
 	WalletAddr                 ethc.Address
 	TreasureManagerABI         *abi.ABI
+	SafeContract               *bindings.Safe
+	SafeABI                    *abi.ABI
+	safeMu                     sync.Mutex
 	txMgr                      txmgr.TxManager
+	nonceManager               *txmgr.NonceManager
+	jobs                       []*job
+	jobMu                      sync.Mutex
+	jobStatus                  map[string]*JobStatus
+	reconcilers                []*job
 	cancel                     func()
 	wg                         sync.WaitGroup
-	once                       sync.Once
+	// withdrawMu guards withdrawing, the IDs of the withdrawals being
+	// executed. withdrawLimitMu orders their policy checks.
+	withdrawMu      sync.Mutex
+	withdrawing     map[string]bool
+	withdrawLimitMu sync.Mutex
+	// proposalMu orders the checks of proposals being executed before they
+	// are recorded as sent, and guards executing, the IDs of those being
+	// executed.
+	proposalMu sync.Mutex
+	executing  map[string]bool
 }
 
 func NewContractCaller(ctx context.Context, cfg *ContractCallerConfig) (*ContractCaller, error) {
-	_, cancel := context.WithTimeout(ctx, time.Second*5)
-	defer cancel()
 	treasureManagerContract, err := bindings.NewTreasureManager(
 		ethc.Address(cfg.TreasureManagerAddr), cfg.ChainClient,
 	)
@@ -91,15 +117,18 @@ func NewContractCaller(ctx context.Context, cfg *ContractCallerConfig) (*Contrac
 		ReceiptQueryInterval:      time.Second,
 		NumConfirmations:          cfg.NumConfirmations,
 		SafeAbortNonceTooLowCount: cfg.SafeAbortNonceTooLowCount,
+		Journal:                   cfg.Journal,
 	}
 	txMgr := txmgr.NewSimpleTxManager(txManagerConfig, cfg.ChainClient)
-	var walletAddr ethc.Address
-	if cfg.EnableHsm {
-		walletAddr = ethc.HexToAddress(cfg.HsmAddress)
-	} else {
-		walletAddr = crypto.PubkeyToAddress(cfg.PrivateKey.PublicKey)
+	if cfg.FeePolicy == nil {
+		cfg.FeePolicy, err = txmgr.NewFeePolicy(txmgr.FeePolicyConfig{}, cfg.ChainClient)
+		if err != nil {
+			return nil, err
+		}
 	}
-	return &ContractCaller{
+	walletAddr := cfg.Signer.Address()
+	ctx, cancel := context.WithCancel(ctx)
+	c := &ContractCaller{
 		Cfg:                        cfg,
 		Ctx:                        ctx,
 		TreasureManagerContract:    treasureManagerContract,
@@ -107,40 +136,93 @@ func NewContractCaller(ctx context.Context, cfg *ContractCallerConfig) (*Contrac
 		WalletAddr:                 walletAddr,
 		TreasureManagerABI:         treasureManagerABI,
 		txMgr:                      txMgr,
+		nonceManager:               txmgr.NewNonceManager(cfg.ChainClient, walletAddr),
+		jobStatus:                  make(map[string]*JobStatus),
 		cancel:                     cancel,
-	}, nil
+	}
+
+	specs := cfg.Jobs
+	if specs == nil {
+		specs = DefaultJobs(cfg.LoopInterval)
+	}
+	for _, spec := range specs {
+		j, err := c.compileJob(spec)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := c.jobStatus[spec.Name]; ok {
+			return nil, errors.Errorf("duplicate job name %s", spec.Name)
+		}
+		c.jobs = append(c.jobs, j)
+		c.jobStatus[spec.Name] = &JobStatus{Name: spec.Name, Mode: j.spec.Mode}
+	}
+
+	if cfg.ApprovalThreshold < 0 || cfg.ApprovalThreshold > len(cfg.Approvers) {
+		return nil, errors.Errorf("approval threshold %d of %d approvers", cfg.ApprovalThreshold, len(cfg.Approvers))
+	}
+	if cfg.SafeAddress != (ethc.Address{}) {
+		if c.SafeContract, err = bindings.NewSafe(cfg.SafeAddress, cfg.ChainClient); err != nil {
+			return nil, err
+		}
+		if c.SafeABI, err = bindings.SafeMetaData.GetAbi(); err != nil {
+			return nil, err
+		}
+		if cfg.SafeTxStore == nil {
+			cfg.SafeTxStore = NewMemorySafeTxStore()
+		}
+	}
+	if cfg.WithdrawManageAddr != "" {
+		if _, err := common2.ParseAddress(cfg.WithdrawManageAddr); err != nil {
+			return nil, err
+		}
+		c.addReconciler(builtinJob("withdraw-manager", cfg.LoopInterval, c.reconcileWithdrawManager))
+	}
+	if cfg.TokenWhitelist != "" {
+		c.addReconciler(builtinJob("token-whitelist", cfg.LoopInterval, c.reconcileTokenWhitelist))
+	}
+	return c, nil
 }
 
+// UpdateGasPrice re-signs tx with the fees picked by the fee policy, priced
+// to replace tx if it is already pending.
 func (c *ContractCaller) UpdateGasPrice(ctx context.Context, tx *types.Transaction) (*types.Transaction, error) {
-	var opts *bind.TransactOpts
-	var err error
-	if !c.Cfg.EnableHsm {
-		opts, err = bind.NewKeyedTransactorWithChainID(
-			c.Cfg.PrivateKey, c.Cfg.ChainID,
-		)
-	} else {
-		opts, err = common2.NewHSMTransactOpts(ctx, c.Cfg.HsmAPIName,
-			c.Cfg.HsmAddress, c.Cfg.ChainID, c.Cfg.HsmCreden)
-	}
+	return c.signWithFees(ctx, tx, tx)
+}
+
+func (c *ContractCaller) signWithFees(ctx context.Context, tx, prev *types.Transaction) (*types.Transaction, error) {
+	gasTipCap, gasFeeCap, err := c.Cfg.FeePolicy.Fees(ctx, prev)
 	if err != nil {
 		return nil, err
 	}
-	opts.Context = ctx
-	opts.Nonce = new(big.Int).SetUint64(tx.Nonce())
-	opts.NoSend = true
-
-	finalTx, err := c.RawTreasureManagerContract.RawTransact(opts, tx.Data())
-	switch {
-	case err == nil:
-		return finalTx, nil
+	replacement := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   c.Cfg.ChainID,
+		Nonce:     tx.Nonce(),
+		GasTipCap: gasTipCap,
+		GasFeeCap: gasFeeCap,
+		Gas:       tx.Gas(),
+		To:        tx.To(),
+		Value:     tx.Value(),
+		Data:      tx.Data(),
+	})
+	return c.Cfg.Signer.SignTx(ctx, replacement, c.Cfg.ChainID)
+}
 
-	case c.IsMaxPriorityFeePerGasNotFoundError(err):
-		log.Info("MtChallenger eth_maxPriorityFeePerGas is unsupported by current backend, using fallback gasTipCap", "txData", tx.Data())
-		opts.GasTipCap = FallbackGasTipCap
-		return c.RawTreasureManagerContract.RawTransact(opts, tx.Data())
+// gasPriceUpdater returns the txmgr callback that signs every attempt of tx,
+// each one priced against the attempt before it. prev is the last attempt
+// already broadcast, nil for a fresh transaction.
+func (c *ContractCaller) gasPriceUpdater(tx, prev *types.Transaction) txmgr.UpdateGasPriceFunc {
+	var mu sync.Mutex
+	return func(ctx context.Context) (*types.Transaction, error) {
+		mu.Lock()
+		defer mu.Unlock()
 
-	default:
-		return nil, err
+		next, err := c.signWithFees(ctx, tx, prev)
+		if err != nil {
+			return nil, err
+		}
+		reportSigned(ctx, next)
+		prev = next
+		return next, nil
 	}
 }
 
@@ -148,90 +230,85 @@ func (c *ContractCaller) SendTransaction(ctx context.Context, tx *types.Transact
 	return c.Cfg.ChainClient.SendTransaction(ctx, tx)
 }
 
-func (c *ContractCaller) IsMaxPriorityFeePerGasNotFoundError(err error) bool {
-	return strings.Contains(
-		err.Error(), errMaxPriorityFeePerGasNotFound.Error(),
-	)
+// decodeRevert fills in the reason of a reverted transaction from the errors
+// of the target contract, e.g. AccessControlUnauthorizedAccount.
+func (c *ContractCaller) decodeRevert(err error, contractABI *abi.ABI) error {
+	var revertErr *txmgr.RevertError
+	if !errors.As(err, &revertErr) || revertErr.Reason != "" {
+		return err
+	}
+	reason, decodeErr := common2.DecodeRevert(revertErr.Data, contractABI)
+	if decodeErr != nil {
+		log.Warn("Contract caller unable to decode revert reason", "TxHash", revertErr.Receipt.TxHash, "err", decodeErr)
+		return err
+	}
+	revertErr.Reason = reason
+	return err
 }
 
-func (c *ContractCaller) setWithdrawManagerTx(ctx context.Context, address ethc.Address) (*types.Transaction, error) {
-	balance, err := c.Cfg.ChainClient.BalanceAt(
-		c.Ctx, ethc.Address(c.WalletAddr), nil,
-	)
-	if err != nil {
-		log.Error("Contract caller unable to get current balance", "err", err)
-		return nil, err
+func (c *ContractCaller) releaseNonce(nonce uint64) {
+	c.nonceManager.Release(nonce)
+	if err := c.nonceManager.Resync(c.Ctx); err != nil {
+		log.Error("Contract caller unable to resync nonce", "err", err)
 	}
-	log.Info("Contract wallet address balance", "balance", balance)
+}
 
-	nonce64, err := c.Cfg.ChainClient.NonceAt(
-		c.Ctx, ethc.Address(c.WalletAddr), nil,
-	)
-	if err != nil {
-		log.Error("Contract wallet unable to get current nonce", "err", err)
-		return nil, err
-	}
-	nonce := new(big.Int).SetUint64(nonce64)
-	var opts *bind.TransactOpts
-	if !c.Cfg.EnableHsm {
-		opts, err = bind.NewKeyedTransactorWithChainID(
-			c.Cfg.PrivateKey, c.Cfg.ChainID,
-		)
-	} else {
-		opts, err = common2.NewHSMTransactOpts(ctx, c.Cfg.HsmAPIName,
-			c.Cfg.HsmAddress, c.Cfg.ChainID, c.Cfg.HsmCreden)
+func (c *ContractCaller) addReconciler(j *job) {
+	c.reconcilers = append(c.reconcilers, j)
+	c.jobStatus[j.spec.Name] = &JobStatus{Name: j.spec.Name, Mode: j.spec.Mode}
+}
+
+// resumeJournal finishes every transaction left in flight by a previous run
+// before new work is accepted, so no nonce is reused or left stuck.
+func (c *ContractCaller) resumeJournal() error {
+	if c.Cfg.Journal == nil {
+		return nil
 	}
+	entries, err := c.Cfg.Journal.Pending()
 	if err != nil {
-		return nil, err
+		return err
 	}
-	opts.Context = ctx
-	opts.Nonce = nonce
-	opts.NoSend = true
-
-	tx, err := c.TreasureManagerContract.SetWithdrawManager(opts, address)
-	switch {
-	case err == nil:
-		return tx, nil
+	if len(entries) == 0 {
+		return nil
+	}
+	log.Info("Contract caller resuming journaled transactions", "count", len(entries))
 
-	case c.IsMaxPriorityFeePerGasNotFoundError(err):
-		log.Warn("contract caller eth_maxPriorityFeePerGas is unsupported by current backend, using fallback gasTipCap")
-		opts.GasTipCap = FallbackGasTipCap
-		return c.TreasureManagerContract.SetWithdrawManager(opts, address)
-	default:
-		return nil, err
+	var wg sync.WaitGroup
+	for _, entry := range entries {
+		txs, err := entry.Transactions()
+		if err != nil || len(txs) == 0 {
+			log.Error("Contract caller skip unreadable journal entry", "nonce", entry.Nonce, "err", err)
+			continue
+		}
+		latest := txs[len(txs)-1]
+		updateGasPrice := c.gasPriceUpdater(latest, latest)
+		wg.Add(1)
+		go func(entry *txmgr.JournalEntry) {
+			defer wg.Done()
+			receipt, err := c.txMgr.Resume(c.Ctx, entry, updateGasPrice, c.SendTransaction)
+			if err != nil {
+				log.Error("Contract caller resume journaled transaction fail", "nonce", entry.Nonce, "err", c.decodeRevert(err, c.TreasureManagerABI))
+				return
+			}
+			log.Info("Contract caller journaled transaction mined", "nonce", entry.Nonce, "TxHash", receipt.TxHash)
+		}(entry)
 	}
+	wg.Wait()
+	return c.nonceManager.Resync(c.Ctx)
 }
 
-func (c *ContractCaller) setWithdrawManager(address string) (*types.Transaction, error) {
-	tx, err := c.setWithdrawManagerTx(c.Ctx, ethc.HexToAddress(address))
-	if err != nil {
-		return nil, err
+func (c *ContractCaller) Start() error {
+	if err := c.resumeJournal(); err != nil {
+		return err
 	}
-	updateGasPrice := func(ctx context.Context) (*types.Transaction, error) {
-		log.Info("Contract caller setWithdrawManager update gas price")
-		return c.UpdateGasPrice(ctx, tx)
+	// reconcile right away, then keep checking for drift every loop
+	for _, j := range c.reconcilers {
+		c.runJobOnce(j)
 	}
-	receipt, err := c.txMgr.Send(
-		c.Ctx, updateGasPrice, c.SendTransaction,
-	)
-	if err != nil {
-		return nil, err
+	for _, j := range append(c.reconcilers, c.jobs...) {
+		c.wg.Add(1)
+		go c.runJob(j)
 	}
-	log.Info("Contract caller set withdraw manager success", "TxHash", receipt.TxHash)
-	return tx, nil
-}
-
-func (c *ContractCaller) Start() error {
-	c.wg.Add(1)
-	go c.eventLoop()
-	c.once.Do(func() {
-		log.Info("Contract caller start exec set withdraw manager")
-		tx, err := c.setWithdrawManager(c.Cfg.WithdrawManageAddr)
-		if err != nil {
-			log.Error("Contract caller set withdraw manager fail", "WithdrawManageAddr", c.Cfg.WithdrawManageAddr, "err", err)
-		}
-		log.Info("Contract caller set withdraw manager success", "WithdrawManageAddr", c.Cfg.WithdrawManageAddr, "txHash", tx.Hash().String())
-	})
 	return nil
 }
 
@@ -239,33 +316,3 @@ func (c *ContractCaller) Stop() {
 	c.cancel()
 	c.wg.Wait()
 }
-
-func (c *ContractCaller) eventLoop() {
-	defer c.wg.Done()
-	ticker := time.NewTicker(c.Cfg.LoopInterval)
-	defer ticker.Stop()
-	for {
-		select {
-		case <-ticker.C:
-			log.Info("Contract caller get loop")
-			addressList, err := c.TreasureManagerContract.GetTokenWhiteList(&bind.CallOpts{})
-			if err != nil {
-				log.Error("get token white list fail", "err", err)
-				continue
-			}
-			for _, address := range addressList {
-				log.Info("token white list address", "address", address.String())
-			}
-
-			withdrawManagerAddr, _ := c.TreasureManagerContract.WithdrawManager(&bind.CallOpts{})
-			log.Info("withdraw manager address", "withdrawManagerAddr", withdrawManagerAddr.String())
-
-			treasureManageAddress, _ := c.TreasureManagerContract.TreasureManager(&bind.CallOpts{})
-			log.Info("treasure manage address", "treasureManageAddress", treasureManageAddress.String())
-
-		case err := <-c.Ctx.Done():
-			log.Error("Contract caller service shutting down", "err", err)
-			return
-		}
-	}
-}
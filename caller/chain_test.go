@@ -0,0 +1,766 @@
+package caller
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"math"
+	"math/big"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/trie"
+
+	"github.com/the-web3/contracts-caller/bindings"
+	"github.com/the-web3/contracts-caller/signer"
+)
+
+const testBlockGasLimit = 30_000_000
+
+var testBaseFee = big.NewInt(params.GWei)
+
+// testChain runs transactions in the geth EVM and serves the JSON-RPC
+// methods the caller uses, in process. A sent transaction is mined right
+// away in a block of its own unless mining is paused, then it waits in the
+// pool like in a mempool.
+type testChain struct {
+	t      *testing.T
+	config *params.ChainConfig
+	signer types.Signer
+	sdb    state.Database
+	client *ethclient.Client
+
+	mu     sync.Mutex
+	blocks []*testBlock
+	pool   map[common.Hash]*types.Transaction
+	paused bool
+	// refuse fails every sent transaction when set
+	refuse error
+	subs   map[*testLogSub]struct{}
+	// writes are state changes applied by the next mined block
+	writes []func(statedb *state.StateDB)
+}
+
+type testBlock struct {
+	header   *types.Header
+	txs      []*types.Transaction
+	receipts []*types.Receipt
+}
+
+type testLogSub struct {
+	filter testFilter
+	logs   chan *types.Log
+}
+
+// newTestChain starts a chain funding each of accounts with 1000 ETH.
+func newTestChain(t *testing.T, accounts ...common.Address) *testChain {
+	c := &testChain{
+		t:      t,
+		config: params.AllDevChainProtocolChanges,
+		signer: types.LatestSigner(params.AllDevChainProtocolChanges),
+		sdb:    state.NewDatabase(rawdb.NewMemoryDatabase()),
+		pool:   make(map[common.Hash]*types.Transaction),
+		subs:   make(map[*testLogSub]struct{}),
+	}
+	statedb, err := state.New(types.EmptyRootHash, c.sdb, nil)
+	require.Nil(t, err)
+	balance := new(big.Int).Mul(big.NewInt(1000), big.NewInt(params.Ether))
+	for _, account := range accounts {
+		statedb.AddBalance(account, uint256.MustFromBig(balance), tracing.BalanceChangeUnspecified)
+	}
+	root, err := statedb.Commit(0, true)
+	require.Nil(t, err)
+	c.blocks = []*testBlock{{header: &types.Header{
+		Number:      new(big.Int),
+		Root:        root,
+		GasLimit:    testBlockGasLimit,
+		BaseFee:     testBaseFee,
+		Difficulty:  new(big.Int),
+		UncleHash:   types.EmptyUncleHash,
+		TxHash:      types.EmptyTxsHash,
+		ReceiptHash: types.EmptyReceiptsHash,
+		Time:        uint64(time.Now().Unix()),
+	}}}
+
+	server := rpc.NewServer()
+	require.Nil(t, server.RegisterName("eth", &testEthAPI{c}))
+	c.client = ethclient.NewClient(rpc.DialInProc(server))
+	t.Cleanup(func() {
+		c.client.Close()
+		server.Stop()
+	})
+	return c
+}
+
+func (c *testChain) head() *testBlock {
+	return c.blocks[len(c.blocks)-1]
+}
+
+func (c *testChain) block(number rpc.BlockNumber) *testBlock {
+	if number < 0 || int(number) >= len(c.blocks) {
+		return c.head()
+	}
+	return c.blocks[number]
+}
+
+func (c *testChain) stateAt(b *testBlock) *state.StateDB {
+	statedb, err := state.New(b.header.Root, c.sdb, nil)
+	require.Nil(c.t, err)
+	return statedb
+}
+
+func (c *testChain) blockContext(header *types.Header) vm.BlockContext {
+	return vm.BlockContext{
+		CanTransfer: core.CanTransfer,
+		Transfer:    core.Transfer,
+		GetHash: func(n uint64) common.Hash {
+			if n < uint64(len(c.blocks)) {
+				return c.blocks[n].header.Hash()
+			}
+			return common.Hash{}
+		},
+		GasLimit:    header.GasLimit,
+		BlockNumber: header.Number,
+		Time:        header.Time,
+		Difficulty:  new(big.Int),
+		BaseFee:     header.BaseFee,
+		BlobBaseFee: big.NewInt(1),
+		Random:      &common.Hash{},
+	}
+}
+
+// Mine mines a block of every executable transaction in the pool.
+func (c *testChain) Mine() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.mine()
+}
+
+// Pause keeps sent transactions in the pool until Mine or Resume.
+func (c *testChain) Pause() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.paused = true
+}
+
+func (c *testChain) Resume() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.paused = false
+	c.mine()
+}
+
+// Refuse fails the transactions sent from now on with err, none when nil,
+// as an unreachable node would.
+func (c *testChain) Refuse(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.refuse = err
+}
+
+// Drop removes a transaction from the pool, as a node evicting it would.
+func (c *testChain) Drop(hash common.Hash) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.pool, hash)
+}
+
+// Rollback drops the last n blocks and their transactions, as a reorg onto
+// a chain without them would, telling log subscribers about the removed
+// logs.
+func (c *testChain) Rollback(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	dropped := c.blocks[len(c.blocks)-n:]
+	c.blocks = c.blocks[:len(c.blocks)-n]
+	for i := len(dropped) - 1; i >= 0; i-- {
+		for _, receipt := range dropped[i].receipts {
+			for _, l := range receipt.Logs {
+				removed := *l
+				removed.Removed = true
+				c.notify(&removed)
+			}
+		}
+	}
+}
+
+// SetStorage writes a storage slot of addr in a block of its own, for state
+// no transaction can reach.
+func (c *testChain) SetStorage(addr common.Address, slot, value common.Hash) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.writes = append(c.writes, func(statedb *state.StateDB) {
+		statedb.SetState(addr, slot, value)
+	})
+	c.mine()
+}
+
+func (c *testChain) mine() {
+	parent := c.head().header
+	header := &types.Header{
+		ParentHash: parent.Hash(),
+		Number:     new(big.Int).Add(parent.Number, common.Big1),
+		GasLimit:   testBlockGasLimit,
+		BaseFee:    testBaseFee,
+		Difficulty: new(big.Int),
+		UncleHash:  types.EmptyUncleHash,
+		Time:       parent.Time + 1,
+	}
+	if now := uint64(time.Now().Unix()); now > header.Time {
+		header.Time = now
+	}
+	statedb := c.stateAt(c.head())
+	for _, write := range c.writes {
+		write(statedb)
+	}
+	c.writes = nil
+	gp := new(core.GasPool).AddGas(header.GasLimit)
+	var usedGas uint64
+	var txs []*types.Transaction
+	var receipts []*types.Receipt
+	for _, tx := range c.executable(statedb) {
+		delete(c.pool, tx.Hash())
+		msg, err := core.TransactionToMessage(tx, c.signer, header.BaseFee)
+		require.Nil(c.t, err)
+		statedb.SetTxContext(tx.Hash(), len(txs))
+		evm := vm.NewEVM(c.blockContext(header), vm.TxContext{}, statedb, c.config, vm.Config{})
+		receipt, err := core.ApplyTransactionWithEVM(msg, c.config, gp, statedb, header.Number, common.Hash{}, tx, &usedGas, evm)
+		if err != nil {
+			c.t.Logf("test chain dropped transaction %s: %v", tx.Hash(), err)
+			continue
+		}
+		if receipt.Logs == nil {
+			receipt.Logs = []*types.Log{}
+		}
+		txs = append(txs, tx)
+		receipts = append(receipts, receipt)
+	}
+	root, err := statedb.Commit(header.Number.Uint64(), true)
+	require.Nil(c.t, err)
+	header.Root = root
+	header.GasUsed = usedGas
+	header.TxHash = types.DeriveSha(types.Transactions(txs), trie.NewStackTrie(nil))
+	header.ReceiptHash = types.DeriveSha(types.Receipts(receipts), trie.NewStackTrie(nil))
+	header.Bloom = types.CreateBloom(receipts)
+
+	hash := header.Hash()
+	for _, receipt := range receipts {
+		receipt.BlockHash = hash
+		for _, l := range receipt.Logs {
+			l.BlockHash = hash
+		}
+	}
+	c.blocks = append(c.blocks, &testBlock{header: header, txs: txs, receipts: receipts})
+	for _, receipt := range receipts {
+		for _, l := range receipt.Logs {
+			c.notify(l)
+		}
+	}
+}
+
+// executable returns the pool transactions that follow the nonces in
+// statedb, by sender and nonce.
+func (c *testChain) executable(statedb *state.StateDB) []*types.Transaction {
+	bySender := make(map[common.Address][]*types.Transaction)
+	for _, tx := range c.pool {
+		from, _ := types.Sender(c.signer, tx)
+		bySender[from] = append(bySender[from], tx)
+	}
+	var executable []*types.Transaction
+	for from, txs := range bySender {
+		sort.Slice(txs, func(i, j int) bool { return txs[i].Nonce() < txs[j].Nonce() })
+		nonce := statedb.GetNonce(from)
+		for _, tx := range txs {
+			if tx.Nonce() != nonce {
+				break
+			}
+			executable = append(executable, tx)
+			nonce++
+		}
+	}
+	return executable
+}
+
+func (c *testChain) pendingNonce(from common.Address) uint64 {
+	nonce := c.stateAt(c.head()).GetNonce(from)
+	for {
+		found := false
+		for _, tx := range c.pool {
+			if sender, _ := types.Sender(c.signer, tx); sender == from && tx.Nonce() == nonce {
+				found = true
+				nonce++
+			}
+		}
+		if !found {
+			return nonce
+		}
+	}
+}
+
+func (c *testChain) notify(l *types.Log) {
+	for sub := range c.subs {
+		if sub.filter.matches(l) {
+			select {
+			case sub.logs <- l:
+			default:
+				c.t.Logf("test chain log subscriber fell behind")
+			}
+		}
+	}
+}
+
+func (c *testChain) sendTransaction(tx *types.Transaction) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.refuse != nil {
+		return c.refuse
+	}
+
+	from, err := types.Sender(c.signer, tx)
+	if err != nil {
+		return err
+	}
+	if _, ok := c.pool[tx.Hash()]; ok {
+		return errors.New("already known")
+	}
+	if tx.Nonce() < c.stateAt(c.head()).GetNonce(from) {
+		return core.ErrNonceTooLow
+	}
+	for hash, pending := range c.pool {
+		if sender, _ := types.Sender(c.signer, pending); sender != from || pending.Nonce() != tx.Nonce() {
+			continue
+		}
+		if tx.GasTipCap().Cmp(minBump(pending.GasTipCap())) < 0 || tx.GasFeeCap().Cmp(minBump(pending.GasFeeCap())) < 0 {
+			return errors.New("replacement transaction underpriced")
+		}
+		delete(c.pool, hash)
+	}
+	c.pool[tx.Hash()] = tx
+	if !c.paused {
+		c.mine()
+	}
+	return nil
+}
+
+func minBump(fee *big.Int) *big.Int {
+	bumped := new(big.Int).Mul(fee, big.NewInt(110))
+	return bumped.Div(bumped, big.NewInt(100))
+}
+
+// call runs args against the state of b.
+func (c *testChain) call(args testCallArgs, b *testBlock, gas uint64) (*core.ExecutionResult, error) {
+	statedb := c.stateAt(b)
+	data := args.Input
+	if len(data) == 0 {
+		data = args.Data
+	}
+	msg := &core.Message{
+		From:              args.From,
+		To:                args.To,
+		Value:             new(big.Int),
+		GasLimit:          gas,
+		GasPrice:          new(big.Int),
+		GasFeeCap:         new(big.Int),
+		GasTipCap:         new(big.Int),
+		Data:              data,
+		SkipAccountChecks: true,
+	}
+	if args.Value != nil {
+		msg.Value = args.Value.ToInt()
+	}
+	evm := vm.NewEVM(c.blockContext(b.header), core.NewEVMTxContext(msg), statedb, c.config, vm.Config{NoBaseFee: true})
+	return core.ApplyMessage(evm, msg, new(core.GasPool).AddGas(math.MaxUint64))
+}
+
+func (c *testChain) findTx(hash common.Hash) (*types.Transaction, *testBlock, *types.Receipt) {
+	if tx, ok := c.pool[hash]; ok {
+		return tx, nil, nil
+	}
+	for _, b := range c.blocks {
+		for i, tx := range b.txs {
+			if tx.Hash() == hash {
+				return tx, b, b.receipts[i]
+			}
+		}
+	}
+	return nil, nil, nil
+}
+
+// Transactor returns options sending from key on this chain.
+func (c *testChain) Transactor(key *ecdsa.PrivateKey) *bind.TransactOpts {
+	opts, err := bind.NewKeyedTransactorWithChainID(key, c.config.ChainID)
+	require.Nil(c.t, err)
+	return opts
+}
+
+// Wait returns the receipt of a transaction sent with a Transactor, which
+// must have succeeded.
+func (c *testChain) Wait(tx *types.Transaction, err error) *types.Receipt {
+	require.Nil(c.t, err)
+	receipt, err := c.client.TransactionReceipt(context.Background(), tx.Hash())
+	require.Nil(c.t, err)
+	require.Equal(c.t, types.ReceiptStatusSuccessful, receipt.Status)
+	return receipt
+}
+
+// DeployTreasureManager deploys and initializes a TreasureManager owned and
+// managed by owner, with 10 ETH deposited and ETH whitelisted.
+func (c *testChain) DeployTreasureManager(key *ecdsa.PrivateKey, owner common.Address) (common.Address, *bindings.TreasureManager) {
+	opts := c.Transactor(key)
+	addr, tx, tm, err := bindings.DeployTreasureManager(opts, c.client)
+	c.Wait(tx, err)
+	c.Wait(tm.Initialize(opts, owner, owner, owner))
+	eth, err := tm.EthAddress(nil)
+	require.Nil(c.t, err)
+	if owner == crypto.PubkeyToAddress(key.PublicKey) {
+		c.Wait(tm.SetTokenWhiteList(opts, eth))
+	}
+	opts.Value = new(big.Int).Mul(big.NewInt(10), big.NewInt(params.Ether))
+	c.Wait(tm.DepositETH(opts))
+	return addr, tm
+}
+
+// DeploySafe deploys a Safe singleton and a proxy of it owned by owners,
+// needing threshold of their signatures.
+func (c *testChain) DeploySafe(key *ecdsa.PrivateKey, owners []common.Address, threshold int64) (common.Address, *bindings.Safe) {
+	opts := c.Transactor(key)
+	singleton, tx, _, err := bindings.DeploySafe(opts, c.client)
+	c.Wait(tx, err)
+	_, tx, factory, err := bindings.DeploySafeProxyFactory(opts, c.client)
+	c.Wait(tx, err)
+	safeABI, err := bindings.SafeMetaData.GetAbi()
+	require.Nil(c.t, err)
+	setup, err := safeABI.Pack("setup", owners, big.NewInt(threshold), common.Address{}, []byte{},
+		common.Address{}, common.Address{}, new(big.Int), common.Address{})
+	require.Nil(c.t, err)
+	receipt := c.Wait(factory.CreateProxyWithNonce(opts, singleton, setup, new(big.Int)))
+	for _, l := range receipt.Logs {
+		if created, err := factory.ParseProxyCreation(*l); err == nil {
+			safe, err := bindings.NewSafe(created.Proxy, c.client)
+			require.Nil(c.t, err)
+			return created.Proxy, safe
+		}
+	}
+	c.t.Fatal("no ProxyCreation event")
+	return common.Address{}, nil
+}
+
+// NewCaller returns a caller of tmAddr sending from key, with cfg filled in
+// for this chain.
+func (c *testChain) NewCaller(key *ecdsa.PrivateKey, tmAddr common.Address, cfg ContractCallerConfig) *ContractCaller {
+	cfg.ChainClient = c.client
+	cfg.ChainID = c.config.ChainID
+	cfg.TreasureManagerAddr = tmAddr
+	cfg.Signer = signer.NewPrivateKeySigner(key)
+	if cfg.LoopInterval == 0 {
+		cfg.LoopInterval = time.Second
+	}
+	if cfg.NumConfirmations == 0 {
+		cfg.NumConfirmations = 1
+	}
+	if cfg.SafeAbortNonceTooLowCount == 0 {
+		cfg.SafeAbortNonceTooLowCount = 3
+	}
+	if cfg.Jobs == nil {
+		cfg.Jobs = []JobSpec{}
+	}
+	cc, err := NewContractCaller(context.Background(), &cfg)
+	require.Nil(c.t, err)
+	c.t.Cleanup(cc.Stop)
+	return cc
+}
+
+func newTestKey(t *testing.T) (*ecdsa.PrivateKey, common.Address) {
+	key, err := crypto.GenerateKey()
+	require.Nil(t, err)
+	return key, crypto.PubkeyToAddress(key.PublicKey)
+}
+
+type testCallArgs struct {
+	From  common.Address  `json:"from"`
+	To    *common.Address `json:"to"`
+	Gas   *hexutil.Uint64 `json:"gas"`
+	Value *hexutil.Big    `json:"value"`
+	Data  hexutil.Bytes   `json:"data"`
+	Input hexutil.Bytes   `json:"input"`
+}
+
+type testFilter struct {
+	BlockHash *common.Hash     `json:"blockHash"`
+	FromBlock *rpc.BlockNumber `json:"fromBlock"`
+	ToBlock   *rpc.BlockNumber `json:"toBlock"`
+	Addresses []common.Address `json:"address"`
+	Topics    [][]common.Hash  `json:"topics"`
+}
+
+func (f *testFilter) matches(l *types.Log) bool {
+	if len(f.Addresses) > 0 {
+		found := false
+		for _, addr := range f.Addresses {
+			found = found || addr == l.Address
+		}
+		if !found {
+			return false
+		}
+	}
+	for i, topics := range f.Topics {
+		if len(topics) == 0 {
+			continue
+		}
+		if i >= len(l.Topics) {
+			return false
+		}
+		found := false
+		for _, topic := range topics {
+			found = found || topic == l.Topics[i]
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// testRevertError carries revert data the way a node does.
+type testRevertError struct {
+	data []byte
+}
+
+func (e *testRevertError) Error() string          { return "execution reverted" }
+func (e *testRevertError) ErrorCode() int         { return 3 }
+func (e *testRevertError) ErrorData() interface{} { return hexutil.Encode(e.data) }
+
+func resultError(result *core.ExecutionResult) error {
+	if errors.Is(result.Err, vm.ErrExecutionReverted) {
+		return &testRevertError{data: result.Revert()}
+	}
+	return result.Err
+}
+
+// testEthAPI is the eth namespace of testChain.
+type testEthAPI struct {
+	c *testChain
+}
+
+func (api *testEthAPI) ChainId() *hexutil.Big {
+	return (*hexutil.Big)(api.c.config.ChainID)
+}
+
+func (api *testEthAPI) BlockNumber() hexutil.Uint64 {
+	api.c.mu.Lock()
+	defer api.c.mu.Unlock()
+	return hexutil.Uint64(api.c.head().header.Number.Uint64())
+}
+
+func (api *testEthAPI) GetBlockByNumber(number rpc.BlockNumber, full bool) *types.Header {
+	api.c.mu.Lock()
+	defer api.c.mu.Unlock()
+	if number >= 0 && int(number) >= len(api.c.blocks) {
+		return nil
+	}
+	return api.c.block(number).header
+}
+
+func (api *testEthAPI) GetBlockByHash(hash common.Hash, full bool) *types.Header {
+	api.c.mu.Lock()
+	defer api.c.mu.Unlock()
+	for _, b := range api.c.blocks {
+		if b.header.Hash() == hash {
+			return b.header
+		}
+	}
+	return nil
+}
+
+func (api *testEthAPI) GetTransactionCount(addr common.Address, block rpc.BlockNumberOrHash) hexutil.Uint64 {
+	api.c.mu.Lock()
+	defer api.c.mu.Unlock()
+	if number, ok := block.Number(); ok && number == rpc.PendingBlockNumber {
+		return hexutil.Uint64(api.c.pendingNonce(addr))
+	}
+	return hexutil.Uint64(api.c.stateAt(api.c.head()).GetNonce(addr))
+}
+
+func (api *testEthAPI) GetBalance(addr common.Address, block rpc.BlockNumberOrHash) *hexutil.Big {
+	api.c.mu.Lock()
+	defer api.c.mu.Unlock()
+	number, _ := block.Number()
+	return (*hexutil.Big)(api.c.stateAt(api.c.block(number)).GetBalance(addr).ToBig())
+}
+
+func (api *testEthAPI) GetCode(addr common.Address, block rpc.BlockNumberOrHash) hexutil.Bytes {
+	api.c.mu.Lock()
+	defer api.c.mu.Unlock()
+	number, _ := block.Number()
+	return api.c.stateAt(api.c.block(number)).GetCode(addr)
+}
+
+func (api *testEthAPI) Call(args testCallArgs, block *rpc.BlockNumberOrHash) (hexutil.Bytes, error) {
+	api.c.mu.Lock()
+	defer api.c.mu.Unlock()
+	b := api.c.head()
+	if block != nil {
+		number, _ := block.Number()
+		b = api.c.block(number)
+	}
+	result, err := api.c.call(args, b, testBlockGasLimit)
+	if err != nil {
+		return nil, err
+	}
+	if result.Failed() {
+		return nil, resultError(result)
+	}
+	return result.Return(), nil
+}
+
+// EstimateGas searches the lowest gas args succeed with, as geth does.
+func (api *testEthAPI) EstimateGas(args testCallArgs, block *rpc.BlockNumberOrHash) (hexutil.Uint64, error) {
+	api.c.mu.Lock()
+	defer api.c.mu.Unlock()
+	b := api.c.head()
+	result, err := api.c.call(args, b, testBlockGasLimit)
+	if err != nil {
+		return 0, err
+	}
+	if result.Failed() {
+		return 0, resultError(result)
+	}
+	lo, hi := result.UsedGas-1, uint64(testBlockGasLimit)
+	for lo+1 < hi {
+		mid := (lo + hi) / 2
+		result, err := api.c.call(args, b, mid)
+		if err != nil || result.Failed() {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return hexutil.Uint64(hi), nil
+}
+
+func (api *testEthAPI) MaxPriorityFeePerGas() *hexutil.Big {
+	return (*hexutil.Big)(big.NewInt(params.GWei))
+}
+
+func (api *testEthAPI) GasPrice() *hexutil.Big {
+	return (*hexutil.Big)(new(big.Int).Mul(big.NewInt(3), big.NewInt(params.GWei)))
+}
+
+func (api *testEthAPI) SendRawTransaction(raw hexutil.Bytes) (common.Hash, error) {
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(raw); err != nil {
+		return common.Hash{}, err
+	}
+	return tx.Hash(), api.c.sendTransaction(tx)
+}
+
+func (api *testEthAPI) GetTransactionReceipt(hash common.Hash) *types.Receipt {
+	api.c.mu.Lock()
+	defer api.c.mu.Unlock()
+	_, _, receipt := api.c.findTx(hash)
+	return receipt
+}
+
+func (api *testEthAPI) GetTransactionByHash(hash common.Hash) (map[string]interface{}, error) {
+	api.c.mu.Lock()
+	defer api.c.mu.Unlock()
+	tx, b, _ := api.c.findTx(hash)
+	if tx == nil {
+		return nil, nil
+	}
+	fields, err := tx.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	var out map[string]interface{}
+	if err := json.Unmarshal(fields, &out); err != nil {
+		return nil, err
+	}
+	from, _ := types.Sender(api.c.signer, tx)
+	out["from"] = from
+	if b != nil {
+		out["blockNumber"] = (*hexutil.Big)(b.header.Number)
+		out["blockHash"] = b.header.Hash()
+	}
+	return out, nil
+}
+
+func (api *testEthAPI) GetLogs(filter testFilter) ([]*types.Log, error) {
+	api.c.mu.Lock()
+	defer api.c.mu.Unlock()
+	from, to := rpc.BlockNumber(0), rpc.BlockNumber(len(api.c.blocks)-1)
+	if filter.FromBlock != nil && *filter.FromBlock >= 0 {
+		from = *filter.FromBlock
+	}
+	if filter.ToBlock != nil && *filter.ToBlock >= 0 && *filter.ToBlock < to {
+		to = *filter.ToBlock
+	}
+	logs := []*types.Log{}
+	for _, b := range api.c.blocks {
+		number := rpc.BlockNumber(b.header.Number.Int64())
+		if filter.BlockHash != nil {
+			if b.header.Hash() != *filter.BlockHash {
+				continue
+			}
+		} else if number < from || number > to {
+			continue
+		}
+		for _, receipt := range b.receipts {
+			for _, l := range receipt.Logs {
+				if filter.matches(l) {
+					logs = append(logs, l)
+				}
+			}
+		}
+	}
+	return logs, nil
+}
+
+func (api *testEthAPI) Logs(ctx context.Context, filter testFilter) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return nil, rpc.ErrNotificationsUnsupported
+	}
+	sub := &testLogSub{filter: filter, logs: make(chan *types.Log, 128)}
+	api.c.mu.Lock()
+	api.c.subs[sub] = struct{}{}
+	api.c.mu.Unlock()
+
+	rpcSub := notifier.CreateSubscription()
+	go func() {
+		defer func() {
+			api.c.mu.Lock()
+			delete(api.c.subs, sub)
+			api.c.mu.Unlock()
+		}()
+		for {
+			select {
+			case l := <-sub.logs:
+				notifier.Notify(rpcSub.ID, l)
+			case <-rpcSub.Err():
+				return
+			}
+		}
+	}()
+	return rpcSub, nil
+}
@@ -0,0 +1,246 @@
+package caller
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"gopkg.in/yaml.v3"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	ethc "github.com/ethereum/go-ethereum/common"
+
+	common2 "github.com/the-web3/contracts-caller/common"
+)
+
+const (
+	// JobContractTreasureManager targets the configured TreasureManager.
+	JobContractTreasureManager = "treasure-manager"
+
+	// JobModeSend signs and sends the method through Transact.
+	JobModeSend = "send"
+	// JobModeCall only reads the method and logs the result.
+	JobModeCall = "call"
+	// JobModeReconcile is a built-in job keeping contract state as
+	// configured.
+	JobModeReconcile = "reconcile"
+)
+
+// JobsFile is the YAML file describing what the caller does on its own:
+//
+//	jobs:
+//	  - name: sync-withdraw-manager
+//	    method: setWithdrawManager
+//	    args: ["0x0B306BF915C4d645ff596e518fAf3F9669b97016"]
+//	    interval: 1m
+//	    precondition:
+//	      method: withdrawManager
+//	      op: ne
+//	      value: "0x0B306BF915C4d645ff596e518fAf3F9669b97016"
+//	  - name: whitelist
+//	    mode: call
+//	    method: getTokenWhiteList
+//	    cron: "*/5 * * * *"
+type JobsFile struct {
+	Jobs []JobSpec `yaml:"jobs"`
+}
+
+type JobSpec struct {
+	Name string `yaml:"name"`
+	// Contract is an address or treasure-manager, the default.
+	Contract string `yaml:"contract"`
+	// ABI is the path of an ABI file, the TreasureManager ABI by default.
+	ABI    string   `yaml:"abi"`
+	Mode   string   `yaml:"mode"`
+	Method string   `yaml:"method"`
+	Args   []string `yaml:"args"`
+	// Value is the wei sent along with a payable method.
+	Value string `yaml:"value"`
+
+	// Exactly one of Interval and Cron is set.
+	Interval time.Duration `yaml:"interval"`
+	Cron     string        `yaml:"cron"`
+
+	Precondition *PreconditionSpec `yaml:"precondition"`
+}
+
+// PreconditionSpec gates a job on a view of the same contract: the job runs
+// only when `Method(Args) Op Value` holds.
+type PreconditionSpec struct {
+	Method string   `yaml:"method"`
+	Args   []string `yaml:"args"`
+	// Op is one of eq, ne, lt, le, gt, ge; the last four for integers.
+	Op    string `yaml:"op"`
+	Value string `yaml:"value"`
+}
+
+// LoadJobs reads a jobs file; the jobs are checked when the caller compiles
+// them.
+func LoadJobs(path string) ([]JobSpec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var file JobsFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("parse jobs file %s: %w", path, err)
+	}
+	return file.Jobs, nil
+}
+
+// DefaultJobs is what the caller did before jobs files existed: log the
+// whitelist, withdraw manager and treasure manager every interval.
+func DefaultJobs(interval time.Duration) []JobSpec {
+	var jobs []JobSpec
+	for _, method := range []string{"getTokenWhiteList", "withdrawManager", "treasureManager"} {
+		jobs = append(jobs, JobSpec{Name: method, Mode: JobModeCall, Method: method, Interval: interval})
+	}
+	return jobs
+}
+
+// job is a JobSpec with everything resolved and packed.
+type job struct {
+	spec     JobSpec
+	abi      *abi.ABI
+	to       ethc.Address
+	method   *abi.Method
+	data     []byte
+	value    *big.Int
+	schedule cron.Schedule
+
+	pre       *PreconditionSpec
+	preMethod *abi.Method
+	preData   []byte
+	preValue  interface{}
+
+	// exec replaces the method of built-in jobs.
+	exec func(ctx context.Context) (string, error)
+}
+
+// intervalSchedule runs a job a fixed delay after the previous run ended.
+// Unlike cron.ConstantDelaySchedule it does not round the start of the delay
+// down to the second, which would put sub-second delays in the past.
+type intervalSchedule time.Duration
+
+func (d intervalSchedule) Next(t time.Time) time.Time {
+	return t.Add(time.Duration(d))
+}
+
+// builtinJob wraps exec into a job running every interval.
+func builtinJob(name string, interval time.Duration, exec func(ctx context.Context) (string, error)) *job {
+	return &job{
+		spec:     JobSpec{Name: name, Mode: JobModeReconcile, Interval: interval},
+		schedule: intervalSchedule(interval),
+		exec:     exec,
+	}
+}
+
+func (j *job) label() string {
+	if j.method != nil {
+		return j.method.Sig
+	}
+	return j.spec.Mode
+}
+
+func (c *ContractCaller) compileJob(spec JobSpec) (*job, error) {
+	if spec.Name == "" {
+		return nil, fmt.Errorf("job without a name")
+	}
+	fail := func(format string, args ...interface{}) (*job, error) {
+		return nil, fmt.Errorf("job %s: %s", spec.Name, fmt.Sprintf(format, args...))
+	}
+	j := &job{spec: spec, abi: c.TreasureManagerABI, to: c.Cfg.TreasureManagerAddr}
+
+	if spec.ABI != "" {
+		contractABI, err := common2.LoadABI(spec.ABI)
+		if err != nil {
+			return fail("%v", err)
+		}
+		j.abi = contractABI
+	}
+	if spec.Contract != "" && spec.Contract != JobContractTreasureManager {
+		to, err := common2.ParseAddress(spec.Contract)
+		if err != nil {
+			return fail("%v", err)
+		}
+		j.to = to
+	}
+
+	switch spec.Mode {
+	case "":
+		j.spec.Mode = JobModeSend
+	case JobModeSend, JobModeCall:
+	default:
+		return fail("unknown mode %s", spec.Mode)
+	}
+
+	var err error
+	if j.method, j.data, err = packMethod(j.abi, spec.Method, spec.Args); err != nil {
+		return fail("%v", err)
+	}
+	if spec.Value != "" {
+		value, ok := new(big.Int).SetString(spec.Value, 0)
+		if !ok || value.Sign() < 0 {
+			return fail("invalid value %s", spec.Value)
+		}
+		if value.Sign() > 0 && !j.method.IsPayable() {
+			return fail("%s is not payable", j.method.Sig)
+		}
+		j.value = value
+	}
+
+	switch {
+	case spec.Interval > 0 && spec.Cron == "":
+		j.schedule = intervalSchedule(spec.Interval)
+	case spec.Cron != "" && spec.Interval == 0:
+		if j.schedule, err = cron.ParseStandard(spec.Cron); err != nil {
+			return fail("cron %q: %v", spec.Cron, err)
+		}
+	default:
+		return fail("exactly one of interval and cron must be set")
+	}
+
+	if pre := spec.Precondition; pre != nil {
+		j.pre = pre
+		if j.preMethod, j.preData, err = packMethod(j.abi, pre.Method, pre.Args); err != nil {
+			return fail("precondition: %v", err)
+		}
+		if len(j.preMethod.Outputs) != 1 {
+			return fail("precondition %s must return one value", j.preMethod.Sig)
+		}
+		values, err := common2.ParseABIArgs(j.preMethod.Outputs, []string{pre.Value})
+		if err != nil {
+			return fail("precondition value: %v", err)
+		}
+		j.preValue = values[0]
+		switch pre.Op {
+		case "eq", "ne":
+		case "lt", "le", "gt", "ge":
+			if t := j.preMethod.Outputs[0].Type.T; t != abi.UintTy && t != abi.IntTy {
+				return fail("precondition op %s needs an integer, %s returns %s", pre.Op, j.preMethod.Sig, j.preMethod.Outputs[0].Type)
+			}
+		default:
+			return fail("unknown precondition op %s", pre.Op)
+		}
+	}
+	return j, nil
+}
+
+func packMethod(contractABI *abi.ABI, name string, args []string) (*abi.Method, []byte, error) {
+	method, err := common2.FindMethod(contractABI, name)
+	if err != nil {
+		return nil, nil, err
+	}
+	values, err := common2.ParseABIArgs(method.Inputs, args)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", method.Sig, err)
+	}
+	data, err := contractABI.Pack(method.Name, values...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return method, data, nil
+}
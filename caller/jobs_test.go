@@ -0,0 +1,233 @@
+package caller
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/the-web3/contracts-caller/bindings"
+)
+
+const jobsManager = "0x0B306BF915C4d645ff596e518fAf3F9669b97016"
+
+// jobsCaller is enough of a caller to compile jobs against.
+func jobsCaller(t *testing.T) *ContractCaller {
+	tmABI, err := bindings.TreasureManagerMetaData.GetAbi()
+	require.Nil(t, err)
+	return &ContractCaller{
+		Cfg:                &ContractCallerConfig{TreasureManagerAddr: common.HexToAddress("0x5FbDB2315678afecb367f032d93F642f64180aa3")},
+		TreasureManagerABI: tmABI,
+	}
+}
+
+func TestCompileJob(t *testing.T) {
+	tests := []struct {
+		name string
+		spec JobSpec
+		err  string
+	}{
+		{
+			name: "send",
+			spec: JobSpec{Name: "j", Method: "setWithdrawManager", Args: []string{jobsManager}, Interval: time.Minute},
+		},
+		{
+			name: "call on cron",
+			spec: JobSpec{Name: "j", Mode: JobModeCall, Method: "getTokenWhiteList", Cron: "*/5 * * * *"},
+		},
+		{
+			name: "payable value",
+			spec: JobSpec{Name: "j", Method: "depositETH", Value: "1000", Interval: time.Minute},
+		},
+		{
+			name: "other contract",
+			spec: JobSpec{Name: "j", Contract: jobsManager, Method: "withdrawManager", Mode: JobModeCall, Interval: time.Minute},
+		},
+		{
+			name: "integer precondition",
+			spec: JobSpec{Name: "j", Method: "depositETH", Value: "1", Interval: time.Minute, Precondition: &PreconditionSpec{
+				Method: "tokenBalances", Args: []string{jobsManager}, Op: "lt", Value: "1000000",
+			}},
+		},
+		{
+			name: "address precondition",
+			spec: JobSpec{Name: "j", Method: "setWithdrawManager", Args: []string{jobsManager}, Interval: time.Minute, Precondition: &PreconditionSpec{
+				Method: "withdrawManager", Op: "ne", Value: jobsManager,
+			}},
+		},
+		{
+			name: "no name",
+			spec: JobSpec{Method: "withdrawManager", Interval: time.Minute},
+			err:  "job without a name",
+		},
+		{
+			name: "unknown mode",
+			spec: JobSpec{Name: "j", Mode: "reconcile", Method: "withdrawManager", Interval: time.Minute},
+			err:  "job j: unknown mode reconcile",
+		},
+		{
+			name: "unknown method",
+			spec: JobSpec{Name: "j", Method: "selfDestruct", Interval: time.Minute},
+			err:  "job j:",
+		},
+		{
+			name: "bad args",
+			spec: JobSpec{Name: "j", Method: "setWithdrawManager", Args: []string{"manager"}, Interval: time.Minute},
+			err:  "job j: setWithdrawManager(address)",
+		},
+		{
+			name: "bad contract",
+			spec: JobSpec{Name: "j", Contract: "treasury", Method: "withdrawManager", Interval: time.Minute},
+			err:  "job j:",
+		},
+		{
+			name: "value on non-payable",
+			spec: JobSpec{Name: "j", Method: "setWithdrawManager", Args: []string{jobsManager}, Value: "1", Interval: time.Minute},
+			err:  "setWithdrawManager(address) is not payable",
+		},
+		{
+			name: "negative value",
+			spec: JobSpec{Name: "j", Method: "depositETH", Value: "-1", Interval: time.Minute},
+			err:  "invalid value -1",
+		},
+		{
+			name: "no schedule",
+			spec: JobSpec{Name: "j", Method: "withdrawManager"},
+			err:  "exactly one of interval and cron must be set",
+		},
+		{
+			name: "two schedules",
+			spec: JobSpec{Name: "j", Method: "withdrawManager", Interval: time.Minute, Cron: "* * * * *"},
+			err:  "exactly one of interval and cron must be set",
+		},
+		{
+			name: "bad cron",
+			spec: JobSpec{Name: "j", Method: "withdrawManager", Cron: "every minute"},
+			err:  `cron "every minute"`,
+		},
+		{
+			name: "precondition without output",
+			spec: JobSpec{Name: "j", Method: "withdrawManager", Interval: time.Minute, Precondition: &PreconditionSpec{
+				Method: "claimAllTokens", Op: "eq", Value: "0",
+			}},
+			err: "must return one value",
+		},
+		{
+			name: "precondition bad value",
+			spec: JobSpec{Name: "j", Method: "withdrawManager", Interval: time.Minute, Precondition: &PreconditionSpec{
+				Method: "withdrawManager", Op: "eq", Value: "nobody",
+			}},
+			err: "precondition value",
+		},
+		{
+			name: "ordering an address",
+			spec: JobSpec{Name: "j", Method: "withdrawManager", Interval: time.Minute, Precondition: &PreconditionSpec{
+				Method: "withdrawManager", Op: "lt", Value: jobsManager,
+			}},
+			err: "precondition op lt needs an integer",
+		},
+		{
+			name: "unknown op",
+			spec: JobSpec{Name: "j", Method: "withdrawManager", Interval: time.Minute, Precondition: &PreconditionSpec{
+				Method: "withdrawManager", Op: "is", Value: jobsManager,
+			}},
+			err: "unknown precondition op is",
+		},
+	}
+
+	c := jobsCaller(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j, err := c.compileJob(tt.spec)
+			if tt.err != "" {
+				require.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.Nil(t, err)
+			require.NotNil(t, j.schedule)
+			require.NotEmpty(t, j.data)
+			require.Equal(t, tt.spec.Method, j.method.Name)
+		})
+	}
+}
+
+func TestCompileJobDefaults(t *testing.T) {
+	c := jobsCaller(t)
+
+	j, err := c.compileJob(JobSpec{Name: "j", Method: "setWithdrawManager", Args: []string{jobsManager}, Interval: time.Minute})
+	require.Nil(t, err)
+	require.Equal(t, JobModeSend, j.spec.Mode)
+	require.Equal(t, c.Cfg.TreasureManagerAddr, j.to)
+	require.Equal(t, "setWithdrawManager(address)", j.label())
+
+	j, err = c.compileJob(JobSpec{Name: "j", Contract: JobContractTreasureManager, Method: "depositETH", Value: "0x10", Interval: time.Minute})
+	require.Nil(t, err)
+	require.Equal(t, c.Cfg.TreasureManagerAddr, j.to)
+	require.Equal(t, int64(16), j.value.Int64())
+}
+
+func TestJobSchedules(t *testing.T) {
+	c := jobsCaller(t)
+	from := time.Date(2024, 5, 1, 10, 2, 30, 0, time.UTC)
+	tests := []struct {
+		interval time.Duration
+		cron     string
+		next     time.Time
+	}{
+		{interval: 90 * time.Second, next: time.Date(2024, 5, 1, 10, 4, 0, 0, time.UTC)},
+		{cron: "*/5 * * * *", next: time.Date(2024, 5, 1, 10, 5, 0, 0, time.UTC)},
+		{cron: "0 0 * * *", next: time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC)},
+		{cron: "@hourly", next: time.Date(2024, 5, 1, 11, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		j, err := c.compileJob(JobSpec{Name: "j", Mode: JobModeCall, Method: "withdrawManager", Interval: tt.interval, Cron: tt.cron})
+		require.Nil(t, err)
+		require.Equal(t, tt.next, j.schedule.Next(from), "interval %v cron %q", tt.interval, tt.cron)
+	}
+
+	// sub-second intervals are not rounded into the past
+	late := from.Add(700 * time.Millisecond)
+	j := builtinJob("fast", 250*time.Millisecond, nil)
+	require.Equal(t, late.Add(250*time.Millisecond), j.schedule.Next(late))
+}
+
+func TestLoadJobs(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jobs.yaml")
+	require.Nil(t, os.WriteFile(path, []byte(`
+jobs:
+  - name: sync-withdraw-manager
+    method: setWithdrawManager
+    args: ["0x0B306BF915C4d645ff596e518fAf3F9669b97016"]
+    interval: 1m
+    precondition:
+      method: withdrawManager
+      op: ne
+      value: "0x0B306BF915C4d645ff596e518fAf3F9669b97016"
+  - name: whitelist
+    mode: call
+    method: getTokenWhiteList
+    cron: "*/5 * * * *"
+`), 0o600))
+
+	specs, err := LoadJobs(path)
+	require.Nil(t, err)
+	require.Len(t, specs, 2)
+	require.Equal(t, time.Minute, specs[0].Interval)
+	require.Equal(t, "ne", specs[0].Precondition.Op)
+	require.Equal(t, JobModeCall, specs[1].Mode)
+	require.Equal(t, "*/5 * * * *", specs[1].Cron)
+
+	c := jobsCaller(t)
+	for _, spec := range specs {
+		_, err := c.compileJob(spec)
+		require.Nil(t, err)
+	}
+
+	require.Nil(t, os.WriteFile(path, []byte("jobs: {"), 0o600))
+	_, err = LoadJobs(path)
+	require.ErrorContains(t, err, "parse jobs file")
+}
@@ -0,0 +1,187 @@
+package caller
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	ethc "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/ethdb/leveldb"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+)
+
+type OperationKind string
+
+const (
+	OperationWithdrawal OperationKind = "withdrawal"
+	OperationReward     OperationKind = "reward"
+)
+
+type OperationStatus string
+
+const (
+	// OperationQueued waits for the operator, or for the approval of a
+	// withdrawal over its threshold. An operation none of whose
+	// transactions was broadcast goes back to it.
+	OperationQueued    OperationStatus = "queued"
+	OperationSimulated OperationStatus = "simulated"
+	OperationSigned    OperationStatus = "signed"
+	OperationBroadcast OperationStatus = "broadcast"
+	OperationMined     OperationStatus = "mined"
+	// OperationConfirmed has its transaction confirmed and its event in
+	// the receipt.
+	OperationConfirmed OperationStatus = "confirmed"
+	OperationFailed    OperationStatus = "failed"
+	OperationCancelled OperationStatus = "cancelled"
+)
+
+// Cancellable says whether nothing was signed yet for an operation in
+// status s, so it can still be cancelled.
+func (s OperationStatus) Cancellable() bool {
+	return s == OperationQueued || s == OperationSimulated
+}
+
+// Done says whether nothing more happens to an operation in status s.
+func (s OperationStatus) Done() bool {
+	return s == OperationConfirmed || s == OperationFailed || s == OperationCancelled
+}
+
+var ErrOperationNotFound = errors.New("caller: operation not found")
+
+// OperationTransition is one status an operation went through.
+type OperationTransition struct {
+	Status OperationStatus `json:"status"`
+	TxHash ethc.Hash       `json:"txHash,omitempty"`
+	At     time.Time       `json:"at"`
+}
+
+// Operation is a withdrawal or reward grant submitted to the Operator, and
+// how far it got.
+type Operation struct {
+	ID          string             `json:"id"`
+	Kind        OperationKind      `json:"kind"`
+	SubmittedBy string             `json:"submittedBy"`
+	Withdrawal  *WithdrawalRequest `json:"withdrawal,omitempty"`
+	Reward      *RewardEntry       `json:"reward,omitempty"`
+	// RecordID is the id of the withdrawal, or the key of the reward
+	// record, the operation went through.
+	RecordID    string                `json:"recordId,omitempty"`
+	Status      OperationStatus       `json:"status"`
+	TxHash      ethc.Hash             `json:"txHash,omitempty"`
+	BlockNumber uint64                `json:"blockNumber,omitempty"`
+	Error       string                `json:"error,omitempty"`
+	History     []OperationTransition `json:"history"`
+	CreatedAt   time.Time             `json:"createdAt"`
+	UpdatedAt   time.Time             `json:"updatedAt"`
+}
+
+// setStatus moves op to status, recording the transition when it changes
+// anything.
+func (op *Operation) setStatus(status OperationStatus, txHash ethc.Hash) {
+	if last := len(op.History) - 1; last >= 0 && op.History[last].Status == status && op.History[last].TxHash == txHash {
+		return
+	}
+	op.Status = status
+	if txHash != (ethc.Hash{}) {
+		op.TxHash = txHash
+	}
+	op.History = append(op.History, OperationTransition{Status: status, TxHash: txHash, At: time.Now()})
+}
+
+// OperationStore durably records submitted operations and their history.
+type OperationStore interface {
+	Get(id string) (*Operation, error)
+	Put(op *Operation) error
+	// List returns every operation, oldest first.
+	List() ([]*Operation, error)
+	Close() error
+}
+
+var operationPrefix = []byte("op-")
+
+// KVOperationStore is an OperationStore backed by any go-ethereum key-value
+// store.
+type KVOperationStore struct {
+	db ethdb.KeyValueStore
+	mu sync.Mutex
+}
+
+func NewKVOperationStore(db ethdb.KeyValueStore) *KVOperationStore {
+	return &KVOperationStore{db: db}
+}
+
+// NewLevelDBOperationStore opens (or creates) an on-disk store at path.
+func NewLevelDBOperationStore(path string) (*KVOperationStore, error) {
+	db, err := leveldb.New(path, 16, 16, "", false)
+	if err != nil {
+		return nil, err
+	}
+	return NewKVOperationStore(db), nil
+}
+
+// NewMemoryOperationStore returns a store that does not survive restarts.
+func NewMemoryOperationStore() *KVOperationStore {
+	return NewKVOperationStore(memorydb.New())
+}
+
+func operationKey(id string) []byte {
+	return append(append([]byte{}, operationPrefix...), id...)
+}
+
+func (s *KVOperationStore) Get(id string) (*Operation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ok, err := s.db.Has(operationKey(id))
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrOperationNotFound
+	}
+	value, err := s.db.Get(operationKey(id))
+	if err != nil {
+		return nil, err
+	}
+	var op Operation
+	if err := json.Unmarshal(value, &op); err != nil {
+		return nil, err
+	}
+	return &op, nil
+}
+
+func (s *KVOperationStore) Put(op *Operation) error {
+	op.UpdatedAt = time.Now()
+	value, err := json.Marshal(op)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.db.Put(operationKey(op.ID), value)
+}
+
+func (s *KVOperationStore) List() ([]*Operation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	it := s.db.NewIterator(operationPrefix, nil)
+	defer it.Release()
+
+	var ops []*Operation
+	for it.Next() {
+		var op Operation
+		if err := json.Unmarshal(it.Value(), &op); err != nil {
+			return nil, err
+		}
+		ops = append(ops, &op)
+	}
+	return ops, it.Error()
+}
+
+func (s *KVOperationStore) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,387 @@
+package caller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	ethc "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/the-web3/contracts-caller/txmgr"
+)
+
+var (
+	// ErrOperationRefused is returned for an operation the operator does
+	// not take or its checks do not allow.
+	ErrOperationRefused = errors.New("operation refused")
+	// ErrNotCancellable is returned for an operation already on its way.
+	ErrNotCancellable = errors.New("operation can no longer be cancelled")
+)
+
+// OperatorConfig is what an Operator records operations in and which kinds
+// it takes.
+type OperatorConfig struct {
+	Store OperationStore
+	// Withdrawals and WithdrawalPolicy take withdrawal operations, which
+	// are refused when either is nil.
+	Withdrawals      WithdrawalStore
+	WithdrawalPolicy *WithdrawalPolicy
+	// Rewards takes reward operations, which are refused when nil.
+	Rewards RewardStore
+	// Interval is how often unfinished operations are tried again, e.g. a
+	// withdrawal awaiting approval, LoopInterval of the caller when 0.
+	Interval time.Duration
+}
+
+// Operator sends submitted withdrawals and reward grants one at a time
+// through the caller and records each step of their transactions.
+type Operator struct {
+	c       *ContractCaller
+	cfg     OperatorConfig
+	mu      sync.Mutex
+	running string
+	wake    chan struct{}
+	cancel  func()
+	wg      sync.WaitGroup
+}
+
+func NewOperator(c *ContractCaller, cfg OperatorConfig) *Operator {
+	if cfg.Interval == 0 {
+		cfg.Interval = c.Cfg.LoopInterval
+	}
+	return &Operator{
+		c:      c,
+		cfg:    cfg,
+		wake:   make(chan struct{}, 1),
+		cancel: func() {},
+	}
+}
+
+// SubmitWithdrawal requests req as submittedBy under the withdrawal policy
+// and queues it. A withdrawal over its approval threshold stays queued
+// until someone else approves it with ApproveWithdrawal.
+func (o *Operator) SubmitWithdrawal(ctx context.Context, submittedBy string, req *WithdrawalRequest) (*Operation, error) {
+	if o.cfg.Withdrawals == nil || o.cfg.WithdrawalPolicy == nil {
+		return nil, fmt.Errorf("%w: withdrawals need a withdrawals store and policy", ErrOperationRefused)
+	}
+	req.RequestedBy = submittedBy
+	w, err := RequestWithdrawal(ctx, &o.c.TreasureManagerContract.TreasureManagerCaller, o.cfg.WithdrawalPolicy, o.cfg.Withdrawals, req)
+	if err != nil {
+		return nil, err
+	}
+	return o.submit(&Operation{Kind: OperationWithdrawal, SubmittedBy: submittedBy, Withdrawal: req, RecordID: w.ID})
+}
+
+// SubmitReward queues the grant of entry once the whitelist and treasury
+// balance allow it. An entry granted before is confirmed with the
+// transaction that granted it.
+func (o *Operator) SubmitReward(ctx context.Context, submittedBy string, entry *RewardEntry) (*Operation, error) {
+	if o.cfg.Rewards == nil {
+		return nil, fmt.Errorf("%w: reward grants need a rewards store", ErrOperationRefused)
+	}
+	if entry.Amount == nil || entry.Amount.Sign() <= 0 {
+		return nil, fmt.Errorf("%w: invalid amount %v", ErrOperationRefused, entry.Amount)
+	}
+	plan, err := PlanRewards(ctx, &o.c.TreasureManagerContract.TreasureManagerCaller, o.cfg.Rewards, []*RewardEntry{entry})
+	if err != nil {
+		return nil, err
+	}
+	if len(plan.Problems) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrOperationRefused, strings.Join(plan.Problems, "; "))
+	}
+	return o.submit(&Operation{Kind: OperationReward, SubmittedBy: submittedBy, Reward: entry, RecordID: entry.Key()})
+}
+
+func (o *Operator) submit(op *Operation) (*Operation, error) {
+	id, err := newRecordID()
+	if err != nil {
+		return nil, err
+	}
+	op.ID = id
+	op.CreatedAt = time.Now()
+	op.setStatus(OperationQueued, ethc.Hash{})
+	if err := o.cfg.Store.Put(op); err != nil {
+		return nil, err
+	}
+	log.Info("Contract caller operation queued", "id", op.ID, "kind", op.Kind, "record", op.RecordID, "submittedBy", op.SubmittedBy)
+	o.wakeUp()
+	return op, nil
+}
+
+// wakeUp runs the operations now rather than at the next Interval.
+func (o *Operator) wakeUp() {
+	select {
+	case o.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (o *Operator) Get(id string) (*Operation, error) {
+	return o.cfg.Store.Get(id)
+}
+
+func (o *Operator) List() ([]*Operation, error) {
+	return o.cfg.Store.List()
+}
+
+// ApproveWithdrawal is the second approval of the withdrawal id by
+// approvedBy, who must not be its requester. Its operation runs right
+// after.
+func (o *Operator) ApproveWithdrawal(id, approvedBy string) (*Withdrawal, error) {
+	if o.cfg.Withdrawals == nil {
+		return nil, fmt.Errorf("%w: withdrawals need a withdrawals store and policy", ErrOperationRefused)
+	}
+	w, err := ApproveWithdrawal(o.cfg.Withdrawals, id, approvedBy)
+	if err != nil {
+		return nil, err
+	}
+	o.wakeUp()
+	return w, nil
+}
+
+// RejectWithdrawal drops the withdrawal id before it is sent, and cancels
+// its operation along with it.
+func (o *Operator) RejectWithdrawal(id, by, reason string) (*Withdrawal, error) {
+	if o.cfg.Withdrawals == nil {
+		return nil, fmt.Errorf("%w: withdrawals need a withdrawals store and policy", ErrOperationRefused)
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	ops, err := o.cfg.Store.List()
+	if err != nil {
+		return nil, err
+	}
+	var op *Operation
+	for _, other := range ops {
+		if other.Kind == OperationWithdrawal && other.RecordID == id && !other.Status.Done() {
+			op = other
+		}
+	}
+	if op != nil && (!op.Status.Cancellable() || o.running == op.ID) {
+		return nil, fmt.Errorf("%w: %s is %s", ErrNotCancellable, op.ID, op.Status)
+	}
+	w, err := RejectWithdrawal(o.cfg.Withdrawals, id, by, reason)
+	if err != nil {
+		return nil, err
+	}
+	if op != nil {
+		op.Error = "rejected by " + by
+		op.setStatus(OperationCancelled, ethc.Hash{})
+		log.Info("Contract caller operation cancelled", "id", op.ID, "by", by)
+		if err := o.cfg.Store.Put(op); err != nil {
+			return nil, err
+		}
+	}
+	return w, nil
+}
+
+// Cancel drops an operation nothing was signed for yet, or whose
+// transaction was never broadcast. A withdrawal is rejected along with it.
+func (o *Operator) Cancel(id, by string) (*Operation, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	op, err := o.cfg.Store.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if !op.Status.Cancellable() || o.running == id {
+		return nil, fmt.Errorf("%w: %s is %s", ErrNotCancellable, id, op.Status)
+	}
+	if op.Kind == OperationWithdrawal {
+		if _, err := RejectWithdrawal(o.cfg.Withdrawals, op.RecordID, by, "operation cancelled"); err != nil {
+			return nil, err
+		}
+	}
+	op.Error = "cancelled by " + by
+	op.setStatus(OperationCancelled, ethc.Hash{})
+	log.Info("Contract caller operation cancelled", "id", id, "by", by)
+	return op, o.cfg.Store.Put(op)
+}
+
+// Start runs queued operations as they are submitted, and tries the
+// unfinished ones again every Interval, including those a restart left
+// in flight.
+func (o *Operator) Start(ctx context.Context) {
+	ctx, o.cancel = context.WithCancel(ctx)
+	o.wg.Add(1)
+	go func() {
+		defer o.wg.Done()
+		ticker := time.NewTicker(o.cfg.Interval)
+		defer ticker.Stop()
+		for {
+			o.runAll(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			case <-o.wake:
+			}
+		}
+	}()
+}
+
+func (o *Operator) Stop() {
+	o.cancel()
+	o.wg.Wait()
+}
+
+func (o *Operator) runAll(ctx context.Context) {
+	ops, err := o.cfg.Store.List()
+	if err != nil {
+		log.Error("Contract caller unable to list operations", "err", err)
+		return
+	}
+	for _, op := range ops {
+		if ctx.Err() != nil {
+			return
+		}
+		if !op.Status.Done() {
+			o.run(ctx, op.ID)
+		}
+	}
+}
+
+// run takes one operation as far as it goes now.
+func (o *Operator) run(ctx context.Context, id string) {
+	o.mu.Lock()
+	op, err := o.cfg.Store.Get(id)
+	if err != nil || op.Status.Done() {
+		o.mu.Unlock()
+		return
+	}
+	if op.Kind == OperationWithdrawal {
+		w, err := o.cfg.Withdrawals.Get(op.RecordID)
+		if err == nil && w.Status == WithdrawalAwaitingApproval {
+			o.mu.Unlock()
+			return
+		}
+	}
+	o.running = id
+	o.mu.Unlock()
+	defer func() {
+		o.mu.Lock()
+		o.running = ""
+		o.mu.Unlock()
+	}()
+
+	// the stages of txmgr are operation statuses of their own
+	ctx = txmgr.WithProgress(ctx, func(stage txmgr.TxStage, txHash ethc.Hash) {
+		o.update(id, func(op *Operation) {
+			op.setStatus(OperationStatus(stage), txHash)
+		})
+	})
+	switch op.Kind {
+	case OperationWithdrawal:
+		w, err := o.c.ExecuteWithdrawal(ctx, o.cfg.WithdrawalPolicy, o.cfg.Withdrawals, op.RecordID)
+		o.update(id, func(op *Operation) {
+			finishWithdrawalOperation(op, w, err)
+		})
+	case OperationReward:
+		started := time.Now()
+		plan, _, err := o.c.GrantRewardSchedule(ctx, o.cfg.Rewards, []*RewardEntry{op.Reward})
+		record, getErr := o.cfg.Rewards.Get(op.RecordID)
+		if getErr != nil || (record.Status == RewardStatusFailed && record.UpdatedAt.Before(started)) {
+			// a grant that failed before this run is not its outcome
+			record = nil
+		}
+		o.update(id, func(op *Operation) {
+			finishRewardOperation(op, plan, record, err)
+		})
+	}
+}
+
+func (o *Operator) update(id string, change func(op *Operation)) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	op, err := o.cfg.Store.Get(id)
+	if err != nil {
+		log.Error("Contract caller unable to update operation", "id", id, "err", err)
+		return
+	}
+	prev := op.Status
+	change(op)
+	if op.Status != prev {
+		log.Info("Contract caller operation", "id", id, "status", op.Status, "TxHash", op.TxHash)
+	}
+	if err := o.cfg.Store.Put(op); err != nil {
+		log.Error("Contract caller unable to update operation", "id", id, "err", err)
+	}
+}
+
+// finishWithdrawalOperation settles op from its withdrawal. A withdrawal
+// that is still pending, or could not be sent for a reason other than the
+// policy, leaves op to be tried again.
+func finishWithdrawalOperation(op *Operation, w *Withdrawal, err error) {
+	if err != nil {
+		op.Error = err.Error()
+	}
+	if w == nil {
+		return
+	}
+	switch w.Status {
+	case WithdrawalExecuted:
+		op.Error = ""
+		op.BlockNumber = w.BlockNumber
+		op.setStatus(OperationConfirmed, w.TxHash)
+	case WithdrawalFailed:
+		op.Error = w.Error
+		op.setStatus(OperationFailed, w.TxHash)
+	case WithdrawalRejected:
+		op.setStatus(OperationCancelled, ethc.Hash{})
+	case WithdrawalApproved:
+		if errors.Is(err, ErrWithdrawalPolicy) || errors.Is(err, ErrProposalRequired) {
+			op.setStatus(OperationFailed, ethc.Hash{})
+		}
+	}
+	requeueOperation(op, err)
+}
+
+// finishRewardOperation settles op from its reward record. A grant that is
+// still pending, or could not be sent for a reason other than the plan,
+// leaves op to be tried again.
+func finishRewardOperation(op *Operation, plan *RewardPlan, record *RewardRecord, err error) {
+	if err != nil {
+		op.Error = err.Error()
+	}
+	switch {
+	case record != nil && record.Status == RewardStatusGranted:
+		op.Error = ""
+		op.BlockNumber = record.BlockNumber
+		op.setStatus(OperationConfirmed, record.TxHash)
+	case record != nil && record.Status == RewardStatusFailed:
+		op.Error = record.Error
+		op.setStatus(OperationFailed, record.TxHash)
+	case plan != nil && len(plan.Problems) > 0:
+		op.setStatus(OperationFailed, ethc.Hash{})
+	}
+	requeueOperation(op, err)
+}
+
+// requeueOperation puts op back to queued when its transaction was never
+// broadcast, so it can be cancelled before it is tried again.
+func requeueOperation(op *Operation, err error) {
+	if op.Status.Done() || !errors.Is(err, txmgr.ErrNotBroadcast) {
+		return
+	}
+	op.TxHash = ethc.Hash{}
+	op.setStatus(OperationQueued, ethc.Hash{})
+}
+
+// EthAddress is the token address the TreasureManager uses for ETH.
+func (o *Operator) EthAddress(ctx context.Context) (ethc.Address, error) {
+	return o.c.TreasureManagerContract.EthAddress(&bind.CallOpts{Context: ctx})
+}
+
+// JobStatuses is what the jobs of the caller last did.
+func (o *Operator) JobStatuses() []JobStatus {
+	return o.c.JobStatuses()
+}
@@ -0,0 +1,124 @@
+package caller
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestOperatorApproveWithdrawal(t *testing.T) {
+	_, tm, c, withdrawals := withdrawalCaller(t, ContractCallerConfig{})
+	o := NewOperator(c, OperatorConfig{
+		Store:            NewMemoryOperationStore(),
+		Withdrawals:      withdrawals,
+		WithdrawalPolicy: testPolicy(t),
+		Interval:         10 * time.Millisecond,
+	})
+	ctx := context.Background()
+	eth, err := tm.EthAddress(nil)
+	require.Nil(t, err)
+
+	var ops []*Operation
+	for i := 0; i < 2; i++ {
+		op, err := o.SubmitWithdrawal(ctx, "payouts", &WithdrawalRequest{Token: eth, To: alice, Amount: ether(4)})
+		require.Nil(t, err)
+		ops = append(ops, op)
+	}
+
+	// the submitter cannot approve its own withdrawal
+	_, err = o.ApproveWithdrawal(ops[0].RecordID, "payouts")
+	require.ErrorIs(t, err, ErrApprovalRefused)
+	_, err = o.ApproveWithdrawal("missing", "treasurer")
+	require.ErrorIs(t, err, ErrWithdrawalNotFound)
+	w, err := o.ApproveWithdrawal(ops[0].RecordID, "treasurer")
+	require.Nil(t, err)
+	require.Equal(t, WithdrawalApproved, w.Status)
+	require.Equal(t, "treasurer", w.ApprovedBy)
+
+	// rejecting a withdrawal cancels its operation
+	w, err = o.RejectWithdrawal(ops[1].RecordID, "treasurer", "duplicate")
+	require.Nil(t, err)
+	require.Equal(t, WithdrawalRejected, w.Status)
+	op, err := o.Get(ops[1].ID)
+	require.Nil(t, err)
+	require.Equal(t, OperationCancelled, op.Status)
+	require.Equal(t, "rejected by treasurer", op.Error)
+
+	o.Start(ctx)
+	defer o.Stop()
+	require.Eventually(t, func() bool {
+		op, err := o.Get(ops[0].ID)
+		return err == nil && op.Status == OperationConfirmed
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, 1, withdrawCount(t, tm))
+}
+
+func TestOperatorCancel(t *testing.T) {
+	chain, tm, c, withdrawals := withdrawalCaller(t, ContractCallerConfig{})
+	o := NewOperator(c, OperatorConfig{
+		Store:            NewMemoryOperationStore(),
+		Withdrawals:      withdrawals,
+		WithdrawalPolicy: testPolicy(t),
+		Interval:         10 * time.Millisecond,
+	})
+	ctx := context.Background()
+	eth, err := tm.EthAddress(nil)
+	require.Nil(t, err)
+	submit := func() *Operation {
+		op, err := o.SubmitWithdrawal(ctx, "payouts", &WithdrawalRequest{Token: eth, To: alice, Amount: ether(4)})
+		require.Nil(t, err)
+		_, err = o.ApproveWithdrawal(op.RecordID, "treasurer")
+		require.Nil(t, err)
+		return op
+	}
+
+	// a run whose transaction was never broadcast leaves its operation
+	// queued again, and cancellable
+	op := submit()
+	chain.Refuse(errors.New("connection refused"))
+	runCtx, cancel := context.WithTimeout(ctx, 300*time.Millisecond)
+	o.run(runCtx, op.ID)
+	cancel()
+	chain.Refuse(nil)
+	op, err = o.Get(op.ID)
+	require.Nil(t, err)
+	require.Equal(t, OperationQueued, op.Status)
+	require.Zero(t, op.TxHash)
+	require.Equal(t, OperationSigned, op.History[len(op.History)-2].Status)
+	op, err = o.Cancel(op.ID, "ops")
+	require.Nil(t, err)
+	require.Equal(t, OperationCancelled, op.Status)
+	w, err := withdrawals.Get(op.RecordID)
+	require.Nil(t, err)
+	require.Equal(t, WithdrawalRejected, w.Status)
+
+	// a simulated operation has nothing signed yet, a signed one may be
+	// on its way
+	tests := []struct {
+		status OperationStatus
+		err    error
+	}{
+		{status: OperationSimulated},
+		{status: OperationSigned, err: ErrNotCancellable},
+		{status: OperationBroadcast, err: ErrNotCancellable},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.status), func(t *testing.T) {
+			op := submit()
+			op.setStatus(tt.status, common.Hash{})
+			require.Nil(t, o.cfg.Store.Put(op))
+			_, err := o.Cancel(op.ID, "ops")
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.Nil(t, err)
+		})
+	}
+	require.Equal(t, 0, withdrawCount(t, tm))
+}
@@ -0,0 +1,169 @@
+package caller
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"sync"
+	"time"
+
+	ethc "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/ethdb/leveldb"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+)
+
+type ProposalStatus string
+
+const (
+	// ProposalOpen collects approvals until it is executed, cancelled or
+	// expires.
+	ProposalOpen ProposalStatus = "open"
+	// ProposalSent is recorded before the transaction is sent, so it is
+	// never sent twice.
+	ProposalSent      ProposalStatus = "sent"
+	ProposalExecuted  ProposalStatus = "executed"
+	ProposalFailed    ProposalStatus = "failed"
+	ProposalCancelled ProposalStatus = "cancelled"
+)
+
+var ErrProposalNotFound = errors.New("caller: proposal not found")
+
+// ProposalApproval is the EIP-712 signature of a proposal by an approver.
+type ProposalApproval struct {
+	Approver  ethc.Address  `json:"approver"`
+	Signature hexutil.Bytes `json:"signature"`
+}
+
+// Proposal is a TreasureManager call awaiting M-of-N approval, with
+// everything an approver needs to check before signing its Hash.
+type Proposal struct {
+	ID     string         `json:"id"`
+	Hash   ethc.Hash      `json:"hash"`
+	Method string         `json:"method"`
+	Args   []string       `json:"args"`
+	To     ethc.Address   `json:"to"`
+	Value  *big.Int       `json:"value"`
+	Data   hexutil.Bytes  `json:"data"`
+	Salt   ethc.Hash      `json:"salt"`
+	Status ProposalStatus `json:"status"`
+	// Deadline is when the proposal expires, approved or not.
+	Deadline time.Time `json:"deadline"`
+	ChainID  *big.Int  `json:"chainId"`
+	// ProposedBy is the caller wallet the call was simulated from and is
+	// sent by.
+	ProposedBy ethc.Address `json:"proposedBy"`
+	// SimulatedBlock, SimulatedGas and SimulatedResult are what the call
+	// did against the pending block when proposed.
+	SimulatedBlock  uint64             `json:"simulatedBlock"`
+	SimulatedGas    uint64             `json:"simulatedGas"`
+	SimulatedResult hexutil.Bytes      `json:"simulatedResult"`
+	Approvals       []ProposalApproval `json:"approvals"`
+	// WithdrawalID is the withdrawal the proposal sends, if any.
+	WithdrawalID string `json:"withdrawalId,omitempty"`
+	// Sent is the transaction sending a proposal of no withdrawal, the
+	// withdrawal records its own.
+	Sent        *SentTx   `json:"sent,omitempty"`
+	CreatedAt   time.Time `json:"createdAt"`
+	TxHash      ethc.Hash `json:"txHash,omitempty"`
+	BlockNumber uint64    `json:"blockNumber,omitempty"`
+	Error       string    `json:"error,omitempty"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// ProposalStore durably records proposals and their approvals.
+type ProposalStore interface {
+	Get(id string) (*Proposal, error)
+	Put(p *Proposal) error
+	// List returns every proposal, oldest first.
+	List() ([]*Proposal, error)
+	Close() error
+}
+
+var proposalPrefix = []byte("prp-")
+
+// KVProposalStore is a ProposalStore backed by any go-ethereum key-value
+// store.
+type KVProposalStore struct {
+	db ethdb.KeyValueStore
+	mu sync.Mutex
+}
+
+func NewKVProposalStore(db ethdb.KeyValueStore) *KVProposalStore {
+	return &KVProposalStore{db: db}
+}
+
+// NewLevelDBProposalStore opens (or creates) an on-disk store at path.
+func NewLevelDBProposalStore(path string) (*KVProposalStore, error) {
+	db, err := leveldb.New(path, 16, 16, "", false)
+	if err != nil {
+		return nil, err
+	}
+	return NewKVProposalStore(db), nil
+}
+
+// NewMemoryProposalStore returns a store that does not survive restarts.
+func NewMemoryProposalStore() *KVProposalStore {
+	return NewKVProposalStore(memorydb.New())
+}
+
+func proposalKey(id string) []byte {
+	return append(append([]byte{}, proposalPrefix...), id...)
+}
+
+func (s *KVProposalStore) Get(id string) (*Proposal, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ok, err := s.db.Has(proposalKey(id))
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrProposalNotFound
+	}
+	value, err := s.db.Get(proposalKey(id))
+	if err != nil {
+		return nil, err
+	}
+	var p Proposal
+	if err := json.Unmarshal(value, &p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (s *KVProposalStore) Put(p *Proposal) error {
+	p.UpdatedAt = time.Now()
+	value, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.db.Put(proposalKey(p.ID), value)
+}
+
+func (s *KVProposalStore) List() ([]*Proposal, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	it := s.db.NewIterator(proposalPrefix, nil)
+	defer it.Release()
+
+	var proposals []*Proposal
+	for it.Next() {
+		var p Proposal
+		if err := json.Unmarshal(it.Value(), &p); err != nil {
+			return nil, err
+		}
+		proposals = append(proposals, &p)
+	}
+	return proposals, it.Error()
+}
+
+func (s *KVProposalStore) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,447 @@
+package caller
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/pkg/errors"
+
+	ethc "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+
+	"github.com/the-web3/contracts-caller/txmgr"
+)
+
+// DefaultProposalTTL is how long a proposal can collect approvals when
+// ContractCallerConfig.ProposalTTL is not set.
+const DefaultProposalTTL = 24 * time.Hour
+
+// ProposalMethods are the TreasureManager methods that need M-of-N approval
+// once ContractCallerConfig.ApprovalThreshold is set.
+var ProposalMethods = map[string]bool{
+	"withdrawETH":       true,
+	"withdrawERC20":     true,
+	"transferOwnership": true,
+	"grantRole":         true,
+	"renounceOwnership": true,
+}
+
+var (
+	// ErrProposalRequired is returned for a call of one of ProposalMethods
+	// that did not go through an approved proposal.
+	ErrProposalRequired = errors.New("call needs an approved proposal")
+	ErrProposalExpired  = errors.New("proposal expired")
+)
+
+// proposalTypes is the EIP-712 schema approvers sign. Method is only there
+// for wallets to show, Data is what is executed.
+var proposalTypes = apitypes.Types{
+	"EIP712Domain": {
+		{Name: "name", Type: "string"},
+		{Name: "version", Type: "string"},
+		{Name: "chainId", Type: "uint256"},
+		{Name: "verifyingContract", Type: "address"},
+	},
+	"Proposal": {
+		{Name: "method", Type: "string"},
+		{Name: "to", Type: "address"},
+		{Name: "value", Type: "uint256"},
+		{Name: "data", Type: "bytes"},
+		{Name: "deadline", Type: "uint256"},
+		{Name: "salt", Type: "bytes32"},
+	},
+}
+
+// ProposalTypedData is the EIP-712 message of p, in the domain of the
+// contract it calls, for approvers to sign with eth_signTypedData_v4.
+func ProposalTypedData(p *Proposal) apitypes.TypedData {
+	return apitypes.TypedData{
+		Types:       proposalTypes,
+		PrimaryType: "Proposal",
+		Domain: apitypes.TypedDataDomain{
+			Name:              "ContractsCaller",
+			Version:           "1",
+			ChainId:           (*math.HexOrDecimal256)(p.ChainID),
+			VerifyingContract: p.To.Hex(),
+		},
+		Message: apitypes.TypedDataMessage{
+			"method":   p.Method,
+			"to":       p.To.Hex(),
+			"value":    p.Value.String(),
+			"data":     hexutil.Encode(p.Data),
+			"deadline": fmt.Sprint(p.Deadline.Unix()),
+			"salt":     p.Salt.Hex(),
+		},
+	}
+}
+
+func proposalHash(p *Proposal) (ethc.Hash, error) {
+	hash, _, err := apitypes.TypedDataAndHash(ProposalTypedData(p))
+	if err != nil {
+		return ethc.Hash{}, err
+	}
+	return ethc.BytesToHash(hash), nil
+}
+
+// recoverApprover returns the account that signed hash, with V being 0 or 1
+// as from crypto.Sign or 27 or 28 as from wallets.
+func recoverApprover(hash ethc.Hash, signature []byte) (ethc.Address, error) {
+	if len(signature) != crypto.SignatureLength {
+		return ethc.Address{}, fmt.Errorf("signature is %d bytes, want %d", len(signature), crypto.SignatureLength)
+	}
+	sig := append([]byte{}, signature...)
+	if sig[64] >= 27 {
+		sig[64] -= 27
+	}
+	pub, err := crypto.SigToPub(hash[:], sig)
+	if err != nil {
+		return ethc.Address{}, err
+	}
+	return crypto.PubkeyToAddress(*pub), nil
+}
+
+func isApprover(approvers []ethc.Address, account ethc.Address) bool {
+	for _, approver := range approvers {
+		if approver == account {
+			return true
+		}
+	}
+	return false
+}
+
+// Propose packs a call of one of ProposalMethods, simulates it from the
+// caller wallet and records it as an open proposal until its deadline.
+func (c *ContractCaller) Propose(ctx context.Context, store ProposalStore, method string, value *big.Int, args ...interface{}) (*Proposal, error) {
+	p, err := c.newProposal(ctx, method, value, args...)
+	if err != nil {
+		return nil, err
+	}
+	return p, store.Put(p)
+}
+
+// newProposal is Propose without recording the proposal.
+func (c *ContractCaller) newProposal(ctx context.Context, method string, value *big.Int, args ...interface{}) (*Proposal, error) {
+	if !ProposalMethods[method] {
+		return nil, fmt.Errorf("%s does not go through proposals", method)
+	}
+	data, err := c.TreasureManagerABI.Pack(method, args...)
+	if err != nil {
+		return nil, err
+	}
+	if value == nil {
+		value = new(big.Int)
+	}
+	req := &TxRequest{To: c.Cfg.TreasureManagerAddr, Data: data, Value: value}
+	block, err := c.Cfg.ChainClient.BlockNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result, gas, err := c.simulate(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := newRecordID()
+	if err != nil {
+		return nil, err
+	}
+	ttl := c.Cfg.ProposalTTL
+	if ttl == 0 {
+		ttl = DefaultProposalTTL
+	}
+	p := &Proposal{
+		ID:              id,
+		Method:          method,
+		Args:            proposalArgs(args),
+		To:              req.To,
+		Value:           value,
+		Data:            data,
+		Status:          ProposalOpen,
+		Deadline:        time.Now().Add(ttl).Truncate(time.Second),
+		ChainID:         c.Cfg.ChainID,
+		ProposedBy:      c.WalletAddr,
+		SimulatedBlock:  block,
+		SimulatedGas:    gas,
+		SimulatedResult: result,
+		Approvals:       []ProposalApproval{},
+		CreatedAt:       time.Now(),
+	}
+	if _, err := rand.Read(p.Salt[:]); err != nil {
+		return nil, err
+	}
+	if p.Hash, err = proposalHash(p); err != nil {
+		return nil, err
+	}
+	log.Info("Contract caller proposal created", "id", p.ID, "method", method, "args", p.Args,
+		"hash", p.Hash, "deadline", p.Deadline)
+	return p, nil
+}
+
+func proposalArgs(args []interface{}) []string {
+	strs := make([]string, 0, len(args))
+	for _, arg := range args {
+		switch v := arg.(type) {
+		case ethc.Address:
+			strs = append(strs, v.Hex())
+		case [32]byte:
+			strs = append(strs, ethc.Hash(v).Hex())
+		default:
+			strs = append(strs, fmt.Sprint(v))
+		}
+	}
+	return strs
+}
+
+// ApproveProposal records the signature of the proposal hash by one of
+// approvers. Signing twice, or after the deadline, is refused.
+func ApproveProposal(store ProposalStore, id string, signature []byte, approvers []ethc.Address) (*Proposal, error) {
+	p, err := store.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if p.Status != ProposalOpen {
+		return nil, fmt.Errorf("proposal %s is %s", id, p.Status)
+	}
+	if time.Now().After(p.Deadline) {
+		return nil, errors.Wrapf(ErrProposalExpired, "proposal %s", id)
+	}
+	approver, err := recoverApprover(p.Hash, signature)
+	if err != nil {
+		return nil, fmt.Errorf("proposal %s signature: %w", id, err)
+	}
+	if !isApprover(approvers, approver) {
+		return nil, fmt.Errorf("proposal %s signed by %s, which is not an approver", id, approver)
+	}
+	for _, approval := range p.Approvals {
+		if approval.Approver == approver {
+			return nil, fmt.Errorf("proposal %s already approved by %s", id, approver)
+		}
+	}
+	p.Approvals = append(p.Approvals, ProposalApproval{Approver: approver, Signature: signature})
+	log.Info("Contract caller proposal approved", "id", p.ID, "approver", approver, "approvals", len(p.Approvals))
+	return p, store.Put(p)
+}
+
+// CancelProposal drops an open proposal.
+func CancelProposal(store ProposalStore, id string) (*Proposal, error) {
+	p, err := store.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if p.Status != ProposalOpen {
+		return nil, fmt.Errorf("proposal %s is %s and can no longer be cancelled", id, p.Status)
+	}
+	p.Status = ProposalCancelled
+	log.Info("Contract caller proposal cancelled", "id", p.ID)
+	return p, store.Put(p)
+}
+
+// ExecuteProposal sends an open proposal once it has not expired and holds
+// valid signatures of at least ApprovalThreshold of the configured
+// Approvers. The proposal is recorded as sent before the transaction goes
+// out, so it is never sent twice: a sent proposal is settled from its
+// transaction once that is mined, fails with ErrTxInFlight while it is
+// pending and is sent again once it was dropped. The proposal of a
+// withdrawal is only sent through ExecuteWithdrawalProposal, which applies
+// the withdrawal policy.
+func (c *ContractCaller) ExecuteProposal(ctx context.Context, store ProposalStore, id string) (*Proposal, *types.Receipt, error) {
+	if !c.claimExecution(id) {
+		return nil, nil, fmt.Errorf("proposal %s is already being executed", id)
+	}
+	defer c.unclaimExecution(id)
+
+	p, err := store.Get(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if p.Status == ProposalSent && p.WithdrawalID == "" {
+		receipt, err := c.resolveSentProposal(ctx, store, p)
+		if err != nil || p.Status != ProposalSent {
+			return p, receipt, err
+		}
+	}
+	if p, err = c.claimProposal(store, id, "", true); err != nil {
+		return p, nil, err
+	}
+	ctx = recordSent(ctx, p.Sent, func() error { return store.Put(p) })
+	receipt, err := c.sendProposal(ctx, store, p)
+	return p, receipt, err
+}
+
+// resolveSentProposal settles the sent proposal p from its transaction. It
+// leaves p sent, to be sent again, when nothing was signed for it or its
+// transaction was dropped.
+func (c *ContractCaller) resolveSentProposal(ctx context.Context, store ProposalStore, p *Proposal) (*types.Receipt, error) {
+	if p.Sent == nil {
+		// sent before its transaction was recorded
+		return nil, fmt.Errorf("proposal %s is %s", p.ID, p.Status)
+	}
+	if !p.Sent.known() {
+		return nil, nil
+	}
+	receipt, err := c.resolveSentTx(ctx, p.Sent, func() error { return store.Put(p) })
+	switch {
+	case err != nil:
+		return nil, errors.Wrapf(err, "proposal %s", p.ID)
+	case receipt == nil:
+		log.Warn("Contract caller sent proposal dropped, sending again", "id", p.ID, "nonce", p.Sent.Nonce,
+			"safeTxHash", p.Sent.SafeTxHash)
+		return nil, nil
+	}
+	log.Info("Contract caller sent proposal mined", "id", p.ID, "TxHash", receipt.TxHash)
+	return receipt, c.settleProposal(store, p, receipt)
+}
+
+// claimExecution marks the proposal id as being executed, false when it
+// already is.
+func (c *ContractCaller) claimExecution(id string) bool {
+	c.proposalMu.Lock()
+	defer c.proposalMu.Unlock()
+	if c.executing[id] {
+		return false
+	}
+	if c.executing == nil {
+		c.executing = make(map[string]bool)
+	}
+	c.executing[id] = true
+	return true
+}
+
+func (c *ContractCaller) unclaimExecution(id string) {
+	c.proposalMu.Lock()
+	defer c.proposalMu.Unlock()
+	delete(c.executing, id)
+}
+
+// checkProposal returns what keeps p from being sent for the withdrawal
+// withdrawalID, none for a proposal of no withdrawal. A proposal already
+// sent is only sent again on resend, once its transaction was dropped.
+func (c *ContractCaller) checkProposal(p *Proposal, withdrawalID string, resend bool) error {
+	switch {
+	case p.WithdrawalID != withdrawalID && withdrawalID == "":
+		return fmt.Errorf("proposal %s is of withdrawal %s, execute the withdrawal instead", p.ID, p.WithdrawalID)
+	case p.WithdrawalID != withdrawalID:
+		return fmt.Errorf("proposal %s is not of withdrawal %s", p.ID, withdrawalID)
+	case p.Status != ProposalOpen && !(resend && p.Status == ProposalSent):
+		return fmt.Errorf("proposal %s is %s", p.ID, p.Status)
+	case time.Now().After(p.Deadline):
+		return errors.Wrapf(ErrProposalExpired, "proposal %s", p.ID)
+	}
+	return c.checkApprovals(p)
+}
+
+// claimProposal checks the proposal id and records it as sent in one go, so
+// it is sent once however many execute it at the same time.
+func (c *ContractCaller) claimProposal(store ProposalStore, id, withdrawalID string, resend bool) (*Proposal, error) {
+	c.proposalMu.Lock()
+	defer c.proposalMu.Unlock()
+	p, err := store.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.checkProposal(p, withdrawalID, resend); err != nil {
+		return p, err
+	}
+	p.Status = ProposalSent
+	if withdrawalID == "" {
+		p.Sent = &SentTx{}
+	}
+	return p, store.Put(p)
+}
+
+// sendProposal sends the claimed proposal p and records what became of it.
+func (c *ContractCaller) sendProposal(ctx context.Context, store ProposalStore, p *Proposal) (*types.Receipt, error) {
+	receipt, err := c.Transact(ctx, &TxRequest{To: p.To, Data: p.Data, Value: p.Value, approved: true})
+	switch {
+	case errors.Is(err, ErrSimulationReverted) || errors.Is(err, txmgr.ErrNotBroadcast):
+		// nothing was sent, the proposal can be executed again
+		p.Status = ProposalOpen
+		p.Sent = nil
+		p.Error = err.Error()
+		if putErr := store.Put(p); putErr != nil {
+			return nil, putErr
+		}
+		return nil, err
+	case errors.Is(err, txmgr.ErrTxReverted):
+		p.Status = ProposalFailed
+		p.Error = err.Error()
+		p.TxHash = receipt.TxHash
+		p.BlockNumber = receipt.BlockNumber.Uint64()
+		if putErr := store.Put(p); putErr != nil {
+			return receipt, putErr
+		}
+		return receipt, err
+	case err != nil:
+		// the transaction may still be mined, so it stays sent
+		p.Error = err.Error()
+		if putErr := store.Put(p); putErr != nil {
+			return nil, putErr
+		}
+		return nil, err
+	}
+	return receipt, c.settleProposal(store, p, receipt)
+}
+
+// settleProposal records what became of p in receipt.
+func (c *ContractCaller) settleProposal(store ProposalStore, p *Proposal, receipt *types.Receipt) error {
+	p.TxHash = receipt.TxHash
+	p.BlockNumber = receipt.BlockNumber.Uint64()
+	if receipt.Status == types.ReceiptStatusFailed {
+		p.Status = ProposalFailed
+		p.Error = txmgr.ErrTxReverted.Error()
+		return store.Put(p)
+	}
+	p.Status = ProposalExecuted
+	p.Error = ""
+	log.Info("Contract caller proposal executed", "id", p.ID, "method", p.Method, "TxHash", p.TxHash)
+	return store.Put(p)
+}
+
+// checkApprovals verifies the signatures of p against the approvers
+// configured now, not those of when it was proposed.
+func (c *ContractCaller) checkApprovals(p *Proposal) error {
+	if c.Cfg.ApprovalThreshold == 0 {
+		return errors.New("no approval threshold configured")
+	}
+	hash, err := proposalHash(p)
+	if err != nil {
+		return err
+	}
+	if hash != p.Hash {
+		return fmt.Errorf("proposal %s does not match its hash %s", p.ID, p.Hash)
+	}
+	approved := make(map[ethc.Address]bool)
+	for _, approval := range p.Approvals {
+		approver, err := recoverApprover(hash, approval.Signature)
+		if err != nil || approver != approval.Approver || !isApprover(c.Cfg.Approvers, approver) {
+			log.Warn("Contract caller ignoring proposal approval", "id", p.ID, "approver", approval.Approver, "err", err)
+			continue
+		}
+		approved[approver] = true
+	}
+	if len(approved) < c.Cfg.ApprovalThreshold {
+		return fmt.Errorf("proposal %s has %d of %d approvals", p.ID, len(approved), c.Cfg.ApprovalThreshold)
+	}
+	return nil
+}
+
+// requiresProposal is whether req calls one of ProposalMethods while
+// approvals are configured.
+func (c *ContractCaller) requiresProposal(req *TxRequest) (string, bool) {
+	if c.Cfg.ApprovalThreshold == 0 || req.approved || req.To != c.Cfg.TreasureManagerAddr || len(req.Data) < 4 {
+		return "", false
+	}
+	method, err := c.TreasureManagerABI.MethodById(req.Data[:4])
+	if err != nil {
+		return "", false
+	}
+	return method.RawName, ProposalMethods[method.RawName]
+}
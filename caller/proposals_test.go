@@ -0,0 +1,256 @@
+package caller
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// approverKeys returns three approvers, two of which are needed.
+func approverKeys(t *testing.T) (ContractCallerConfig, []*ecdsa.PrivateKey) {
+	cfg := ContractCallerConfig{ApprovalThreshold: 2}
+	keys := make([]*ecdsa.PrivateKey, 3)
+	for i := range keys {
+		var approver common.Address
+		keys[i], approver = newTestKey(t)
+		cfg.Approvers = append(cfg.Approvers, approver)
+	}
+	return cfg, keys
+}
+
+func approve(t *testing.T, c *ContractCaller, store ProposalStore, p *Proposal, keys ...*ecdsa.PrivateKey) {
+	for _, key := range keys {
+		signature, err := crypto.Sign(p.Hash[:], key)
+		require.Nil(t, err)
+		_, err = ApproveProposal(store, p.ID, signature, c.Cfg.Approvers)
+		require.Nil(t, err)
+	}
+}
+
+func TestExecuteProposal(t *testing.T) {
+	cfg, keys := approverKeys(t)
+	_, tm, c, _ := withdrawalCaller(t, cfg)
+	proposals := NewMemoryProposalStore()
+	ctx := context.Background()
+
+	_, err := c.WithdrawETH(ctx, alice, ether(1))
+	require.ErrorIs(t, err, ErrProposalRequired)
+
+	p, err := c.Propose(ctx, proposals, "withdrawETH", nil, alice, ether(1))
+	require.Nil(t, err)
+	require.NotZero(t, p.SimulatedGas)
+	_, _, err = c.ExecuteProposal(ctx, proposals, p.ID)
+	require.ErrorContains(t, err, "has 0 of 2 approvals")
+	approve(t, c, proposals, p, keys[0], keys[2])
+
+	// executed once, however many execute it at the same time
+	var wg sync.WaitGroup
+	errs := make(chan error, 4)
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _, err := c.ExecuteProposal(ctx, proposals, p.ID)
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	failed := 0
+	for err := range errs {
+		if err != nil {
+			failed++
+		}
+	}
+	require.Equal(t, 3, failed)
+	require.Equal(t, 1, withdrawCount(t, tm))
+	p, err = proposals.Get(p.ID)
+	require.Nil(t, err)
+	require.Equal(t, ProposalExecuted, p.Status)
+}
+
+func TestExecuteProposalAgain(t *testing.T) {
+	tests := []struct {
+		name string
+		// interrupt fails the first execution of the proposal
+		interrupt func(chain *testChain) context.Context
+		// resume decides what becomes of its transaction before the rerun
+		resume   func(chain *testChain, p *Proposal)
+		status   ProposalStatus
+		inFlight bool
+	}{
+		{
+			name: "not broadcast",
+			interrupt: func(chain *testChain) context.Context {
+				chain.Refuse(errors.New("connection refused"))
+				return timeout(t, 300*time.Millisecond)
+			},
+			resume: func(chain *testChain, p *Proposal) { chain.Refuse(nil) },
+			status: ProposalOpen,
+		},
+		{
+			name: "context cancelled",
+			interrupt: func(chain *testChain) context.Context {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				return ctx
+			},
+			resume: func(chain *testChain, p *Proposal) {},
+			status: ProposalSent,
+		},
+		{
+			name:      "in flight",
+			interrupt: pausedChain,
+			resume:    func(chain *testChain, p *Proposal) {},
+			status:    ProposalSent,
+			inFlight:  true,
+		},
+		{
+			name:      "mined meanwhile",
+			interrupt: pausedChain,
+			resume:    func(chain *testChain, p *Proposal) { chain.Resume() },
+			status:    ProposalSent,
+		},
+		{
+			name:      "dropped",
+			interrupt: pausedChain,
+			resume: func(chain *testChain, p *Proposal) {
+				chain.Drop(p.Sent.TxHashes[0])
+				chain.Resume()
+			},
+			status: ProposalSent,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, keys := approverKeys(t)
+			chain, tm, c, _ := withdrawalCaller(t, cfg)
+			proposals := NewMemoryProposalStore()
+			p, err := c.Propose(context.Background(), proposals, "withdrawETH", nil, alice, ether(1))
+			require.Nil(t, err)
+			approve(t, c, proposals, p, keys[0], keys[1])
+
+			_, _, err = c.ExecuteProposal(tt.interrupt(chain), proposals, p.ID)
+			require.Error(t, err)
+			p, err = proposals.Get(p.ID)
+			require.Nil(t, err)
+			require.Equal(t, tt.status, p.Status)
+			tt.resume(chain, p)
+
+			_, _, err = c.ExecuteProposal(context.Background(), proposals, p.ID)
+			if tt.inFlight {
+				require.ErrorIs(t, err, ErrTxInFlight)
+				require.Equal(t, 0, withdrawCount(t, tm))
+				chain.Resume()
+				_, _, err = c.ExecuteProposal(context.Background(), proposals, p.ID)
+			}
+			require.Nil(t, err)
+			p, err = proposals.Get(p.ID)
+			require.Nil(t, err)
+			require.Equal(t, ProposalExecuted, p.Status)
+			require.Equal(t, 1, withdrawCount(t, tm))
+
+			_, _, err = c.ExecuteProposal(context.Background(), proposals, p.ID)
+			require.ErrorContains(t, err, "is executed")
+			require.Equal(t, 1, withdrawCount(t, tm))
+		})
+	}
+}
+
+// pausedChain keeps what is sent from being mined, until a short deadline.
+func pausedChain(chain *testChain) context.Context {
+	chain.Pause()
+	return timeout(chain.t, 300*time.Millisecond)
+}
+
+func timeout(t *testing.T, d time.Duration) context.Context {
+	ctx, cancel := context.WithTimeout(context.Background(), d)
+	t.Cleanup(cancel)
+	return ctx
+}
+
+func TestWithdrawalProposal(t *testing.T) {
+	cfg, keys := approverKeys(t)
+	_, tm, c, withdrawals := withdrawalCaller(t, cfg, 4, 4, 4)
+	proposals := NewMemoryProposalStore()
+	policy := testPolicy(t)
+	ctx := context.Background()
+
+	_, err := c.ExecuteWithdrawal(ctx, policy, withdrawals, "a")
+	require.ErrorIs(t, err, ErrProposalRequired)
+	_, err = c.ExecuteWithdrawalProposal(ctx, policy, withdrawals, proposals, "a")
+	require.ErrorIs(t, err, ErrProposalRequired)
+
+	// each is within the policy until the others are sent
+	var ps []*Proposal
+	for _, id := range []string{"a", "b", "c"} {
+		w, p, err := c.ProposeWithdrawal(ctx, policy, withdrawals, proposals, id)
+		require.Nil(t, err)
+		require.Equal(t, p.ID, w.ProposalID)
+		require.Equal(t, id, p.WithdrawalID)
+		ps = append(ps, p)
+	}
+	_, _, err = c.ProposeWithdrawal(ctx, policy, withdrawals, proposals, "a")
+	require.ErrorContains(t, err, "is already proposed")
+
+	_, err = c.ExecuteWithdrawalProposal(ctx, policy, withdrawals, proposals, "a")
+	require.ErrorContains(t, err, "has 0 of 2 approvals")
+	for _, p := range ps {
+		approve(t, c, proposals, p, keys[0], keys[1])
+	}
+	_, _, err = c.ExecuteProposal(ctx, proposals, ps[0].ID)
+	require.ErrorContains(t, err, "execute the withdrawal instead")
+
+	for _, id := range []string{"a", "b"} {
+		w, err := c.ExecuteWithdrawalProposal(ctx, policy, withdrawals, proposals, id)
+		require.Nil(t, err)
+		require.Equal(t, WithdrawalExecuted, w.Status)
+		p, err := proposals.Get(w.ProposalID)
+		require.Nil(t, err)
+		require.Equal(t, ProposalExecuted, p.Status)
+		require.Equal(t, w.TxHash, p.TxHash)
+	}
+	w, err := c.ExecuteWithdrawalProposal(ctx, policy, withdrawals, proposals, "c")
+	require.ErrorIs(t, err, ErrWithdrawalPolicy)
+	require.ErrorContains(t, err, "over the daily limit")
+	require.Equal(t, WithdrawalApproved, w.Status)
+	p, err := proposals.Get(ps[2].ID)
+	require.Nil(t, err)
+	require.Equal(t, ProposalOpen, p.Status)
+	require.Equal(t, 2, withdrawCount(t, tm))
+
+	// the policy is checked when proposing too
+	_, _, err = c.ProposeWithdrawal(ctx, policy, withdrawals, proposals, "c")
+	require.ErrorContains(t, err, "is already proposed")
+	require.Nil(t, withdrawals.Put(&Withdrawal{ID: "d", Token: w.Token, To: alice, Amount: ether(4), RequestedBy: "ops", ApprovedBy: "treasurer", Status: WithdrawalApproved}))
+	_, _, err = c.ProposeWithdrawal(ctx, policy, withdrawals, proposals, "d")
+	require.ErrorIs(t, err, ErrWithdrawalPolicy)
+}
+
+func TestProposalDoesNotSendAnotherWithdrawal(t *testing.T) {
+	cfg, keys := approverKeys(t)
+	_, tm, c, withdrawals := withdrawalCaller(t, cfg, 1, 2)
+	proposals := NewMemoryProposalStore()
+	policy := testPolicy(t)
+	ctx := context.Background()
+
+	_, p, err := c.ProposeWithdrawal(ctx, policy, withdrawals, proposals, "a")
+	require.Nil(t, err)
+	approve(t, c, proposals, p, keys[1], keys[2])
+	b, err := withdrawals.Get("b")
+	require.Nil(t, err)
+	b.ProposalID = p.ID
+	require.Nil(t, withdrawals.Put(b))
+
+	_, err = c.ExecuteWithdrawalProposal(ctx, policy, withdrawals, proposals, "b")
+	require.ErrorContains(t, err, "is not of withdrawal b")
+	require.Equal(t, 0, withdrawCount(t, tm))
+}
@@ -0,0 +1,50 @@
+package caller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	ethc "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// ErrStateDrift is reported by reconcilers in observe-only mode when the
+// contract no longer matches the desired state.
+var ErrStateDrift = errors.New("contract state drifted from desired state")
+
+const (
+	ReconcileInSync    = "in sync"
+	ReconcileCorrected = "corrected"
+)
+
+// reconcileWithdrawManager makes WithdrawManager() equal the configured
+// address, sending setWithdrawManager only when they differ. In observe-only
+// mode the difference is reported as ErrStateDrift instead.
+func (c *ContractCaller) reconcileWithdrawManager(ctx context.Context) (string, error) {
+	desired := ethc.HexToAddress(c.Cfg.WithdrawManageAddr)
+	current, err := c.TreasureManagerContract.WithdrawManager(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return "", fmt.Errorf("get withdraw manager: %w", err)
+	}
+	if current == desired {
+		return ReconcileInSync, nil
+	}
+	if c.Cfg.ObserveOnly {
+		return "", fmt.Errorf("%w: withdraw manager is %s, want %s", ErrStateDrift, current, desired)
+	}
+
+	balance, err := c.Cfg.ChainClient.BalanceAt(ctx, c.WalletAddr, nil)
+	if err != nil {
+		log.Error("Contract caller unable to get current balance", "err", err)
+		return "", err
+	}
+	log.Info("Contract caller correct withdraw manager", "current", current, "desired", desired, "balance", balance)
+	receipt, err := c.SetWithdrawManager(ctx, desired)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s %s", ReconcileCorrected, receipt.TxHash), nil
+}
@@ -0,0 +1,69 @@
+package caller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestReconcileWithdrawManager(t *testing.T) {
+	manager := common.HexToAddress(jobsManager)
+	tests := []struct {
+		name        string
+		desired     common.Address
+		observeOnly bool
+		notOwner    bool
+		result      string
+		err         error
+		errContains string
+		want        common.Address
+	}{
+		{name: "in sync", desired: manager, result: ReconcileInSync, want: manager},
+		{name: "corrected", result: ReconcileCorrected, want: manager},
+		{name: "observe only", observeOnly: true, err: ErrStateDrift},
+		{name: "not the owner", notOwner: true, errContains: "reverted"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, owner := newTestKey(t)
+			otherKey, other := newTestKey(t)
+			chain := newTestChain(t, owner, other)
+			tmAddr, tm := chain.DeployTreasureManager(key, owner)
+			if tt.desired == manager {
+				chain.Wait(tm.SetWithdrawManager(chain.Transactor(key), manager))
+			}
+			callerKey := key
+			if tt.notOwner {
+				callerKey = otherKey
+			}
+			c := chain.NewCaller(callerKey, tmAddr, ContractCallerConfig{WithdrawManageAddr: jobsManager, ObserveOnly: tt.observeOnly})
+			require.Len(t, c.reconcilers, 1)
+
+			result, err := c.reconcileWithdrawManager(context.Background())
+			current, callErr := tm.WithdrawManager(&bind.CallOpts{})
+			require.Nil(t, callErr)
+			switch {
+			case tt.err != nil:
+				require.ErrorIs(t, err, tt.err)
+				require.Equal(t, owner, current)
+				return
+			case tt.errContains != "":
+				require.ErrorContains(t, err, tt.errContains)
+				require.Equal(t, owner, current)
+				return
+			}
+			require.Nil(t, err)
+			require.Contains(t, result, tt.result)
+			require.Equal(t, tt.want, current)
+
+			// a second run finds nothing left to do
+			result, err = c.reconcileWithdrawManager(context.Background())
+			require.Nil(t, err)
+			require.Equal(t, ReconcileInSync, result)
+		})
+	}
+}
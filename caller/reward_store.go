@@ -0,0 +1,142 @@
+package caller
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"sync"
+	"time"
+
+	ethc "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/ethdb/leveldb"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+)
+
+type RewardStatus string
+
+const (
+	// RewardStatusPending is recorded before grantRewards is sent, so a
+	// crash leaves a trace that is resolved against the chain on rerun.
+	RewardStatusPending RewardStatus = "pending"
+	RewardStatusGranted RewardStatus = "granted"
+	RewardStatusFailed  RewardStatus = "failed"
+)
+
+var ErrRewardRecordNotFound = errors.New("caller: reward record not found")
+
+// RewardRecord is the outcome of one schedule entry, by its idempotency key.
+type RewardRecord struct {
+	Key     string       `json:"key"`
+	Token   ethc.Address `json:"token"`
+	Granter ethc.Address `json:"granter"`
+	Amount  *big.Int     `json:"amount"`
+	Epoch   uint64       `json:"epoch"`
+	Status  RewardStatus `json:"status"`
+	// StartBlock is the head when the grant was first sent, where its event
+	// is looked for when the record is still pending.
+	StartBlock uint64 `json:"startBlock"`
+	// Sent is the transaction granting it, nil until one is signed.
+	Sent        *SentTx   `json:"sent,omitempty"`
+	TxHash      ethc.Hash `json:"txHash,omitempty"`
+	BlockNumber uint64    `json:"blockNumber,omitempty"`
+	Error       string    `json:"error,omitempty"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// RewardStore durably records which schedule entries were granted, so a
+// rerun of a schedule never grants an entry twice.
+type RewardStore interface {
+	Get(key string) (*RewardRecord, error)
+	Put(record *RewardRecord) error
+	// List returns every record, by key.
+	List() ([]*RewardRecord, error)
+	Close() error
+}
+
+var rewardPrefix = []byte("rwd-")
+
+// KVRewardStore is a RewardStore backed by any go-ethereum key-value store.
+type KVRewardStore struct {
+	db ethdb.KeyValueStore
+	mu sync.Mutex
+}
+
+func NewKVRewardStore(db ethdb.KeyValueStore) *KVRewardStore {
+	return &KVRewardStore{db: db}
+}
+
+// NewLevelDBRewardStore opens (or creates) an on-disk store at path.
+func NewLevelDBRewardStore(path string) (*KVRewardStore, error) {
+	db, err := leveldb.New(path, 16, 16, "", false)
+	if err != nil {
+		return nil, err
+	}
+	return NewKVRewardStore(db), nil
+}
+
+// NewMemoryRewardStore returns a store that does not survive restarts, for
+// planning without a store.
+func NewMemoryRewardStore() *KVRewardStore {
+	return NewKVRewardStore(memorydb.New())
+}
+
+func rewardKey(key string) []byte {
+	return append(append([]byte{}, rewardPrefix...), key...)
+}
+
+func (s *KVRewardStore) Get(key string) (*RewardRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ok, err := s.db.Has(rewardKey(key))
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrRewardRecordNotFound
+	}
+	value, err := s.db.Get(rewardKey(key))
+	if err != nil {
+		return nil, err
+	}
+	var record RewardRecord
+	if err := json.Unmarshal(value, &record); err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+func (s *KVRewardStore) Put(record *RewardRecord) error {
+	record.UpdatedAt = time.Now()
+	value, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.db.Put(rewardKey(record.Key), value)
+}
+
+func (s *KVRewardStore) List() ([]*RewardRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	it := s.db.NewIterator(rewardPrefix, nil)
+	defer it.Release()
+
+	var records []*RewardRecord
+	for it.Next() {
+		var record RewardRecord
+		if err := json.Unmarshal(it.Value(), &record); err != nil {
+			return nil, err
+		}
+		records = append(records, &record)
+	}
+	return records, it.Error()
+}
+
+func (s *KVRewardStore) Close() error {
+	return s.db.Close()
+}
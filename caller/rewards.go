@@ -0,0 +1,421 @@
+package caller
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	ethc "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/the-web3/contracts-caller/bindings"
+	common2 "github.com/the-web3/contracts-caller/common"
+	"github.com/the-web3/contracts-caller/txmgr"
+)
+
+const (
+	RewardActionGrant   = "grant"
+	RewardActionDone    = "done"
+	RewardActionPending = "pending"
+)
+
+// RewardEntry is one line of a reward schedule.
+type RewardEntry struct {
+	Token   ethc.Address `json:"token"`
+	Granter ethc.Address `json:"granter"`
+	Amount  *big.Int     `json:"amount"`
+	Epoch   uint64       `json:"epoch"`
+	// ID is the idempotency key, epoch:token:granter when empty.
+	ID string `json:"id,omitempty"`
+}
+
+func (e *RewardEntry) Key() string {
+	if e.ID != "" {
+		return e.ID
+	}
+	return fmt.Sprintf("%d:%s:%s", e.Epoch, e.Token.Hex(), e.Granter.Hex())
+}
+
+// LoadRewardSchedule reads a .csv with a token,granter,amount,epoch[,id]
+// header, or a .json array of objects with the same fields.
+func LoadRewardSchedule(path string) ([]*RewardEntry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var rows []map[string]string
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		rows, err = readCSVRows(f)
+	case ".json":
+		rows, err = readJSONRows(f)
+	default:
+		return nil, fmt.Errorf("reward schedule %s: not a .csv or .json file", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reward schedule %s: %w", path, err)
+	}
+
+	entries := make([]*RewardEntry, 0, len(rows))
+	for i, row := range rows {
+		entry, err := parseRewardRow(row)
+		if err != nil {
+			return nil, fmt.Errorf("reward schedule %s entry %d: %w", path, i+1, err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func readCSVRows(r io.Reader) ([]map[string]string, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+	header := records[0]
+	rows := make([]map[string]string, 0, len(records)-1)
+	for _, record := range records[1:] {
+		row := make(map[string]string, len(header))
+		for i, name := range header {
+			row[strings.TrimSpace(name)] = strings.TrimSpace(record[i])
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func readJSONRows(r io.Reader) ([]map[string]string, error) {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	var objects []map[string]interface{}
+	if err := dec.Decode(&objects); err != nil {
+		return nil, err
+	}
+	rows := make([]map[string]string, 0, len(objects))
+	for _, object := range objects {
+		row := make(map[string]string, len(object))
+		for name, value := range object {
+			row[name] = fmt.Sprint(value)
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func parseRewardRow(row map[string]string) (*RewardEntry, error) {
+	token, err := common2.ParseAddress(row["token"])
+	if err != nil {
+		return nil, fmt.Errorf("token: %w", err)
+	}
+	granter, err := common2.ParseAddress(row["granter"])
+	if err != nil {
+		return nil, fmt.Errorf("granter: %w", err)
+	}
+	amount, ok := new(big.Int).SetString(row["amount"], 0)
+	if !ok || amount.Sign() <= 0 {
+		return nil, fmt.Errorf("invalid amount: %v", row["amount"])
+	}
+	epoch, err := strconv.ParseUint(row["epoch"], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid epoch: %v", row["epoch"])
+	}
+	return &RewardEntry{Token: token, Granter: granter, Amount: amount, Epoch: epoch, ID: row["id"]}, nil
+}
+
+// PlannedReward is what granting a schedule does with one entry.
+type PlannedReward struct {
+	Key     string       `json:"key"`
+	Token   ethc.Address `json:"token"`
+	Granter ethc.Address `json:"granter"`
+	Amount  string       `json:"amount"`
+	Epoch   uint64       `json:"epoch"`
+	Action  string       `json:"action"`
+}
+
+// RewardTokenTotal is what a schedule still grants of a token, against what
+// the treasury holds of it.
+type RewardTokenTotal struct {
+	Token   ethc.Address `json:"token"`
+	ToGrant string       `json:"toGrant"`
+	Balance string       `json:"balance"`
+}
+
+type RewardPlan struct {
+	Entries []PlannedReward    `json:"entries"`
+	Tokens  []RewardTokenTotal `json:"tokens"`
+	// Problems keep the schedule from being granted at all.
+	Problems []string `json:"problems"`
+}
+
+// PlanRewards validates entries against the whitelist and tokenBalances of
+// tm, and against what store already granted. Pending entries count as still
+// to grant.
+func PlanRewards(ctx context.Context, tm *bindings.TreasureManagerCaller, store RewardStore, entries []*RewardEntry) (*RewardPlan, error) {
+	opts := &bind.CallOpts{Context: ctx}
+	whitelist, err := tm.GetTokenWhiteList(opts)
+	if err != nil {
+		return nil, fmt.Errorf("getTokenWhiteList: %w", err)
+	}
+	whitelisted := make(map[ethc.Address]bool, len(whitelist))
+	for _, token := range whitelist {
+		whitelisted[token] = true
+	}
+
+	plan := &RewardPlan{Entries: []PlannedReward{}, Tokens: []RewardTokenTotal{}, Problems: []string{}}
+	seen := make(map[string]bool, len(entries))
+	toGrant := make(map[ethc.Address]*big.Int)
+	var tokens []ethc.Address
+	for _, entry := range entries {
+		key := entry.Key()
+		if seen[key] {
+			plan.Problems = append(plan.Problems, fmt.Sprintf("duplicate entry %s", key))
+			continue
+		}
+		seen[key] = true
+
+		action := RewardActionGrant
+		record, err := store.Get(key)
+		switch {
+		case errors.Is(err, ErrRewardRecordNotFound):
+		case err != nil:
+			return nil, err
+		case record.Status == RewardStatusGranted:
+			action = RewardActionDone
+		case record.Status == RewardStatusPending:
+			action = RewardActionPending
+		}
+		plan.Entries = append(plan.Entries, PlannedReward{
+			Key:     key,
+			Token:   entry.Token,
+			Granter: entry.Granter,
+			Amount:  entry.Amount.String(),
+			Epoch:   entry.Epoch,
+			Action:  action,
+		})
+		if action == RewardActionDone {
+			continue
+		}
+		if !whitelisted[entry.Token] {
+			plan.Problems = append(plan.Problems, fmt.Sprintf("entry %s: token %s is not whitelisted", key, entry.Token))
+		}
+		if entry.Granter == (ethc.Address{}) {
+			plan.Problems = append(plan.Problems, fmt.Sprintf("entry %s: zero granter", key))
+		}
+		if toGrant[entry.Token] == nil {
+			toGrant[entry.Token] = new(big.Int)
+			tokens = append(tokens, entry.Token)
+		}
+		toGrant[entry.Token].Add(toGrant[entry.Token], entry.Amount)
+	}
+
+	for _, token := range tokens {
+		balance, err := tm.TokenBalances(opts, token)
+		if err != nil {
+			return nil, fmt.Errorf("tokenBalances(%s): %w", token, err)
+		}
+		if toGrant[token].Cmp(balance) > 0 {
+			plan.Problems = append(plan.Problems, fmt.Sprintf("token %s: granting %s but the treasure holds %s", token, toGrant[token], balance))
+		}
+		plan.Tokens = append(plan.Tokens, RewardTokenTotal{Token: token, ToGrant: toGrant[token].String(), Balance: balance.String()})
+	}
+	return plan, nil
+}
+
+// GrantRewardSchedule grants every entry not granted yet, in order, and
+// stops at the first failure. Each grant is recorded as pending before it is
+// sent and as granted once its GrantRewardTokenAmount event is in the
+// receipt. A pending record left by a crash is looked up on chain first, and
+// stops the run with ErrTxInFlight while its transaction may still be mined,
+// so rerunning a schedule never grants an entry twice.
+func (c *ContractCaller) GrantRewardSchedule(ctx context.Context, store RewardStore, entries []*RewardEntry) (*RewardPlan, []*RewardRecord, error) {
+	for _, entry := range entries {
+		record, err := store.Get(entry.Key())
+		if err != nil || record.Status != RewardStatusPending {
+			continue
+		}
+		if err := c.resolvePendingReward(ctx, store, record); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	tm := &c.TreasureManagerContract.TreasureManagerCaller
+	plan, err := PlanRewards(ctx, tm, store, entries)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(plan.Problems) > 0 {
+		return plan, nil, fmt.Errorf("reward schedule not granted: %s", strings.Join(plan.Problems, "; "))
+	}
+
+	var records []*RewardRecord
+	for i, entry := range entries {
+		if plan.Entries[i].Action == RewardActionDone {
+			continue
+		}
+		record, err := c.grantReward(ctx, store, entry)
+		if record != nil {
+			records = append(records, record)
+		}
+		if err != nil {
+			return plan, records, fmt.Errorf("entry %s: %w", entry.Key(), err)
+		}
+	}
+	return plan, records, nil
+}
+
+func (c *ContractCaller) grantReward(ctx context.Context, store RewardStore, entry *RewardEntry) (*RewardRecord, error) {
+	head, err := c.Cfg.ChainClient.BlockNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+	record := &RewardRecord{
+		Key:        entry.Key(),
+		Token:      entry.Token,
+		Granter:    entry.Granter,
+		Amount:     entry.Amount,
+		Epoch:      entry.Epoch,
+		Status:     RewardStatusPending,
+		StartBlock: head,
+	}
+	if err := store.Put(record); err != nil {
+		return nil, err
+	}
+
+	record.Sent = &SentTx{}
+	ctx = recordSent(ctx, record.Sent, func() error { return store.Put(record) })
+	receipt, err := c.GrantRewards(ctx, entry.Token, entry.Granter, entry.Amount)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrSimulationReverted) || errors.Is(err, txmgr.ErrTxReverted):
+			record.Status = RewardStatusFailed
+			record.Error = err.Error()
+		case errors.Is(err, txmgr.ErrNotBroadcast):
+			record.Sent = nil
+		default:
+			// a transaction that may still be mined stays pending
+			return record, err
+		}
+		if putErr := store.Put(record); putErr != nil {
+			return record, putErr
+		}
+		return record, err
+	}
+	return record, c.settleReward(store, record, receipt)
+}
+
+// settleReward records the outcome of the grant mined with receipt.
+func (c *ContractCaller) settleReward(store RewardStore, record *RewardRecord, receipt *types.Receipt) error {
+	record.TxHash = receipt.TxHash
+	record.BlockNumber = receipt.BlockNumber.Uint64()
+	switch {
+	case receipt.Status == types.ReceiptStatusFailed:
+		record.Status = RewardStatusFailed
+		record.Error = txmgr.ErrTxReverted.Error()
+	case !c.hasGrantEvent(receipt, record):
+		return fmt.Errorf("no matching GrantRewardTokenAmount event in %s", receipt.TxHash)
+	default:
+		record.Status = RewardStatusGranted
+		record.Error = ""
+		log.Info("Contract caller reward granted", "key", record.Key, "token", record.Token,
+			"granter", record.Granter, "amount", record.Amount, "TxHash", record.TxHash)
+	}
+	return store.Put(record)
+}
+
+func (c *ContractCaller) hasGrantEvent(receipt *types.Receipt, record *RewardRecord) bool {
+	for _, l := range receipt.Logs {
+		if l.Address != c.Cfg.TreasureManagerAddr {
+			continue
+		}
+		event, err := c.TreasureManagerContract.ParseGrantRewardTokenAmount(*l)
+		if err != nil {
+			continue
+		}
+		if event.TokenAddress == record.Token && event.Granter == record.Granter && event.Amount.Cmp(record.Amount) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// resolvePendingReward finds out what became of the grant of a pending
+// record. The transactions recorded as sent for it are looked up first: a
+// mined one settles the record, and one that may still be mined keeps it
+// pending with ErrTxInFlight. Its Safe transaction is executed once it holds
+// enough signatures. Only once they were dropped, or the Safe transaction
+// replaced, is the record left for the grant to be sent again. A record
+// without sent transactions, e.g. of a crash before one was signed, is
+// looked for among the GrantRewardTokenAmount events sent by this caller
+// since record.StartBlock.
+func (c *ContractCaller) resolvePendingReward(ctx context.Context, store RewardStore, record *RewardRecord) error {
+	if record.Sent.known() {
+		receipt, err := c.resolveSentTx(ctx, record.Sent, func() error { return store.Put(record) })
+		switch {
+		case err != nil:
+			return errors.Wrapf(err, "reward %s", record.Key)
+		case receipt != nil:
+			log.Info("Contract caller pending reward mined", "key", record.Key, "TxHash", receipt.TxHash)
+			return c.settleReward(store, record, receipt)
+		}
+		log.Warn("Contract caller pending reward dropped, granting again", "key", record.Key, "nonce", record.Sent.Nonce,
+			"safeTxHash", record.Sent.SafeTxHash)
+		return nil
+	}
+
+	records, err := store.List()
+	if err != nil {
+		return err
+	}
+	owned := make(map[ethc.Hash]bool)
+	for _, other := range records {
+		if other.Status == RewardStatusGranted {
+			owned[other.TxHash] = true
+		}
+	}
+
+	it, err := c.TreasureManagerContract.FilterGrantRewardTokenAmount(
+		&bind.FilterOpts{Start: record.StartBlock, Context: ctx}, []ethc.Address{record.Token})
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+	for it.Next() {
+		event := it.Event
+		if owned[event.Raw.TxHash] || event.Granter != record.Granter || event.Amount.Cmp(record.Amount) != 0 {
+			continue
+		}
+		tx, _, err := c.Cfg.ChainClient.TransactionByHash(ctx, event.Raw.TxHash)
+		if err != nil {
+			return err
+		}
+		if !c.sentByCaller(tx) {
+			continue
+		}
+		record.Status = RewardStatusGranted
+		record.TxHash = event.Raw.TxHash
+		record.BlockNumber = event.Raw.BlockNumber
+		log.Info("Contract caller pending reward found on chain", "key", record.Key, "TxHash", record.TxHash)
+		return store.Put(record)
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+	log.Warn("Contract caller pending reward not on chain, granting again", "key", record.Key)
+	return nil
+}
@@ -0,0 +1,101 @@
+package caller
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+
+	"github.com/the-web3/contracts-caller/bindings"
+)
+
+// grantCount is how many GrantRewardTokenAmount events tm emitted.
+func grantCount(t *testing.T, tm *bindings.TreasureManager) int {
+	it, err := tm.FilterGrantRewardTokenAmount(&bind.FilterOpts{}, nil)
+	require.Nil(t, err)
+	defer it.Close()
+	count := 0
+	for it.Next() {
+		count++
+	}
+	require.Nil(t, it.Error())
+	return count
+}
+
+func TestGrantRewardSchedule(t *testing.T) {
+	tests := []struct {
+		name string
+		// interrupt is given the grant left pending as by a crash, and decides
+		// what becomes of its transaction before the rerun
+		interrupt func(chain *testChain, sent *SentTx)
+		inFlight  bool
+	}{
+		{
+			name: "granted",
+		},
+		{
+			name:      "in flight",
+			interrupt: func(chain *testChain, sent *SentTx) {},
+			inFlight:  true,
+		},
+		{
+			name:      "mined meanwhile",
+			interrupt: func(chain *testChain, sent *SentTx) { chain.Resume() },
+		},
+		{
+			name: "dropped",
+			interrupt: func(chain *testChain, sent *SentTx) {
+				chain.Drop(sent.TxHashes[0])
+				chain.Resume()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, owner := newTestKey(t)
+			chain := newTestChain(t, owner)
+			tmAddr, tm := chain.DeployTreasureManager(key, owner)
+			c := chain.NewCaller(key, tmAddr, ContractCallerConfig{})
+			eth, err := tm.EthAddress(nil)
+			require.Nil(t, err)
+			store := NewMemoryRewardStore()
+			entries := []*RewardEntry{{Token: eth, Granter: alice, Amount: big.NewInt(1000), Epoch: 1}}
+
+			if tt.interrupt != nil {
+				chain.Pause()
+				ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+				_, _, err := c.GrantRewardSchedule(ctx, store, entries)
+				cancel()
+				require.ErrorIs(t, err, context.DeadlineExceeded)
+				record, err := store.Get(entries[0].Key())
+				require.Nil(t, err)
+				require.Equal(t, RewardStatusPending, record.Status)
+				require.Len(t, record.Sent.TxHashes, 1)
+				tt.interrupt(chain, record.Sent)
+			}
+
+			_, _, err = c.GrantRewardSchedule(context.Background(), store, entries)
+			if tt.inFlight {
+				require.ErrorIs(t, err, ErrTxInFlight)
+				require.Equal(t, 0, grantCount(t, tm))
+				chain.Resume()
+				_, _, err = c.GrantRewardSchedule(context.Background(), store, entries)
+			}
+			require.Nil(t, err)
+			record, err := store.Get(entries[0].Key())
+			require.Nil(t, err)
+			require.Equal(t, RewardStatusGranted, record.Status)
+			require.Equal(t, 1, grantCount(t, tm))
+
+			// a rerun grants nothing
+			_, records, err := c.GrantRewardSchedule(context.Background(), store, entries)
+			require.Nil(t, err)
+			require.Empty(t, records)
+			require.Equal(t, 1, grantCount(t, tm))
+		})
+	}
+}
@@ -0,0 +1,285 @@
+package caller
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	ethc "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+
+	"github.com/the-web3/contracts-caller/bindings"
+	common2 "github.com/the-web3/contracts-caller/common"
+)
+
+// roleLogChunk is the block range of one eth_getLogs when replaying roles.
+const roleLogChunk = 5000
+
+const (
+	RoleActionGrant             = "grant"
+	RoleActionRevoke            = "revoke"
+	RoleActionTransferOwnership = "transfer-ownership"
+)
+
+// RolesManifest is the desired AccessControl state of the TreasureManager:
+//
+//	owner: "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"
+//	fromBlock: 1200000
+//	roles:
+//	  DEFAULT_ADMIN_ROLE: ["0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"]
+//	  "0x65d7a28e3265b37a6474929f336521b332c1681b933f6cb9f3376673440d862a":
+//	    - "0x70997970C51812dc3A010C7d01b54e8b0b6FA4d6"
+//
+// Only the roles listed are managed: their members are granted and anyone
+// else holding them is revoked. An empty list revokes everyone.
+type RolesManifest struct {
+	// Owner is the desired owner, not managed when empty.
+	Owner string `yaml:"owner"`
+	// FromBlock is where role events are replayed from, e.g. the block the
+	// contract was deployed at.
+	FromBlock uint64 `yaml:"fromBlock"`
+	// Roles maps a role, by name or 32-byte hex, to its members.
+	Roles map[string][]string `yaml:"roles"`
+}
+
+func LoadRolesManifest(path string) (*RolesManifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var manifest RolesManifest
+	if err := yaml.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("parse roles manifest %s: %w", path, err)
+	}
+	return &manifest, nil
+}
+
+// RoleChange is one transaction of a RolesPlan. Role is nil for an
+// ownership transfer.
+type RoleChange struct {
+	Action   string       `json:"action"`
+	RoleName string       `json:"roleName,omitempty"`
+	Role     *ethc.Hash   `json:"role,omitempty"`
+	Account  ethc.Address `json:"account"`
+}
+
+func (rc RoleChange) Method() string {
+	switch rc.Action {
+	case RoleActionGrant:
+		return "grantRole"
+	case RoleActionRevoke:
+		return "revokeRole"
+	default:
+		return "transferOwnership"
+	}
+}
+
+// RolesPlan is what it takes to bring the contract to a RolesManifest, as
+// read at Block.
+type RolesPlan struct {
+	Block uint64       `json:"block"`
+	Owner ethc.Address `json:"owner"`
+	// Members is the current membership of every managed role.
+	Members map[string][]ethc.Address `json:"members"`
+	Changes []RoleChange              `json:"changes"`
+}
+
+// PlanRoles compares manifest with the contract at the latest block.
+// Membership is rebuilt from RoleGranted and RoleRevoked logs since
+// manifest.FromBlock, then confirmed with hasRole, so accounts granted before
+// FromBlock are still found when they are in the manifest.
+func PlanRoles(ctx context.Context, client *ethclient.Client, contract ethc.Address, manifest *RolesManifest) (*RolesPlan, error) {
+	tm, err := bindings.NewTreasureManager(contract, client)
+	if err != nil {
+		return nil, err
+	}
+	header, err := client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	head := header.Number.Uint64()
+	opts := &bind.CallOpts{Context: ctx, BlockNumber: header.Number}
+
+	type managedRole struct {
+		name    string
+		role    ethc.Hash
+		desired []ethc.Address
+	}
+	var managed []managedRole
+	for name, accounts := range manifest.Roles {
+		role, err := common2.ParseRole(name)
+		if err != nil {
+			return nil, err
+		}
+		desired := make([]ethc.Address, 0, len(accounts))
+		for _, account := range accounts {
+			addr, err := common2.ParseAddress(account)
+			if err != nil {
+				return nil, fmt.Errorf("role %s: %w", name, err)
+			}
+			desired = append(desired, addr)
+		}
+		managed = append(managed, managedRole{name, ethc.Hash(role), desired})
+	}
+	sort.Slice(managed, func(i, j int) bool { return managed[i].name < managed[j].name })
+
+	members, err := RoleMembership(ctx, &tm.TreasureManagerFilterer, manifest.FromBlock, head)
+	if err != nil {
+		return nil, err
+	}
+	owner, err := tm.Owner(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &RolesPlan{Block: head, Owner: owner, Members: make(map[string][]ethc.Address), Changes: []RoleChange{}}
+	var revokes []RoleChange
+	for i := range managed {
+		m := &managed[i]
+		candidates := make(map[ethc.Address]bool)
+		for account := range members[m.role] {
+			candidates[account] = true
+		}
+		wanted := make(map[ethc.Address]bool, len(m.desired))
+		for _, account := range m.desired {
+			wanted[account] = true
+			candidates[account] = true
+		}
+
+		current := []ethc.Address{}
+		for _, account := range sortedAddresses(candidates) {
+			has, err := tm.HasRole(opts, m.role, account)
+			if err != nil {
+				return nil, err
+			}
+			if has {
+				current = append(current, account)
+			}
+			switch {
+			case wanted[account] && !has:
+				plan.Changes = append(plan.Changes, RoleChange{RoleActionGrant, m.name, &m.role, account})
+			case !wanted[account] && has:
+				revokes = append(revokes, RoleChange{RoleActionRevoke, m.name, &m.role, account})
+			}
+		}
+		plan.Members[m.name] = current
+	}
+
+	// grants go first so the contract is never left without an admin, and an
+	// ownership transfer last since it may take away the caller's own rights
+	plan.Changes = append(plan.Changes, revokes...)
+	if manifest.Owner != "" {
+		desiredOwner, err := common2.ParseAddress(manifest.Owner)
+		if err != nil {
+			return nil, fmt.Errorf("owner: %w", err)
+		}
+		if desiredOwner != owner {
+			plan.Changes = append(plan.Changes, RoleChange{Action: RoleActionTransferOwnership, Account: desiredOwner})
+		}
+	}
+	return plan, nil
+}
+
+// RoleMembership replays RoleGranted and RoleRevoked between from and to, in
+// chunks, and returns the members of every role seen.
+func RoleMembership(ctx context.Context, tm *bindings.TreasureManagerFilterer, from, to uint64) (map[[32]byte]map[ethc.Address]bool, error) {
+	type roleEvent struct {
+		log     types.Log
+		role    [32]byte
+		account ethc.Address
+		granted bool
+	}
+	members := make(map[[32]byte]map[ethc.Address]bool)
+	for start := from; start <= to; start += roleLogChunk {
+		end := start + roleLogChunk - 1
+		if end > to {
+			end = to
+		}
+		opts := &bind.FilterOpts{Start: start, End: &end, Context: ctx}
+
+		var events []roleEvent
+		granted, err := tm.FilterRoleGranted(opts, nil, nil, nil)
+		if err != nil {
+			return nil, fmt.Errorf("filter RoleGranted %d-%d: %w", start, end, err)
+		}
+		for granted.Next() {
+			e := granted.Event
+			events = append(events, roleEvent{e.Raw, e.Role, e.Account, true})
+		}
+		if err := granted.Error(); err != nil {
+			return nil, err
+		}
+		granted.Close()
+		revoked, err := tm.FilterRoleRevoked(opts, nil, nil, nil)
+		if err != nil {
+			return nil, fmt.Errorf("filter RoleRevoked %d-%d: %w", start, end, err)
+		}
+		for revoked.Next() {
+			e := revoked.Event
+			events = append(events, roleEvent{e.Raw, e.Role, e.Account, false})
+		}
+		if err := revoked.Error(); err != nil {
+			return nil, err
+		}
+		revoked.Close()
+
+		sort.Slice(events, func(i, j int) bool {
+			if events[i].log.BlockNumber != events[j].log.BlockNumber {
+				return events[i].log.BlockNumber < events[j].log.BlockNumber
+			}
+			return events[i].log.Index < events[j].log.Index
+		})
+		for _, e := range events {
+			if members[e.role] == nil {
+				members[e.role] = make(map[ethc.Address]bool)
+			}
+			if e.granted {
+				members[e.role][e.account] = true
+			} else {
+				delete(members[e.role], e.account)
+			}
+		}
+	}
+	return members, nil
+}
+
+// ApplyRolesPlan sends every change of plan in order and stops at the first
+// failure. The receipts of the changes made so far are returned either way.
+func (c *ContractCaller) ApplyRolesPlan(ctx context.Context, plan *RolesPlan) ([]*types.Receipt, error) {
+	var receipts []*types.Receipt
+	for _, change := range plan.Changes {
+		var (
+			receipt *types.Receipt
+			err     error
+		)
+		switch change.Action {
+		case RoleActionGrant:
+			receipt, err = c.GrantRole(ctx, *change.Role, change.Account)
+		case RoleActionRevoke:
+			receipt, err = c.RevokeRole(ctx, *change.Role, change.Account)
+		case RoleActionTransferOwnership:
+			receipt, err = c.TransferOwnership(ctx, change.Account)
+		default:
+			err = fmt.Errorf("unknown role action %s", change.Action)
+		}
+		if err != nil {
+			return receipts, fmt.Errorf("%s %s %s: %w", change.Action, change.RoleName, change.Account, err)
+		}
+		receipts = append(receipts, receipt)
+	}
+	return receipts, nil
+}
+
+func sortedAddresses(set map[ethc.Address]bool) []ethc.Address {
+	addrs := make([]ethc.Address, 0, len(set))
+	for addr := range set {
+		addrs = append(addrs, addr)
+	}
+	sort.Slice(addrs, func(i, j int) bool { return addrs[i].Cmp(addrs[j]) < 0 })
+	return addrs
+}
@@ -0,0 +1,172 @@
+package caller
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/the-web3/contracts-caller/bindings"
+	common2 "github.com/the-web3/contracts-caller/common"
+)
+
+var (
+	operatorRole = crypto.Keccak256Hash([]byte("OPERATOR"))
+	alice        = common.HexToAddress("0x70997970C51812dc3A010C7d01b54e8b0b6FA4d6")
+	bob          = common.HexToAddress("0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC")
+)
+
+// accessControlStorage is where AccessControlUpgradeable keeps its roles.
+var accessControlStorage = common.HexToHash("0x02dd7bc7dec4dceedda775e58dd541e08a116c6c53815c0bd028192f7b626800")
+
+// grantAdmin makes account an admin of tm. Initialize grants
+// DEFAULT_ADMIN_ROLE to no one, so it is written into storage directly.
+func grantAdmin(t *testing.T, chain *testChain, tm *bindings.TreasureManager, tmAddr, account common.Address) {
+	roleData := crypto.Keccak256Hash(common.Hash{}.Bytes(), accessControlStorage.Bytes())
+	slot := crypto.Keccak256Hash(common.LeftPadBytes(account.Bytes(), 32), roleData.Bytes())
+	chain.SetStorage(tmAddr, slot, common.BigToHash(common.Big1))
+	has, err := tm.HasRole(&bind.CallOpts{}, [32]byte{}, account)
+	require.Nil(t, err)
+	require.True(t, has)
+}
+
+func TestPlanAndApplyRoles(t *testing.T) {
+	tests := []struct {
+		name    string
+		granted []common.Address
+		roles   map[string][]string
+		owner   string
+		changes []RoleChange
+	}{
+		{
+			name:    "grant",
+			roles:   map[string][]string{"OPERATOR": {alice.Hex()}},
+			changes: []RoleChange{{RoleActionGrant, "OPERATOR", &operatorRole, alice}},
+		},
+		{
+			name:    "in sync",
+			granted: []common.Address{alice},
+			roles:   map[string][]string{"OPERATOR": {alice.Hex()}},
+			changes: []RoleChange{},
+		},
+		{
+			name:    "grants before revokes",
+			granted: []common.Address{bob},
+			roles:   map[string][]string{"OPERATOR": {alice.Hex()}},
+			changes: []RoleChange{
+				{RoleActionGrant, "OPERATOR", &operatorRole, alice},
+				{RoleActionRevoke, "OPERATOR", &operatorRole, bob},
+			},
+		},
+		{
+			name:    "empty list revokes everyone",
+			granted: []common.Address{alice, bob},
+			roles:   map[string][]string{operatorRole.Hex(): {}},
+			// by address
+			changes: []RoleChange{
+				{RoleActionRevoke, operatorRole.Hex(), &operatorRole, bob},
+				{RoleActionRevoke, operatorRole.Hex(), &operatorRole, alice},
+			},
+		},
+		{
+			name:    "ownership last",
+			roles:   map[string][]string{"OPERATOR": {bob.Hex()}},
+			owner:   alice.Hex(),
+			changes: []RoleChange{{RoleActionGrant, "OPERATOR", &operatorRole, bob}, {Action: RoleActionTransferOwnership, Account: alice}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, owner := newTestKey(t)
+			chain := newTestChain(t, owner)
+			tmAddr, tm := chain.DeployTreasureManager(key, owner)
+			grantAdmin(t, chain, tm, tmAddr, owner)
+			for _, account := range tt.granted {
+				chain.Wait(tm.GrantRole(chain.Transactor(key), operatorRole, account))
+			}
+			// revoked members are dropped when replaying the events
+			chain.Wait(tm.GrantRole(chain.Transactor(key), crypto.Keccak256Hash([]byte("AUDITOR")), alice))
+			chain.Wait(tm.RevokeRole(chain.Transactor(key), crypto.Keccak256Hash([]byte("AUDITOR")), alice))
+
+			ctx := context.Background()
+			manifest := &RolesManifest{Owner: tt.owner, Roles: tt.roles}
+			plan, err := PlanRoles(ctx, chain.client, tmAddr, manifest)
+			require.Nil(t, err)
+			require.Equal(t, owner, plan.Owner)
+			require.Equal(t, tt.changes, plan.Changes)
+			for name := range tt.roles {
+				require.ElementsMatch(t, tt.granted, plan.Members[name])
+			}
+
+			c := chain.NewCaller(key, tmAddr, ContractCallerConfig{})
+			receipts, err := c.ApplyRolesPlan(ctx, plan)
+			require.Nil(t, err)
+			require.Len(t, receipts, len(tt.changes))
+
+			plan, err = PlanRoles(ctx, chain.client, tmAddr, manifest)
+			require.Nil(t, err)
+			require.Empty(t, plan.Changes)
+			if tt.owner != "" {
+				require.Equal(t, common.HexToAddress(tt.owner), plan.Owner)
+			}
+		})
+	}
+}
+
+func TestRoleMembership(t *testing.T) {
+	key, owner := newTestKey(t)
+	chain := newTestChain(t, owner)
+	tmAddr, tm := chain.DeployTreasureManager(key, owner)
+	grantAdmin(t, chain, tm, tmAddr, owner)
+	start := chain.head().header.Number.Uint64()
+
+	opts := chain.Transactor(key)
+	chain.Wait(tm.GrantRole(opts, operatorRole, alice))
+	chain.Wait(tm.GrantRole(opts, operatorRole, bob))
+	chain.Wait(tm.RevokeRole(opts, operatorRole, alice))
+	chain.Wait(tm.GrantRole(opts, operatorRole, alice))
+	chain.Wait(tm.RevokeRole(opts, operatorRole, bob))
+	end := chain.head().header.Number.Uint64()
+
+	members, err := RoleMembership(context.Background(), &tm.TreasureManagerFilterer, start, end)
+	require.Nil(t, err)
+	require.Equal(t, map[common.Address]bool{alice: true}, members[operatorRole])
+
+	// only what happened in range is replayed
+	members, err = RoleMembership(context.Background(), &tm.TreasureManagerFilterer, start, start+2)
+	require.Nil(t, err)
+	require.Equal(t, map[common.Address]bool{alice: true, bob: true}, members[operatorRole])
+	has, err := tm.HasRole(&bind.CallOpts{}, operatorRole, bob)
+	require.Nil(t, err)
+	require.False(t, has)
+}
+
+func TestLoadRolesManifest(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "roles.yaml")
+	require.Nil(t, os.WriteFile(path, []byte(`
+owner: "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"
+fromBlock: 1200000
+roles:
+  DEFAULT_ADMIN_ROLE: ["0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"]
+  OPERATOR: []
+`), 0o600))
+
+	manifest, err := LoadRolesManifest(path)
+	require.Nil(t, err)
+	require.Equal(t, uint64(1200000), manifest.FromBlock)
+	require.Len(t, manifest.Roles["DEFAULT_ADMIN_ROLE"], 1)
+	require.Empty(t, manifest.Roles["OPERATOR"])
+	role, err := common2.ParseRole("DEFAULT_ADMIN_ROLE")
+	require.Nil(t, err)
+	require.Equal(t, [32]byte{}, role)
+
+	require.Nil(t, os.WriteFile(path, []byte("roles: ["), 0o600))
+	_, err = LoadRolesManifest(path)
+	require.ErrorContains(t, err, "parse roles manifest")
+}
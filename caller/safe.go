@@ -0,0 +1,422 @@
+package caller
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	ethc "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+
+	"github.com/the-web3/contracts-caller/bindings"
+	"github.com/the-web3/contracts-caller/txmgr"
+)
+
+// ErrSafeTxQueued is returned by a TreasureManager write in Safe mode
+// that waits for more owner signatures; nothing was sent yet.
+var ErrSafeTxQueued = errors.New("safe transaction queued for owner signatures")
+
+// safeTxTypes is the EIP-712 schema of a SafeTx since Safe v1.3.0.
+var safeTxTypes = apitypes.Types{
+	"EIP712Domain": {
+		{Name: "chainId", Type: "uint256"},
+		{Name: "verifyingContract", Type: "address"},
+	},
+	"SafeTx": {
+		{Name: "to", Type: "address"},
+		{Name: "value", Type: "uint256"},
+		{Name: "data", Type: "bytes"},
+		{Name: "operation", Type: "uint8"},
+		{Name: "safeTxGas", Type: "uint256"},
+		{Name: "baseGas", Type: "uint256"},
+		{Name: "gasPrice", Type: "uint256"},
+		{Name: "gasToken", Type: "address"},
+		{Name: "refundReceiver", Type: "address"},
+		{Name: "nonce", Type: "uint256"},
+	},
+}
+
+// SafeTxTypedData is the EIP-712 message owners sign for tx, e.g. with
+// eth_signTypedData_v4.
+func SafeTxTypedData(tx *SafeTx) apitypes.TypedData {
+	zero := ethc.Address{}.Hex()
+	return apitypes.TypedData{
+		Types:       safeTxTypes,
+		PrimaryType: "SafeTx",
+		Domain: apitypes.TypedDataDomain{
+			ChainId:           (*math.HexOrDecimal256)(tx.ChainID),
+			VerifyingContract: tx.Safe.Hex(),
+		},
+		Message: apitypes.TypedDataMessage{
+			"to":             tx.To.Hex(),
+			"value":          tx.Value.String(),
+			"data":           hexutil.Encode(tx.Data),
+			"operation":      "0",
+			"safeTxGas":      "0",
+			"baseGas":        "0",
+			"gasPrice":       "0",
+			"gasToken":       zero,
+			"refundReceiver": zero,
+			"nonce":          fmt.Sprint(tx.Nonce),
+		},
+	}
+}
+
+// SafeTxHash is the hash of tx the Safe checks owner signatures against.
+func SafeTxHash(tx *SafeTx) (ethc.Hash, error) {
+	hash, _, err := apitypes.TypedDataAndHash(SafeTxTypedData(tx))
+	if err != nil {
+		return ethc.Hash{}, err
+	}
+	return ethc.BytesToHash(hash), nil
+}
+
+// recoverSafeSigner returns the owner that signed safeTxHash and the
+// signature with V being 27 or 28, the form execTransaction takes for a
+// signature of the hash itself.
+func recoverSafeSigner(safeTxHash ethc.Hash, signature []byte) (ethc.Address, []byte, error) {
+	owner, err := recoverApprover(safeTxHash, signature)
+	if err != nil {
+		return ethc.Address{}, nil, err
+	}
+	sig := append([]byte{}, signature...)
+	if sig[64] < 27 {
+		sig[64] += 27
+	}
+	return owner, sig, nil
+}
+
+// ApproveSafeTx records the signature of a queued transaction by one of the
+// current owners of its Safe.
+func ApproveSafeTx(ctx context.Context, safe *bindings.SafeCaller, store SafeTxStore, safeTxHash ethc.Hash, signature []byte) (*SafeTx, error) {
+	tx, err := store.Get(safeTxHash)
+	if err != nil {
+		return nil, err
+	}
+	if tx.Status != SafeTxQueued {
+		return nil, fmt.Errorf("safe transaction %s is %s", safeTxHash, tx.Status)
+	}
+	owner, sig, err := recoverSafeSigner(safeTxHash, signature)
+	if err != nil {
+		return nil, fmt.Errorf("safe transaction %s signature: %w", safeTxHash, err)
+	}
+	owners, err := safe.GetOwners(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return nil, fmt.Errorf("getOwners: %w", err)
+	}
+	if !isApprover(owners, owner) {
+		return nil, fmt.Errorf("safe transaction %s signed by %s, which is not an owner of %s", safeTxHash, owner, tx.Safe)
+	}
+	for _, s := range tx.Signatures {
+		if s.Owner == owner {
+			return nil, fmt.Errorf("safe transaction %s already signed by %s", safeTxHash, owner)
+		}
+	}
+	tx.Signatures = append(tx.Signatures, SafeSignature{Owner: owner, Signature: sig})
+	log.Info("Contract caller safe transaction signed", "safeTxHash", safeTxHash, "owner", owner, "signatures", len(tx.Signatures))
+	return tx, store.Put(tx)
+}
+
+// transactViaSafe queues req as a transaction of the Safe, signs it when
+// the caller wallet is an owner and executes it right away when that meets
+// the threshold. Otherwise it returns ErrSafeTxQueued.
+func (c *ContractCaller) transactViaSafe(ctx context.Context, req *TxRequest) (*types.Receipt, error) {
+	if !req.Force && !c.Cfg.ForceSend {
+		if _, err := c.Cfg.ChainClient.PendingCallContract(ctx, req.callMsg(c.Cfg.SafeAddress)); err != nil {
+			return nil, c.simulationError(req, err)
+		}
+		txmgr.ReportProgress(ctx, txmgr.TxStageSimulated, ethc.Hash{})
+	}
+	tx, err := c.ProposeSafeTx(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	reportSafeTx(ctx, tx)
+	_, missing, err := c.safeSignatures(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	if missing > 0 {
+		return nil, errors.Wrapf(ErrSafeTxQueued, "%s needs %d more", tx.SafeTxHash, missing)
+	}
+	_, receipt, err := c.ExecuteSafeTx(ctx, tx.SafeTxHash)
+	return receipt, err
+}
+
+// resumeSafeTx executes the Safe transaction safeTxHash unless it was
+// already, and returns the receipt of the execTransaction that did. It
+// returns no receipt once the transaction was replaced and can never be
+// executed.
+func (c *ContractCaller) resumeSafeTx(ctx context.Context, safeTxHash ethc.Hash) (*types.Receipt, error) {
+	tx, err := c.Cfg.SafeTxStore.Get(safeTxHash)
+	if err != nil {
+		return nil, err
+	}
+	var receipt *types.Receipt
+	if tx.Status != SafeTxExecuted && tx.Status != SafeTxReplaced {
+		var execErr error
+		tx, receipt, execErr = c.ExecuteSafeTx(ctx, safeTxHash)
+		if tx == nil || (execErr != nil && tx.Status != SafeTxReplaced) {
+			return nil, execErr
+		}
+	}
+	switch {
+	case tx.Status == SafeTxReplaced:
+		return nil, nil
+	case receipt != nil:
+		return receipt, nil
+	}
+	// executed by an earlier run or another owner
+	return c.Cfg.ChainClient.TransactionReceipt(ctx, tx.TxHash)
+}
+
+// ProposeSafeTx queues req under the next Safe nonce no queued transaction
+// holds, with the signature of the caller wallet when it is an owner.
+func (c *ContractCaller) ProposeSafeTx(ctx context.Context, req *TxRequest) (*SafeTx, error) {
+	c.safeMu.Lock()
+	defer c.safeMu.Unlock()
+
+	opts := &bind.CallOpts{Context: ctx}
+	nonce, err := c.nextSafeNonce(opts)
+	if err != nil {
+		return nil, err
+	}
+	head, err := c.Cfg.ChainClient.BlockNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+	value := req.Value
+	if value == nil {
+		value = new(big.Int)
+	}
+	tx := &SafeTx{
+		Safe:       c.Cfg.SafeAddress,
+		ChainID:    c.Cfg.ChainID,
+		To:         req.To,
+		Value:      value,
+		Data:       req.Data,
+		Nonce:      nonce,
+		ProposedBy: c.WalletAddr,
+		Signatures: []SafeSignature{},
+		Status:     SafeTxQueued,
+		StartBlock: head,
+		CreatedAt:  time.Now(),
+	}
+	if len(req.Data) >= 4 {
+		if method, err := c.requestABI(req).MethodById(req.Data[:4]); err == nil {
+			tx.Method = method.RawName
+		}
+	}
+	if tx.SafeTxHash, err = SafeTxHash(tx); err != nil {
+		return nil, err
+	}
+
+	owners, err := c.SafeContract.GetOwners(opts)
+	if err != nil {
+		return nil, fmt.Errorf("getOwners: %w", err)
+	}
+	if isApprover(owners, c.WalletAddr) {
+		sig, err := c.Cfg.Signer.SignTypedData(ctx, SafeTxTypedData(tx))
+		if err != nil {
+			return nil, err
+		}
+		tx.Signatures = append(tx.Signatures, SafeSignature{Owner: c.WalletAddr, Signature: sig})
+	}
+	log.Info("Contract caller safe transaction queued", "safeTxHash", tx.SafeTxHash, "method", tx.Method,
+		"nonce", tx.Nonce, "signatures", len(tx.Signatures))
+	return tx, c.Cfg.SafeTxStore.Put(tx)
+}
+
+// nextSafeNonce is the Safe nonce, or the one after the last transaction
+// queued for it.
+func (c *ContractCaller) nextSafeNonce(opts *bind.CallOpts) (uint64, error) {
+	onChain, err := c.SafeContract.Nonce(opts)
+	if err != nil {
+		return 0, fmt.Errorf("nonce: %w", err)
+	}
+	nonce := onChain.Uint64()
+	txs, err := c.Cfg.SafeTxStore.List()
+	if err != nil {
+		return 0, err
+	}
+	for _, tx := range txs {
+		if tx.Safe != c.Cfg.SafeAddress || tx.Nonce < nonce {
+			continue
+		}
+		if tx.Status == SafeTxQueued || tx.Status == SafeTxSent {
+			nonce = tx.Nonce + 1
+		}
+	}
+	return nonce, nil
+}
+
+// safeSignatures packs the signatures of current owners of tx as
+// execTransaction wants them, sorted by owner, and says how many the
+// threshold still misses.
+func (c *ContractCaller) safeSignatures(ctx context.Context, tx *SafeTx) ([]byte, int, error) {
+	opts := &bind.CallOpts{Context: ctx}
+	owners, err := c.SafeContract.GetOwners(opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("getOwners: %w", err)
+	}
+	threshold, err := c.SafeContract.GetThreshold(opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("getThreshold: %w", err)
+	}
+	var valid []SafeSignature
+	for _, s := range tx.Signatures {
+		owner, sig, err := recoverSafeSigner(tx.SafeTxHash, s.Signature)
+		if err != nil || owner != s.Owner || !isApprover(owners, owner) {
+			log.Warn("Contract caller ignoring safe signature", "safeTxHash", tx.SafeTxHash, "owner", s.Owner, "err", err)
+			continue
+		}
+		valid = append(valid, SafeSignature{Owner: owner, Signature: sig})
+	}
+	need := int(threshold.Int64())
+	if len(valid) < need {
+		return nil, need - len(valid), nil
+	}
+	sort.Slice(valid, func(i, j int) bool {
+		return bytes.Compare(valid[i].Owner.Bytes(), valid[j].Owner.Bytes()) < 0
+	})
+	var packed []byte
+	for _, s := range valid[:need] {
+		packed = append(packed, s.Signature...)
+	}
+	return packed, 0, nil
+}
+
+// ExecuteSafeTx sends execTransaction for a transaction holding enough
+// owner signatures once every lower nonce was used. The Safe nonce keeps a
+// transaction from being executed twice, so a sent or failed one can be
+// executed again; one whose nonce was used is resolved by the
+// ExecutionSuccess event of its hash.
+func (c *ContractCaller) ExecuteSafeTx(ctx context.Context, safeTxHash ethc.Hash) (*SafeTx, *types.Receipt, error) {
+	if c.SafeContract == nil {
+		return nil, nil, errors.New("no safe configured")
+	}
+	store := c.Cfg.SafeTxStore
+	tx, err := store.Get(safeTxHash)
+	if err != nil {
+		return nil, nil, err
+	}
+	if tx.Status == SafeTxExecuted || tx.Status == SafeTxReplaced {
+		return tx, nil, fmt.Errorf("safe transaction %s is %s", safeTxHash, tx.Status)
+	}
+	onChain, err := c.SafeContract.Nonce(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return tx, nil, fmt.Errorf("nonce: %w", err)
+	}
+	switch {
+	case tx.Nonce < onChain.Uint64():
+		return tx, nil, c.resolveUsedSafeNonce(ctx, tx)
+	case tx.Nonce > onChain.Uint64():
+		return tx, nil, fmt.Errorf("safe transaction %s has nonce %d, the safe is at %d", safeTxHash, tx.Nonce, onChain)
+	}
+	signatures, missing, err := c.safeSignatures(ctx, tx)
+	if err != nil {
+		return tx, nil, err
+	}
+	if missing > 0 {
+		return tx, nil, errors.Wrapf(ErrSafeTxQueued, "%s needs %d more", safeTxHash, missing)
+	}
+
+	data, err := c.SafeABI.Pack("execTransaction", tx.To, tx.Value, []byte(tx.Data), uint8(0),
+		new(big.Int), new(big.Int), new(big.Int), ethc.Address{}, ethc.Address{}, signatures)
+	if err != nil {
+		return tx, nil, err
+	}
+	tx.Status = SafeTxSent
+	if err := store.Put(tx); err != nil {
+		return tx, nil, err
+	}
+	receipt, err := c.Transact(ctx, &TxRequest{To: tx.Safe, Data: data, ABI: c.SafeABI})
+	switch {
+	case errors.Is(err, ErrSimulationReverted):
+		tx.Status = SafeTxQueued
+		tx.Error = err.Error()
+		if putErr := store.Put(tx); putErr != nil {
+			return tx, nil, putErr
+		}
+		return tx, nil, err
+	case errors.Is(err, txmgr.ErrTxReverted):
+		tx.Status = SafeTxFailed
+		tx.Error = err.Error()
+		if putErr := store.Put(tx); putErr != nil {
+			return tx, receipt, putErr
+		}
+		return tx, receipt, err
+	case err != nil:
+		tx.Error = err.Error()
+		if putErr := store.Put(tx); putErr != nil {
+			return tx, nil, putErr
+		}
+		return tx, nil, err
+	}
+	tx.TxHash = receipt.TxHash
+	tx.BlockNumber = receipt.BlockNumber.Uint64()
+	if !c.hasSafeExecutionSuccess(receipt, tx) {
+		tx.Status = SafeTxFailed
+		tx.Error = "no ExecutionSuccess event in the receipt"
+		if putErr := store.Put(tx); putErr != nil {
+			return tx, receipt, putErr
+		}
+		return tx, receipt, fmt.Errorf("safe transaction %s: %s", safeTxHash, tx.Error)
+	}
+	tx.Status = SafeTxExecuted
+	tx.Error = ""
+	log.Info("Contract caller safe transaction executed", "safeTxHash", safeTxHash, "method", tx.Method, "TxHash", tx.TxHash)
+	return tx, receipt, store.Put(tx)
+}
+
+func (c *ContractCaller) hasSafeExecutionSuccess(receipt *types.Receipt, tx *SafeTx) bool {
+	for _, l := range receipt.Logs {
+		if l.Address != tx.Safe {
+			continue
+		}
+		event, err := c.SafeContract.ParseExecutionSuccess(*l)
+		if err == nil && ethc.Hash(event.TxHash) == tx.SafeTxHash {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveUsedSafeNonce settles a transaction whose nonce the Safe already
+// used: executed when its ExecutionSuccess event is there, replaced
+// otherwise.
+func (c *ContractCaller) resolveUsedSafeNonce(ctx context.Context, tx *SafeTx) error {
+	it, err := c.SafeContract.FilterExecutionSuccess(
+		&bind.FilterOpts{Start: tx.StartBlock, Context: ctx}, [][32]byte{tx.SafeTxHash})
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+	tx.Status = SafeTxReplaced
+	if it.Next() {
+		tx.Status = SafeTxExecuted
+		tx.TxHash = it.Event.Raw.TxHash
+		tx.BlockNumber = it.Event.Raw.BlockNumber
+		tx.Error = ""
+	} else if err := it.Error(); err != nil {
+		return err
+	}
+	log.Info("Contract caller safe nonce already used", "safeTxHash", tx.SafeTxHash, "nonce", tx.Nonce, "status", tx.Status)
+	if err := c.Cfg.SafeTxStore.Put(tx); err != nil {
+		return err
+	}
+	if tx.Status == SafeTxReplaced {
+		return fmt.Errorf("safe transaction %s was replaced, nonce %d is used", tx.SafeTxHash, tx.Nonce)
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+package caller
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	ethc "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/ethdb/leveldb"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+)
+
+type SafeTxStatus string
+
+const (
+	// SafeTxQueued collects owner signatures until it is executed.
+	SafeTxQueued SafeTxStatus = "queued"
+	// SafeTxSent is recorded before execTransaction is sent.
+	SafeTxSent     SafeTxStatus = "sent"
+	SafeTxExecuted SafeTxStatus = "executed"
+	SafeTxFailed   SafeTxStatus = "failed"
+	// SafeTxReplaced is a transaction whose nonce another Safe
+	// transaction used.
+	SafeTxReplaced SafeTxStatus = "replaced"
+)
+
+var ErrSafeTxNotFound = errors.New("caller: safe transaction not found")
+
+// SafeSignature is the signature of a SafeTxHash by a Safe owner.
+type SafeSignature struct {
+	Owner     ethc.Address  `json:"owner"`
+	Signature hexutil.Bytes `json:"signature"`
+}
+
+// SafeTx is a call the Safe makes once enough of its owners signed its
+// SafeTxHash. Gas refunds are not used, so the gas fields of the SafeTx
+// struct are all zero.
+type SafeTx struct {
+	SafeTxHash ethc.Hash       `json:"safeTxHash"`
+	Safe       ethc.Address    `json:"safe"`
+	ChainID    *big.Int        `json:"chainId"`
+	To         ethc.Address    `json:"to"`
+	Value      *big.Int        `json:"value"`
+	Data       hexutil.Bytes   `json:"data"`
+	Nonce      uint64          `json:"nonce"`
+	Method     string          `json:"method,omitempty"`
+	ProposedBy ethc.Address    `json:"proposedBy"`
+	Signatures []SafeSignature `json:"signatures"`
+	Status     SafeTxStatus    `json:"status"`
+	// StartBlock is the head when it was queued, where the search for its
+	// ExecutionSuccess event starts.
+	StartBlock uint64    `json:"startBlock"`
+	CreatedAt  time.Time `json:"createdAt"`
+	// TxHash is the execTransaction that executed it.
+	TxHash      ethc.Hash `json:"txHash,omitempty"`
+	BlockNumber uint64    `json:"blockNumber,omitempty"`
+	Error       string    `json:"error,omitempty"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// SafeTxStore durably records Safe transactions and their signatures
+// until they are executed.
+type SafeTxStore interface {
+	Get(safeTxHash ethc.Hash) (*SafeTx, error)
+	Put(tx *SafeTx) error
+	// List returns every transaction, by nonce.
+	List() ([]*SafeTx, error)
+	Close() error
+}
+
+var safeTxPrefix = []byte("safe-")
+
+// KVSafeTxStore is a SafeTxStore backed by any go-ethereum key-value store.
+type KVSafeTxStore struct {
+	db ethdb.KeyValueStore
+	mu sync.Mutex
+}
+
+func NewKVSafeTxStore(db ethdb.KeyValueStore) *KVSafeTxStore {
+	return &KVSafeTxStore{db: db}
+}
+
+// NewLevelDBSafeTxStore opens (or creates) an on-disk store at path.
+func NewLevelDBSafeTxStore(path string) (*KVSafeTxStore, error) {
+	db, err := leveldb.New(path, 16, 16, "", false)
+	if err != nil {
+		return nil, err
+	}
+	return NewKVSafeTxStore(db), nil
+}
+
+// NewMemorySafeTxStore returns a store that does not survive restarts,
+// enough for a Safe whose threshold the caller wallet meets alone.
+func NewMemorySafeTxStore() *KVSafeTxStore {
+	return NewKVSafeTxStore(memorydb.New())
+}
+
+func safeTxKey(safeTxHash ethc.Hash) []byte {
+	return append(append([]byte{}, safeTxPrefix...), safeTxHash.Bytes()...)
+}
+
+func (s *KVSafeTxStore) Get(safeTxHash ethc.Hash) (*SafeTx, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ok, err := s.db.Has(safeTxKey(safeTxHash))
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrSafeTxNotFound
+	}
+	value, err := s.db.Get(safeTxKey(safeTxHash))
+	if err != nil {
+		return nil, err
+	}
+	var tx SafeTx
+	if err := json.Unmarshal(value, &tx); err != nil {
+		return nil, err
+	}
+	return &tx, nil
+}
+
+func (s *KVSafeTxStore) Put(tx *SafeTx) error {
+	tx.UpdatedAt = time.Now()
+	value, err := json.Marshal(tx)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.db.Put(safeTxKey(tx.SafeTxHash), value)
+}
+
+func (s *KVSafeTxStore) List() ([]*SafeTx, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	it := s.db.NewIterator(safeTxPrefix, nil)
+	defer it.Release()
+
+	var txs []*SafeTx
+	for it.Next() {
+		var tx SafeTx
+		if err := json.Unmarshal(it.Value(), &tx); err != nil {
+			return nil, err
+		}
+		txs = append(txs, &tx)
+	}
+	if err := it.Error(); err != nil {
+		return nil, err
+	}
+	sort.SliceStable(txs, func(i, j int) bool {
+		return txs[i].Nonce < txs[j].Nonce
+	})
+	return txs, nil
+}
+
+func (s *KVSafeTxStore) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,173 @@
+package caller
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/the-web3/contracts-caller/bindings"
+	"github.com/the-web3/contracts-caller/signer"
+)
+
+// safeCaller returns a caller whose wallet is one of the two owners of the
+// Safe owning the TreasureManager, and the key of the other owner.
+func safeCaller(t *testing.T, threshold int64, amounts ...int64) (*bindings.Safe, *bindings.TreasureManager, *ContractCaller, WithdrawalStore, *ecdsa.PrivateKey) {
+	key, owner := newTestKey(t)
+	otherKey, other := newTestKey(t)
+	chain := newTestChain(t, owner, other)
+	safeAddr, safe := chain.DeploySafe(key, []common.Address{owner, other}, threshold)
+	tmAddr, tm := chain.DeployTreasureManager(key, safeAddr)
+	c := chain.NewCaller(key, tmAddr, ContractCallerConfig{SafeAddress: safeAddr})
+	eth, err := tm.EthAddress(nil)
+	require.Nil(t, err)
+
+	_, err = c.SetTokenWhiteList(context.Background(), eth)
+	if threshold > 1 {
+		require.ErrorIs(t, err, ErrSafeTxQueued)
+		var safeTxs []*SafeTx
+		safeTxs, err = c.Cfg.SafeTxStore.List()
+		require.Nil(t, err)
+		signSafeTx(t, c, otherKey, safeTxs[0].SafeTxHash)
+		_, _, err = c.ExecuteSafeTx(context.Background(), safeTxs[0].SafeTxHash)
+	}
+	require.Nil(t, err)
+
+	store := NewMemoryWithdrawalStore()
+	for i, amount := range amounts {
+		require.Nil(t, store.Put(&Withdrawal{
+			ID:          string(rune('a' + i)),
+			Token:       eth,
+			To:          alice,
+			Amount:      ether(amount),
+			RequestedBy: "ops",
+			ApprovedBy:  "treasurer",
+			Status:      WithdrawalApproved,
+		}))
+	}
+	return safe, tm, c, store, otherKey
+}
+
+func signSafeTx(t *testing.T, c *ContractCaller, key *ecdsa.PrivateKey, safeTxHash common.Hash) {
+	tx, err := c.Cfg.SafeTxStore.Get(safeTxHash)
+	require.Nil(t, err)
+	signature, err := signer.NewPrivateKeySigner(key).SignTypedData(context.Background(), SafeTxTypedData(tx))
+	require.Nil(t, err)
+	_, err = ApproveSafeTx(context.Background(), &c.SafeContract.SafeCaller, c.Cfg.SafeTxStore, safeTxHash, signature)
+	require.Nil(t, err)
+}
+
+func TestSafeWithdrawal(t *testing.T) {
+	policy := testPolicy(t)
+	ctx := context.Background()
+
+	t.Run("executed at once", func(t *testing.T) {
+		_, tm, c, withdrawals, _ := safeCaller(t, 1, 4)
+		w, err := c.ExecuteWithdrawal(ctx, policy, withdrawals, "a")
+		require.Nil(t, err)
+		require.Equal(t, WithdrawalExecuted, w.Status)
+		require.Equal(t, 1, withdrawCount(t, tm))
+
+		// a pending record without its sent transaction is found by the
+		// event the Safe emitted
+		txHash := w.TxHash
+		w.Status, w.Sent, w.TxHash = WithdrawalPending, nil, common.Hash{}
+		require.Nil(t, withdrawals.Put(w))
+		w, err = c.ExecuteWithdrawal(ctx, policy, withdrawals, "a")
+		require.Nil(t, err)
+		require.Equal(t, WithdrawalExecuted, w.Status)
+		require.Equal(t, txHash, w.TxHash)
+		require.Equal(t, 1, withdrawCount(t, tm))
+	})
+
+	t.Run("queued for signatures", func(t *testing.T) {
+		_, tm, c, withdrawals, otherKey := safeCaller(t, 2, 4)
+		w, err := c.ExecuteWithdrawal(ctx, policy, withdrawals, "a")
+		require.ErrorIs(t, err, ErrSafeTxQueued)
+		require.Equal(t, WithdrawalPending, w.Status)
+		require.NotNil(t, w.Sent)
+		safeTxHash := w.Sent.SafeTxHash
+		require.NotZero(t, safeTxHash)
+		queued, err := c.Cfg.SafeTxStore.List()
+		require.Nil(t, err)
+
+		// a retry waits for the same transaction instead of queuing another
+		w, err = c.ExecuteWithdrawal(ctx, policy, withdrawals, "a")
+		require.ErrorIs(t, err, ErrSafeTxQueued)
+		require.Equal(t, safeTxHash, w.Sent.SafeTxHash)
+		safeTxs, err := c.Cfg.SafeTxStore.List()
+		require.Nil(t, err)
+		require.Len(t, safeTxs, len(queued))
+
+		signSafeTx(t, c, otherKey, safeTxHash)
+		w, err = c.ExecuteWithdrawal(ctx, policy, withdrawals, "a")
+		require.Nil(t, err)
+		require.Equal(t, WithdrawalExecuted, w.Status)
+		require.Equal(t, 1, withdrawCount(t, tm))
+		tx, err := c.Cfg.SafeTxStore.Get(safeTxHash)
+		require.Nil(t, err)
+		require.Equal(t, SafeTxExecuted, tx.Status)
+		require.Equal(t, w.TxHash, tx.TxHash)
+
+		_, err = c.ExecuteWithdrawal(ctx, policy, withdrawals, "a")
+		require.ErrorContains(t, err, "is already executed")
+		require.Equal(t, 1, withdrawCount(t, tm))
+	})
+}
+
+func TestResolveUsedSafeNonce(t *testing.T) {
+	tests := []struct {
+		name string
+		// data replaces the data of the executed transaction
+		data   []byte
+		status SafeTxStatus
+		err    string
+	}{
+		{
+			name:   "executed",
+			status: SafeTxExecuted,
+		},
+		{
+			name:   "replaced",
+			data:   []byte{1},
+			status: SafeTxReplaced,
+			err:    "was replaced",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, c, withdrawals, _ := safeCaller(t, 1, 4)
+			ctx := context.Background()
+			w, err := c.ExecuteWithdrawal(ctx, testPolicy(t), withdrawals, "a")
+			require.Nil(t, err)
+
+			// a record of the used nonce that missed its execution
+			tx, err := c.Cfg.SafeTxStore.Get(w.Sent.SafeTxHash)
+			require.Nil(t, err)
+			tx.Status = SafeTxQueued
+			tx.TxHash = common.Hash{}
+			if tt.data != nil {
+				tx.Data = tt.data
+				tx.SafeTxHash, err = SafeTxHash(tx)
+				require.Nil(t, err)
+			}
+			require.Nil(t, c.Cfg.SafeTxStore.Put(tx))
+
+			_, _, err = c.ExecuteSafeTx(ctx, tx.SafeTxHash)
+			if tt.err != "" {
+				require.ErrorContains(t, err, tt.err)
+			} else {
+				require.Nil(t, err)
+			}
+			tx, err = c.Cfg.SafeTxStore.Get(tx.SafeTxHash)
+			require.Nil(t, err)
+			require.Equal(t, tt.status, tx.Status)
+			if tt.status == SafeTxExecuted {
+				require.Equal(t, w.TxHash, tx.TxHash)
+			}
+		})
+	}
+}
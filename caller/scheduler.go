@@ -0,0 +1,194 @@
+package caller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/log"
+
+	common2 "github.com/the-web3/contracts-caller/common"
+	"github.com/the-web3/contracts-caller/txmgr"
+)
+
+// JobStatus is the running tally of a job, as reported by JobStatuses.
+type JobStatus struct {
+	Name     string `json:"name"`
+	Mode     string `json:"mode"`
+	Runs     uint64 `json:"runs"`
+	Skipped  uint64 `json:"skipped"`
+	Failures uint64 `json:"failures"`
+
+	LastRun     time.Time `json:"lastRun"`
+	LastSuccess time.Time `json:"lastSuccess"`
+	NextRun     time.Time `json:"nextRun"`
+	LastError   string    `json:"lastError,omitempty"`
+	LastTxHash  string    `json:"lastTxHash,omitempty"`
+	LastResult  string    `json:"lastResult,omitempty"`
+}
+
+// JobStatuses returns a snapshot of every job, sorted by name.
+func (c *ContractCaller) JobStatuses() []JobStatus {
+	c.jobMu.Lock()
+	defer c.jobMu.Unlock()
+	statuses := make([]JobStatus, 0, len(c.jobStatus))
+	for _, status := range c.jobStatus {
+		statuses = append(statuses, *status)
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Name < statuses[j].Name })
+	return statuses
+}
+
+func (c *ContractCaller) updateJobStatus(name string, update func(*JobStatus)) {
+	c.jobMu.Lock()
+	defer c.jobMu.Unlock()
+	update(c.jobStatus[name])
+}
+
+// runJob runs j on its schedule until the caller shuts down. A job never
+// overlaps with itself; a run that outlasts the schedule delays the next.
+func (c *ContractCaller) runJob(j *job) {
+	defer c.wg.Done()
+	for {
+		next := j.schedule.Next(time.Now())
+		c.updateJobStatus(j.spec.Name, func(s *JobStatus) { s.NextRun = next })
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-timer.C:
+		case <-c.Ctx.Done():
+			timer.Stop()
+			return
+		}
+		// a timer already due may win the select over the shutdown
+		if c.Ctx.Err() != nil {
+			return
+		}
+
+		c.runJobOnce(j)
+	}
+}
+
+// runJobOnce runs j now, recording and logging the outcome.
+func (c *ContractCaller) runJobOnce(j *job) {
+	skipped, result, err := c.execJob(c.Ctx, j)
+	now := time.Now()
+	c.updateJobStatus(j.spec.Name, func(s *JobStatus) {
+		s.LastRun = now
+		switch {
+		case err != nil:
+			s.Runs++
+			s.Failures++
+			s.LastError = err.Error()
+		case skipped:
+			s.Skipped++
+		default:
+			s.Runs++
+			s.LastSuccess = now
+			s.LastError = ""
+			if j.spec.Mode == JobModeSend {
+				s.LastTxHash = result
+			} else {
+				s.LastResult = result
+			}
+		}
+	})
+	switch {
+	case err != nil:
+		log.Error("Contract caller job fail", "job", j.spec.Name, "method", j.label(), "err", err)
+	case skipped:
+		log.Debug("Contract caller job skipped by precondition", "job", j.spec.Name)
+	case j.spec.Mode == JobModeSend:
+		log.Info("Contract caller job sent", "job", j.spec.Name, "method", j.label(), "TxHash", result)
+	default:
+		log.Info("Contract caller job result", "job", j.spec.Name, "method", j.label(), "result", result)
+	}
+}
+
+// execJob checks the precondition and runs j once. result is the tx hash
+// of a send, or the JSON encoded outputs of a call.
+func (c *ContractCaller) execJob(ctx context.Context, j *job) (skipped bool, result string, err error) {
+	if j.exec != nil {
+		result, err := j.exec(ctx)
+		return false, result, err
+	}
+	if j.pre != nil {
+		values, err := c.callView(ctx, j, j.preMethod, j.preData)
+		if err != nil {
+			return false, "", fmt.Errorf("precondition: %w", err)
+		}
+		ok, err := compareABIValues(j.pre.Op, j.preMethod.Outputs[0].Type, values[0], j.preValue)
+		if err != nil || !ok {
+			return true, "", err
+		}
+	}
+
+	if j.spec.Mode == JobModeCall {
+		values, err := c.callView(ctx, j, j.method, j.data)
+		if err != nil {
+			return false, "", err
+		}
+		outputs := make(map[string]interface{}, len(values))
+		for i, out := range j.method.Outputs {
+			name := out.Name
+			if name == "" {
+				name = fmt.Sprint(i)
+			}
+			outputs[name] = common2.ABIValueToJSON(out.Type, values[i])
+		}
+		encoded, err := json.Marshal(outputs)
+		return false, string(encoded), err
+	}
+
+	receipt, err := c.Transact(ctx, &TxRequest{To: j.to, Data: j.data, Value: j.value, ABI: j.abi})
+	if err != nil {
+		return false, "", err
+	}
+	return false, receipt.TxHash.Hex(), nil
+}
+
+func (c *ContractCaller) callView(ctx context.Context, j *job, method *abi.Method, data []byte) ([]interface{}, error) {
+	to := j.to
+	output, err := c.Cfg.ChainClient.CallContract(ctx, ethereum.CallMsg{From: c.WalletAddr, To: &to, Data: data}, nil)
+	if err != nil {
+		if reason, decodeErr := common2.DecodeRevert(txmgr.RevertData(err), j.abi); decodeErr == nil {
+			return nil, fmt.Errorf("%s reverted: %s", method.Sig, reason)
+		}
+		return nil, err
+	}
+	return method.Outputs.Unpack(output)
+}
+
+func compareABIValues(op string, t abi.Type, actual, expected interface{}) (bool, error) {
+	a := fmt.Sprint(common2.ABIValueToJSON(t, actual))
+	b := fmt.Sprint(common2.ABIValueToJSON(t, expected))
+	switch op {
+	case "eq":
+		return a == b, nil
+	case "ne":
+		return a != b, nil
+	}
+
+	x, okX := new(big.Int).SetString(a, 10)
+	y, okY := new(big.Int).SetString(b, 10)
+	if !okX || !okY {
+		return false, fmt.Errorf("precondition op %s on non-integers %s and %s", op, a, b)
+	}
+	cmp := x.Cmp(y)
+	switch op {
+	case "lt":
+		return cmp < 0, nil
+	case "le":
+		return cmp <= 0, nil
+	case "gt":
+		return cmp > 0, nil
+	case "ge":
+		return cmp >= 0, nil
+	default:
+		return false, fmt.Errorf("unknown precondition op %s", op)
+	}
+}
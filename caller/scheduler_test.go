@@ -0,0 +1,227 @@
+package caller
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestCompareABIValues(t *testing.T) {
+	uint256, _ := abi.NewType("uint256", "", nil)
+	int64Type, _ := abi.NewType("int64", "", nil)
+	address, _ := abi.NewType("address", "", nil)
+	alice := common.HexToAddress("0x70997970C51812dc3A010C7d01b54e8b0b6FA4d6")
+	bob := common.HexToAddress("0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC")
+
+	tests := []struct {
+		op       string
+		typ      abi.Type
+		actual   interface{}
+		expected interface{}
+		want     bool
+		err      string
+	}{
+		{op: "eq", typ: address, actual: alice, expected: alice, want: true},
+		{op: "eq", typ: address, actual: alice, expected: bob, want: false},
+		{op: "ne", typ: address, actual: alice, expected: bob, want: true},
+		{op: "eq", typ: uint256, actual: big.NewInt(7), expected: big.NewInt(7), want: true},
+		{op: "lt", typ: uint256, actual: big.NewInt(6), expected: big.NewInt(7), want: true},
+		{op: "lt", typ: uint256, actual: big.NewInt(7), expected: big.NewInt(7), want: false},
+		{op: "le", typ: uint256, actual: big.NewInt(7), expected: big.NewInt(7), want: true},
+		{op: "gt", typ: uint256, actual: big.NewInt(8), expected: big.NewInt(7), want: true},
+		{op: "ge", typ: uint256, actual: big.NewInt(6), expected: big.NewInt(7), want: false},
+		// compared as numbers, not strings
+		{op: "gt", typ: uint256, actual: big.NewInt(10), expected: big.NewInt(9), want: true},
+		{op: "lt", typ: int64Type, actual: int64(-2), expected: int64(1), want: true},
+		{op: "lt", typ: address, actual: alice, expected: bob, err: "on non-integers"},
+		{op: "is", typ: uint256, actual: big.NewInt(1), expected: big.NewInt(1), err: "unknown precondition op is"},
+	}
+	for _, tt := range tests {
+		got, err := compareABIValues(tt.op, tt.typ, tt.actual, tt.expected)
+		if tt.err != "" {
+			require.ErrorContains(t, err, tt.err)
+			continue
+		}
+		require.Nil(t, err)
+		require.Equal(t, tt.want, got, "%v %s %v", tt.actual, tt.op, tt.expected)
+	}
+}
+
+func TestExecJobPrecondition(t *testing.T) {
+	key, owner := newTestKey(t)
+	chain := newTestChain(t, owner)
+	tmAddr, tm := chain.DeployTreasureManager(key, owner)
+	c := chain.NewCaller(key, tmAddr, ContractCallerConfig{})
+	ctx := context.Background()
+
+	sync, err := c.compileJob(JobSpec{
+		Name: "sync", Method: "setWithdrawManager", Args: []string{jobsManager}, Interval: time.Minute,
+		Precondition: &PreconditionSpec{Method: "withdrawManager", Op: "ne", Value: jobsManager},
+	})
+	require.Nil(t, err)
+	skipped, result, err := c.execJob(ctx, sync)
+	require.Nil(t, err)
+	require.False(t, skipped)
+	require.Len(t, result, 66)
+	manager, err := tm.WithdrawManager(&bind.CallOpts{})
+	require.Nil(t, err)
+	require.Equal(t, common.HexToAddress(jobsManager), manager)
+
+	skipped, _, err = c.execJob(ctx, sync)
+	require.Nil(t, err)
+	require.True(t, skipped)
+
+	read, err := c.compileJob(JobSpec{Name: "read", Mode: JobModeCall, Method: "withdrawManager", Interval: time.Minute})
+	require.Nil(t, err)
+	skipped, result, err = c.execJob(ctx, read)
+	require.Nil(t, err)
+	require.False(t, skipped)
+	require.Equal(t, `{"0":"`+common.HexToAddress(jobsManager).Hex()+`"}`, result)
+
+	eth, err := tm.EthAddress(&bind.CallOpts{})
+	require.Nil(t, err)
+	for op, want := range map[string]bool{"ge": false, "lt": true} {
+		topUp, err := c.compileJob(JobSpec{
+			Name: "top-up", Method: "depositETH", Value: "1", Interval: time.Minute,
+			// 10 ETH are deposited
+			Precondition: &PreconditionSpec{Method: "tokenBalances", Args: []string{eth.Hex()}, Op: op, Value: "20000000000000000000"},
+		})
+		require.Nil(t, err)
+		skipped, _, err := c.execJob(ctx, topUp)
+		require.Nil(t, err)
+		require.Equal(t, !want, skipped, "tokenBalances %s 20 ETH", op)
+	}
+
+	outOfRange, err := c.compileJob(JobSpec{
+		Name: "out-of-range", Mode: JobModeCall, Method: "withdrawManager", Interval: time.Minute,
+		Precondition: &PreconditionSpec{Method: "tokenWhiteList", Args: []string{"99"}, Op: "ne", Value: jobsManager},
+	})
+	require.Nil(t, err)
+	_, _, err = c.execJob(ctx, outOfRange)
+	require.ErrorContains(t, err, "precondition:")
+	require.ErrorContains(t, err, "reverted")
+}
+
+// schedulerCaller runs jobs until the test ends.
+func schedulerCaller(t *testing.T) *ContractCaller {
+	ctx, cancel := context.WithCancel(context.Background())
+	c := &ContractCaller{Ctx: ctx, cancel: cancel, jobStatus: make(map[string]*JobStatus)}
+	t.Cleanup(c.Stop)
+	return c
+}
+
+func startJob(c *ContractCaller, j *job) {
+	c.jobStatus[j.spec.Name] = &JobStatus{Name: j.spec.Name, Mode: j.spec.Mode}
+	c.wg.Add(1)
+	go c.runJob(j)
+}
+
+func TestRunJobNeverOverlaps(t *testing.T) {
+	c := schedulerCaller(t)
+
+	var running, overlaps, runs atomic.Int32
+	startJob(c, builtinJob("slow", 10*time.Millisecond, func(ctx context.Context) (string, error) {
+		if running.Add(1) > 1 {
+			overlaps.Add(1)
+		}
+		defer running.Add(-1)
+		runs.Add(1)
+		time.Sleep(50 * time.Millisecond)
+		return "done", nil
+	}))
+
+	time.Sleep(300 * time.Millisecond)
+	c.Stop()
+	require.Zero(t, overlaps.Load())
+	// every run is 50ms plus the 10ms delay
+	require.LessOrEqual(t, runs.Load(), int32(5))
+	require.GreaterOrEqual(t, runs.Load(), int32(4))
+
+	status := c.JobStatuses()[0]
+	require.Equal(t, uint64(runs.Load()), status.Runs)
+	require.Equal(t, JobModeReconcile, status.Mode)
+	require.Equal(t, "done", status.LastResult)
+	require.True(t, status.NextRun.After(status.LastRun))
+}
+
+func TestRunJobRecordsOutcomes(t *testing.T) {
+	c := schedulerCaller(t)
+
+	var calls atomic.Int32
+	failing := builtinJob("flaky", 10*time.Millisecond, func(ctx context.Context) (string, error) {
+		if calls.Add(1)%2 == 1 {
+			return "", errors.New("node unavailable")
+		}
+		return ReconcileInSync, nil
+	})
+	c.jobStatus["flaky"] = &JobStatus{Name: "flaky"}
+
+	c.runJobOnce(failing)
+	status := c.JobStatuses()[0]
+	require.Equal(t, uint64(1), status.Runs)
+	require.Equal(t, uint64(1), status.Failures)
+	require.Equal(t, "node unavailable", status.LastError)
+	require.True(t, status.LastSuccess.IsZero())
+
+	c.runJobOnce(failing)
+	status = c.JobStatuses()[0]
+	require.Equal(t, uint64(2), status.Runs)
+	require.Equal(t, uint64(1), status.Failures)
+	require.Empty(t, status.LastError)
+	require.Equal(t, ReconcileInSync, status.LastResult)
+	require.Equal(t, status.LastRun, status.LastSuccess)
+}
+
+func TestRunJobStopsWithCaller(t *testing.T) {
+	c := schedulerCaller(t)
+
+	var runs atomic.Int32
+	startJob(c, builtinJob("hourly", time.Hour, func(ctx context.Context) (string, error) {
+		runs.Add(1)
+		return "", nil
+	}))
+
+	stopped := make(chan struct{})
+	go func() {
+		c.Stop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("job still running after Stop")
+	}
+	require.Zero(t, runs.Load())
+	require.WithinDuration(t, time.Now().Add(time.Hour), c.JobStatuses()[0].NextRun, time.Minute)
+}
+
+func TestStopCancelsCallerContext(t *testing.T) {
+	key, owner := newTestKey(t)
+	chain := newTestChain(t, owner)
+	tmAddr, _ := chain.DeployTreasureManager(key, owner)
+	c := chain.NewCaller(key, tmAddr, ContractCallerConfig{})
+	startJob(c, builtinJob("hourly", time.Hour, func(ctx context.Context) (string, error) {
+		return "", nil
+	}))
+
+	stopped := make(chan struct{})
+	go func() {
+		c.Stop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("job still running after Stop")
+	}
+	require.ErrorIs(t, c.Ctx.Err(), context.Canceled)
+}
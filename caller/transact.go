@@ -0,0 +1,366 @@
+package caller
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/pkg/errors"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	ethc "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	common2 "github.com/the-web3/contracts-caller/common"
+	"github.com/the-web3/contracts-caller/txmgr"
+)
+
+// ErrSimulationReverted matches every SimulationError with errors.Is.
+var ErrSimulationReverted = errors.New("pre-flight simulation reverted")
+
+// SimulationError is returned when a call would revert against the pending
+// block; nothing has been signed or broadcast.
+type SimulationError struct {
+	Data   []byte
+	Reason string
+	Err    error
+}
+
+func (e *SimulationError) Error() string {
+	if e.Reason != "" {
+		return fmt.Sprintf("%s: %s", ErrSimulationReverted, e.Reason)
+	}
+	return fmt.Sprintf("%s: %v", ErrSimulationReverted, e.Err)
+}
+
+func (e *SimulationError) Is(target error) bool {
+	return target == ErrSimulationReverted
+}
+
+func (e *SimulationError) Unwrap() error {
+	return e.Err
+}
+
+// ErrTxInFlight is returned for a record whose transaction may still be
+// mined, so it is neither confirmed nor sent again yet.
+var ErrTxInFlight = errors.New("transaction still in flight")
+
+// SentTx is what a record keeps of the transaction sending it: its nonce and
+// the hash of every signed attempt, written before each is broadcast. In
+// Safe mode it also keeps the Safe transaction making the call, written once
+// queued.
+type SentTx struct {
+	Nonce      uint64      `json:"nonce"`
+	TxHashes   []ethc.Hash `json:"txHashes"`
+	SafeTxHash ethc.Hash   `json:"safeTxHash,omitempty"`
+	SafeNonce  uint64      `json:"safeNonce,omitempty"`
+}
+
+// known is whether anything was recorded as sent.
+func (s *SentTx) known() bool {
+	return s != nil && (len(s.TxHashes) > 0 || s.SafeTxHash != ethc.Hash{})
+}
+
+type (
+	signedTxKey   struct{}
+	queuedSafeKey struct{}
+)
+
+// withSignedTx makes Transact tell fn every attempt it signs under ctx,
+// before the attempt is broadcast.
+func withSignedTx(ctx context.Context, fn func(tx *types.Transaction)) context.Context {
+	return context.WithValue(ctx, signedTxKey{}, fn)
+}
+
+func reportSigned(ctx context.Context, tx *types.Transaction) {
+	if fn, ok := ctx.Value(signedTxKey{}).(func(tx *types.Transaction)); ok {
+		fn(tx)
+	}
+}
+
+// withSafeTx makes Transact in Safe mode tell fn the Safe transaction it
+// queued under ctx.
+func withSafeTx(ctx context.Context, fn func(tx *SafeTx)) context.Context {
+	return context.WithValue(ctx, queuedSafeKey{}, fn)
+}
+
+func reportSafeTx(ctx context.Context, tx *SafeTx) {
+	if fn, ok := ctx.Value(queuedSafeKey{}).(func(tx *SafeTx)); ok {
+		fn(tx)
+	}
+}
+
+// recordSent returns a context recording every attempt signed under it in
+// sent, as well as the Safe transaction queued, and saving it with save.
+func recordSent(ctx context.Context, sent *SentTx, save func() error) context.Context {
+	ctx = withSignedTx(ctx, func(tx *types.Transaction) {
+		sent.Nonce = tx.Nonce()
+		sent.TxHashes = append(sent.TxHashes, tx.Hash())
+		if err := save(); err != nil {
+			log.Error("Contract caller unable to record signed transaction", "TxHash", tx.Hash(), "err", err)
+		}
+	})
+	return withSafeTx(ctx, func(tx *SafeTx) {
+		sent.SafeTxHash = tx.SafeTxHash
+		sent.SafeNonce = tx.Nonce
+		if err := save(); err != nil {
+			log.Error("Contract caller unable to record safe transaction", "safeTxHash", tx.SafeTxHash, "err", err)
+		}
+	})
+}
+
+// resolveSentTx finds out what became of a call recorded as sent: the
+// receipt of the transaction that made it, ErrTxInFlight while one may
+// still be mined, or neither once the call can be sent again. A Safe
+// transaction not executed yet is executed once it holds enough signatures,
+// recording the attempts in sent and saving them with save, and fails with
+// ErrSafeTxQueued otherwise.
+func (c *ContractCaller) resolveSentTx(ctx context.Context, sent *SentTx, save func() error) (*types.Receipt, error) {
+	if len(sent.TxHashes) > 0 {
+		receipt, pending, err := c.checkSentTx(ctx, sent)
+		switch {
+		case err != nil:
+			return nil, err
+		case receipt != nil:
+			return receipt, nil
+		case pending:
+			return nil, errors.Wrapf(ErrTxInFlight, "nonce %d", sent.Nonce)
+		}
+	}
+	if sent.SafeTxHash == (ethc.Hash{}) {
+		return nil, nil
+	}
+	if c.SafeContract == nil {
+		return nil, fmt.Errorf("queued as safe transaction %s, but no safe is configured", sent.SafeTxHash)
+	}
+	return c.resumeSafeTx(recordSent(ctx, sent, save), sent.SafeTxHash)
+}
+
+// checkSentTx finds out what became of sent: the receipt of the attempt that
+// was mined, or whether one may still be. The nonce is read first, so an
+// attempt mined meanwhile is seen by the receipt lookups after it. An
+// attempt is still pending while the journal or the node has it; otherwise
+// it was dropped and its nonce is released, so the transaction sent again
+// takes it and the dropped one can never be mined.
+func (c *ContractCaller) checkSentTx(ctx context.Context, sent *SentTx) (receipt *types.Receipt, pending bool, err error) {
+	latest, err := c.Cfg.ChainClient.NonceAt(ctx, c.WalletAddr, nil)
+	if err != nil {
+		return nil, false, err
+	}
+	for _, txHash := range sent.TxHashes {
+		receipt, err := c.Cfg.ChainClient.TransactionReceipt(ctx, txHash)
+		if err == nil {
+			return receipt, false, nil
+		}
+		if !errors.Is(err, ethereum.NotFound) {
+			return nil, false, err
+		}
+	}
+	if latest > sent.Nonce {
+		// the nonce went to a transaction of something else
+		return nil, false, nil
+	}
+
+	if c.Cfg.Journal != nil {
+		entry, err := c.Cfg.Journal.Entry(sent.Nonce)
+		if err != nil && !errors.Is(err, txmgr.ErrJournalEntryNotFound) {
+			return nil, false, err
+		}
+		if entry != nil && entry.Status == txmgr.TxStatusPending {
+			for _, attempt := range entry.Attempts {
+				if containsHash(sent.TxHashes, attempt.TxHash) {
+					return nil, true, nil
+				}
+			}
+		}
+	}
+	for _, txHash := range sent.TxHashes {
+		_, isPending, err := c.Cfg.ChainClient.TransactionByHash(ctx, txHash)
+		if err == nil && isPending {
+			return nil, true, nil
+		}
+		if err != nil && !errors.Is(err, ethereum.NotFound) {
+			return nil, false, err
+		}
+	}
+	// sending again fills the nonce instead of stalling behind it
+	c.releaseNonce(sent.Nonce)
+	return nil, false, nil
+}
+
+func containsHash(hashes []ethc.Hash, hash ethc.Hash) bool {
+	for _, h := range hashes {
+		if h == hash {
+			return true
+		}
+	}
+	return false
+}
+
+// TxRequest is a state-changing call sent through the txmgr pipeline.
+type TxRequest struct {
+	To    ethc.Address
+	Data  []byte
+	Value *big.Int
+	// GasLimit skips gas estimation when set; forced sends of calls that
+	// revert need it since the node cannot estimate them.
+	GasLimit uint64
+	// ABI decodes the revert reasons of the target contract, the
+	// TreasureManager ABI is used when nil.
+	ABI *abi.ABI
+	// Force skips the pre-flight simulation.
+	Force bool
+
+	// approved is set by ExecuteProposal only.
+	approved bool
+}
+
+func (r *TxRequest) callMsg(from ethc.Address) ethereum.CallMsg {
+	to := r.To
+	return ethereum.CallMsg{
+		From:  from,
+		To:    &to,
+		Value: r.Value,
+		Data:  r.Data,
+	}
+}
+
+func (c *ContractCaller) requestABI(req *TxRequest) *abi.ABI {
+	if req.ABI != nil {
+		return req.ABI
+	}
+	return c.TreasureManagerABI
+}
+
+// Simulate runs req with eth_call and eth_estimateGas against the pending
+// block and returns the gas it needs, or a SimulationError with the decoded
+// revert reason if it would fail.
+func (c *ContractCaller) Simulate(ctx context.Context, req *TxRequest) (uint64, error) {
+	_, gas, err := c.simulate(ctx, req)
+	return gas, err
+}
+
+// simulate is Simulate also returning what the call returned.
+func (c *ContractCaller) simulate(ctx context.Context, req *TxRequest) ([]byte, uint64, error) {
+	msg := req.callMsg(c.WalletAddr)
+	result, err := c.Cfg.ChainClient.PendingCallContract(ctx, msg)
+	if err != nil {
+		return nil, 0, c.simulationError(req, err)
+	}
+	gas, err := c.Cfg.ChainClient.EstimateGas(ctx, msg)
+	if err != nil {
+		return nil, 0, c.simulationError(req, err)
+	}
+	return result, gas, nil
+}
+
+// simulationError is the SimulationError of a call the node refused. Any
+// other error, e.g. of a node that was not reached, says nothing about the
+// call and is returned as is.
+func (c *ContractCaller) simulationError(req *TxRequest, err error) error {
+	var rpcErr rpc.Error
+	if !errors.As(err, &rpcErr) {
+		return fmt.Errorf("simulate: %w", err)
+	}
+	simErr := &SimulationError{Data: txmgr.RevertData(err), Err: err}
+	if reason, decodeErr := common2.DecodeRevert(simErr.Data, c.requestABI(req)); decodeErr == nil {
+		simErr.Reason = reason
+	}
+	return simErr
+}
+
+// Transact simulates req, then signs and sends it through txmgr with a
+// nonce from the nonce manager, and waits for its confirmation.
+func (c *ContractCaller) Transact(ctx context.Context, req *TxRequest) (*types.Receipt, error) {
+	if method, ok := c.requiresProposal(req); ok {
+		return nil, errors.Wrap(ErrProposalRequired, method)
+	}
+	if c.SafeContract != nil && req.To == c.Cfg.TreasureManagerAddr {
+		return c.transactViaSafe(ctx, req)
+	}
+	gas := req.GasLimit
+	if !req.Force && !c.Cfg.ForceSend {
+		simulatedGas, err := c.Simulate(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		txmgr.ReportProgress(ctx, txmgr.TxStageSimulated, ethc.Hash{})
+		if gas == 0 {
+			gas = simulatedGas
+		}
+	} else if gas == 0 {
+		estimated, err := c.Cfg.ChainClient.EstimateGas(ctx, req.callMsg(c.WalletAddr))
+		if err != nil {
+			return nil, fmt.Errorf("forced send needs a gas limit: %w", err)
+		}
+		gas = estimated
+	}
+
+	nonce, err := c.nonceManager.Next(ctx)
+	if err != nil {
+		log.Error("Contract wallet unable to get next nonce", "err", err)
+		return nil, err
+	}
+	to := req.To
+	tx := types.NewTx(&types.DynamicFeeTx{
+		ChainID: c.Cfg.ChainID,
+		Nonce:   nonce,
+		Gas:     gas,
+		To:      &to,
+		Value:   req.Value,
+		Data:    req.Data,
+	})
+
+	receipt, err := c.txMgr.Send(ctx, c.gasPriceUpdater(tx, nil), c.SendTransaction)
+	if errors.Is(err, txmgr.ErrTxReverted) {
+		c.nonceManager.Confirm(nonce)
+		return receipt, c.decodeRevert(err, c.requestABI(req))
+	}
+	if err != nil {
+		// a broadcast transaction may still be mined, its nonce stays
+		// taken until the chain shows it mined or pending
+		if errors.Is(err, txmgr.ErrNotBroadcast) {
+			c.releaseNonce(nonce)
+		}
+		return nil, err
+	}
+	c.nonceManager.Confirm(nonce)
+	return receipt, nil
+}
+
+// treasureCaller is the account calling the TreasureManager for c: its Safe
+// in Safe mode, the caller wallet otherwise.
+func (c *ContractCaller) treasureCaller() ethc.Address {
+	if c.SafeContract != nil {
+		return c.Cfg.SafeAddress
+	}
+	return c.WalletAddr
+}
+
+// sentByCaller is whether tx is how c calls the TreasureManager: an
+// execTransaction of its Safe in Safe mode, which any owner may send, or a
+// transaction of the caller wallet otherwise.
+func (c *ContractCaller) sentByCaller(tx *types.Transaction) bool {
+	if c.SafeContract != nil {
+		return tx.To() != nil && *tx.To() == c.Cfg.SafeAddress
+	}
+	from, err := types.Sender(types.LatestSignerForChainID(c.Cfg.ChainID), tx)
+	return err == nil && from == c.WalletAddr
+}
+
+// transactTreasureManager packs a TreasureManager write and sends it through
+// Transact.
+func (c *ContractCaller) transactTreasureManager(ctx context.Context, value *big.Int, method string, args ...interface{}) (*types.Receipt, error) {
+	data, err := c.TreasureManagerABI.Pack(method, args...)
+	if err != nil {
+		return nil, err
+	}
+	return c.Transact(ctx, &TxRequest{
+		To:    c.Cfg.TreasureManagerAddr,
+		Data:  data,
+		Value: value,
+	})
+}
@@ -0,0 +1,89 @@
+package caller
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/the-web3/contracts-caller/txmgr"
+)
+
+func TestTransactSimulates(t *testing.T) {
+	tests := []struct {
+		name      string
+		cfg       ContractCallerConfig
+		force     bool
+		cancelled bool
+		// sent is whether the call went on chain, where it reverts
+		sent bool
+		err  error
+	}{
+		{
+			name: "reverting call refused",
+			err:  ErrSimulationReverted,
+		},
+		{
+			name:  "forced request",
+			force: true,
+			sent:  true,
+			err:   txmgr.ErrTxReverted,
+		},
+		{
+			name: "forced by config",
+			cfg:  ContractCallerConfig{ForceSend: true},
+			sent: true,
+			err:  txmgr.ErrTxReverted,
+		},
+		{
+			name:      "node not reached",
+			cancelled: true,
+			err:       context.Canceled,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, owner := newTestKey(t)
+			chain := newTestChain(t, owner)
+			tmAddr, _ := chain.DeployTreasureManager(key, owner)
+			journal := txmgr.NewMemoryJournal()
+			tt.cfg.Journal = journal
+			c := chain.NewCaller(key, tmAddr, tt.cfg)
+			// more than the 10 ETH deposited
+			amount := new(big.Int).Mul(big.NewInt(100), big.NewInt(params.Ether))
+			data, err := c.TreasureManagerABI.Pack("withdrawETH", owner, amount)
+			require.Nil(t, err)
+			head := chain.head().header.Number.Uint64()
+			nonce := chain.pendingNonce(owner)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tt.cancelled {
+				cancel()
+			}
+			_, err = c.Transact(ctx, &TxRequest{To: tmAddr, Data: data, GasLimit: 100_000, Force: tt.force})
+			require.ErrorIs(t, err, tt.err)
+			if tt.sent {
+				require.NotErrorIs(t, err, ErrSimulationReverted)
+				entry, err := journal.Entry(nonce)
+				require.Nil(t, err)
+				require.Len(t, entry.Attempts, 1)
+				require.Equal(t, head+1, chain.head().header.Number.Uint64())
+				return
+			}
+			// nothing was signed
+			_, journalErr := journal.Entry(nonce)
+			require.ErrorIs(t, journalErr, txmgr.ErrJournalEntryNotFound)
+			require.Equal(t, head, chain.head().header.Number.Uint64())
+			require.Equal(t, nonce, chain.pendingNonce(owner))
+			if !tt.cancelled {
+				var simErr *SimulationError
+				require.ErrorAs(t, err, &simErr)
+				require.Equal(t, "Insufficient ETH balance in contract", simErr.Reason)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package caller
+
+import (
+	"context"
+	"math/big"
+
+	ethc "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// The TreasureManager writes, each one simulated, signed and sent through
+// Transact and returning the receipt once confirmed.
+
+func (c *ContractCaller) DepositETH(ctx context.Context, amount *big.Int) (*types.Receipt, error) {
+	return c.transactTreasureManager(ctx, amount, "depositETH")
+}
+
+func (c *ContractCaller) DepositERC20(ctx context.Context, token ethc.Address, amount *big.Int) (*types.Receipt, error) {
+	return c.transactTreasureManager(ctx, nil, "depositERC20", token, amount)
+}
+
+func (c *ContractCaller) GrantRewards(ctx context.Context, token, granter ethc.Address, amount *big.Int) (*types.Receipt, error) {
+	return c.transactTreasureManager(ctx, nil, "grantRewards", token, granter, amount)
+}
+
+func (c *ContractCaller) WithdrawETH(ctx context.Context, to ethc.Address, amount *big.Int) (*types.Receipt, error) {
+	return c.transactTreasureManager(ctx, nil, "withdrawETH", to, amount)
+}
+
+func (c *ContractCaller) WithdrawERC20(ctx context.Context, token, to ethc.Address, amount *big.Int) (*types.Receipt, error) {
+	return c.transactTreasureManager(ctx, nil, "withdrawERC20", token, to, amount)
+}
+
+func (c *ContractCaller) ClaimToken(ctx context.Context, token ethc.Address) (*types.Receipt, error) {
+	return c.transactTreasureManager(ctx, nil, "claimToken", token)
+}
+
+func (c *ContractCaller) ClaimAllTokens(ctx context.Context) (*types.Receipt, error) {
+	return c.transactTreasureManager(ctx, nil, "claimAllTokens")
+}
+
+func (c *ContractCaller) SetTokenWhiteList(ctx context.Context, token ethc.Address) (*types.Receipt, error) {
+	return c.transactTreasureManager(ctx, nil, "setTokenWhiteList", token)
+}
+
+func (c *ContractCaller) SetWithdrawManager(ctx context.Context, withdrawManager ethc.Address) (*types.Receipt, error) {
+	return c.transactTreasureManager(ctx, nil, "setWithdrawManager", withdrawManager)
+}
+
+func (c *ContractCaller) GrantRole(ctx context.Context, role [32]byte, account ethc.Address) (*types.Receipt, error) {
+	return c.transactTreasureManager(ctx, nil, "grantRole", role, account)
+}
+
+func (c *ContractCaller) RevokeRole(ctx context.Context, role [32]byte, account ethc.Address) (*types.Receipt, error) {
+	return c.transactTreasureManager(ctx, nil, "revokeRole", role, account)
+}
+
+// RenounceRole gives up role for the caller wallet itself, the contract
+// wants the wallet address repeated as confirmation.
+func (c *ContractCaller) RenounceRole(ctx context.Context, role [32]byte) (*types.Receipt, error) {
+	return c.transactTreasureManager(ctx, nil, "renounceRole", role, c.WalletAddr)
+}
+
+func (c *ContractCaller) TransferOwnership(ctx context.Context, newOwner ethc.Address) (*types.Receipt, error) {
+	return c.transactTreasureManager(ctx, nil, "transferOwnership", newOwner)
+}
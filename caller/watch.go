@@ -0,0 +1,278 @@
+package caller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	ethc "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/the-web3/contracts-caller/indexer"
+)
+
+// watchResumeMargin is how many blocks before the last live event are
+// filtered again after a resubscribe, for logs still in flight when the
+// connection dropped. It is also how deep a reorg is noticed when polling.
+const watchResumeMargin = 64
+
+// TreasureManagerEvent is one event of the TreasureManager, decoded with its
+// ABI. Raw.Removed is set when the event was reorged out.
+type TreasureManagerEvent struct {
+	Name string
+	Raw  types.Log
+	// Fields are the arguments of the event by name, indexed ones included,
+	// as JSON friendly values.
+	Fields map[string]interface{}
+}
+
+type EventWatcherConfig struct {
+	// FromBlock is the first block whose events are delivered, the head at
+	// start when zero.
+	FromBlock uint64
+	// Subscribe uses a log subscription, which needs a WebSocket or IPC
+	// endpoint. Otherwise new events are polled for every PollInterval.
+	Subscribe    bool
+	PollInterval time.Duration
+	// MaxBackoff caps the wait between failed resubscribes.
+	MaxBackoff time.Duration
+	// ChunkSize is the block range of one eth_getLogs when backfilling.
+	ChunkSize uint64
+}
+
+// WatchEvents calls handle for every TreasureManager event from
+// cfg.FromBlock on, in chain order, until ctx is done. Events are never
+// lost across disconnects: after every (re)subscribe, or every poll, the
+// blocks since the last delivered event are filtered again, and events
+// already delivered are not delivered twice. Events of blocks reorged out
+// are delivered again with Raw.Removed set.
+func (c *ContractCaller) WatchEvents(ctx context.Context, cfg EventWatcherConfig, handle func(*TreasureManagerEvent)) error {
+	if cfg.PollInterval == 0 {
+		cfg.PollInterval = c.Cfg.LoopInterval
+	}
+	if cfg.MaxBackoff == 0 {
+		cfg.MaxBackoff = time.Minute
+	}
+	if cfg.ChunkSize == 0 {
+		cfg.ChunkSize = 2000
+	}
+	w := &eventWatcher{
+		c:         c,
+		cfg:       cfg,
+		handle:    handle,
+		from:      cfg.FromBlock,
+		delivered: make(map[deliveredKey]*TreasureManagerEvent),
+	}
+	if w.from == 0 {
+		head, err := c.Cfg.ChainClient.HeaderByNumber(ctx, nil)
+		if err != nil {
+			return err
+		}
+		w.from = head.Number.Uint64()
+	}
+	if cfg.Subscribe {
+		return w.subscribe(ctx)
+	}
+	return w.poll(ctx)
+}
+
+type deliveredKey struct {
+	blockHash ethc.Hash
+	index     uint
+	removed   bool
+}
+
+type eventWatcher struct {
+	c      *ContractCaller
+	cfg    EventWatcherConfig
+	handle func(*TreasureManagerEvent)
+
+	// mu orders a backfill before the live events arriving meanwhile.
+	mu sync.Mutex
+	// from is the first block the next backfill filters.
+	from      uint64
+	delivered map[deliveredKey]*TreasureManagerEvent
+	lastLive  uint64
+}
+
+func (w *eventWatcher) query() ethereum.FilterQuery {
+	return ethereum.FilterQuery{Addresses: []ethc.Address{w.c.Cfg.TreasureManagerAddr}}
+}
+
+func (w *eventWatcher) poll(ctx context.Context) error {
+	ticker := time.NewTicker(w.cfg.PollInterval)
+	defer ticker.Stop()
+	for {
+		if err := w.backfill(ctx); err != nil && ctx.Err() == nil {
+			log.Error("Contract caller poll events fail", "from", w.from, "err", err)
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (w *eventWatcher) subscribe(ctx context.Context) error {
+	live := make(chan types.Log, 128)
+	sub := event.ResubscribeErr(w.cfg.MaxBackoff, func(subCtx context.Context, lastErr error) (event.Subscription, error) {
+		if lastErr != nil {
+			log.Warn("Contract caller event subscription dropped, resubscribing", "err", lastErr)
+		}
+		w.mu.Lock()
+		defer w.mu.Unlock()
+
+		// subscribe before backfilling, so nothing falls in between
+		s, err := w.c.Cfg.ChainClient.SubscribeFilterLogs(subCtx, w.query(), live)
+		if err != nil {
+			log.Error("Contract caller subscribe logs fail", "err", err)
+			return nil, err
+		}
+		if w.lastLive > 0 {
+			resume := w.lastLive - min(w.lastLive, watchResumeMargin)
+			w.from = max(w.from, resume)
+		}
+		if err := w.backfillLocked(subCtx); err != nil {
+			s.Unsubscribe()
+			log.Error("Contract caller backfill events fail", "from", w.from, "err", err)
+			return nil, err
+		}
+		return s, nil
+	})
+	defer sub.Unsubscribe()
+
+	for {
+		select {
+		case l := <-live:
+			w.mu.Lock()
+			w.deliver(l)
+			if l.BlockNumber > w.lastLive {
+				w.lastLive = l.BlockNumber
+			}
+			w.pruneDelivered(w.lastLive - min(w.lastLive, watchResumeMargin))
+			w.mu.Unlock()
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (w *eventWatcher) backfill(ctx context.Context) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.backfillLocked(ctx)
+}
+
+// backfillLocked unwinds the events of blocks reorged out, then filters
+// w.from to the head in chunks and delivers the events in chain order.
+func (w *eventWatcher) backfillLocked(ctx context.Context) error {
+	if err := w.unwindReorged(ctx); err != nil {
+		return err
+	}
+	header, err := w.c.Cfg.ChainClient.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return err
+	}
+	head := header.Number.Uint64()
+	for start := w.from; start <= head; start += w.cfg.ChunkSize {
+		end := min(start+w.cfg.ChunkSize-1, head)
+		query := w.query()
+		query.FromBlock = new(big.Int).SetUint64(start)
+		query.ToBlock = new(big.Int).SetUint64(end)
+		logs, err := w.c.Cfg.ChainClient.FilterLogs(ctx, query)
+		if err != nil {
+			return fmt.Errorf("filter logs %d-%d: %w", start, end, err)
+		}
+		for _, l := range logs {
+			w.deliver(l)
+		}
+		w.from = end + 1
+	}
+	w.pruneDelivered(w.from - min(w.from, watchResumeMargin))
+	return nil
+}
+
+// unwindReorged delivers the events of every block no longer canonical
+// again as removed, newest first, and filters from the lowest of them
+// again. Only the newest delivered block needs checking as long as it is
+// canonical, since every block before it then is too.
+func (w *eventWatcher) unwindReorged(ctx context.Context) error {
+	for {
+		newest := w.newestDelivered()
+		if len(newest) == 0 {
+			return nil
+		}
+		number := newest[0].Raw.BlockNumber
+		header, err := w.c.Cfg.ChainClient.HeaderByNumber(ctx, new(big.Int).SetUint64(number))
+		switch {
+		case errors.Is(err, ethereum.NotFound):
+		case err != nil:
+			return err
+		case header.Hash() == newest[0].Raw.BlockHash:
+			return nil
+		}
+
+		log.Warn("Contract caller events reorged out", "block", number, "hash", newest[0].Raw.BlockHash)
+		for i := len(newest) - 1; i >= 0; i-- {
+			removed := newest[i].Raw
+			removed.Removed = true
+			w.deliver(removed)
+		}
+		w.from = min(w.from, number)
+	}
+}
+
+// newestDelivered returns the events of the highest block delivered and
+// not removed, in log order.
+func (w *eventWatcher) newestDelivered() []*TreasureManagerEvent {
+	var newest []*TreasureManagerEvent
+	for key, e := range w.delivered {
+		if key.removed {
+			continue
+		}
+		switch {
+		case len(newest) == 0 || e.Raw.BlockNumber > newest[0].Raw.BlockNumber:
+			newest = []*TreasureManagerEvent{e}
+		case e.Raw.BlockNumber == newest[0].Raw.BlockNumber:
+			newest = append(newest, e)
+		}
+	}
+	sort.Slice(newest, func(i, j int) bool { return newest[i].Raw.Index < newest[j].Raw.Index })
+	return newest
+}
+
+// pruneDelivered forgets the events before block, which no backfill
+// filters again.
+func (w *eventWatcher) pruneDelivered(block uint64) {
+	for key, e := range w.delivered {
+		if e.Raw.BlockNumber < block {
+			delete(w.delivered, key)
+		}
+	}
+}
+
+func (w *eventWatcher) deliver(l types.Log) {
+	key := deliveredKey{l.BlockHash, l.Index, l.Removed}
+	if _, ok := w.delivered[key]; ok {
+		return
+	}
+	decoded, err := indexer.DecodeLog(w.c.TreasureManagerABI, l)
+	if err != nil {
+		log.Warn("Contract caller skip undecodable log", "block", l.BlockNumber, "index", l.Index, "err", err)
+		return
+	}
+	e := &TreasureManagerEvent{Name: decoded.Name, Raw: l, Fields: decoded.Fields}
+	if l.Removed {
+		delete(w.delivered, deliveredKey{l.BlockHash, l.Index, false})
+	}
+	w.delivered[key] = e
+	w.handle(e)
+}
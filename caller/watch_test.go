@@ -0,0 +1,82 @@
+package caller
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func nextEvent(t *testing.T, events <-chan *TreasureManagerEvent) *TreasureManagerEvent {
+	select {
+	case e := <-events:
+		return e
+	case <-time.After(5 * time.Second):
+		t.Fatal("no event delivered")
+		return nil
+	}
+}
+
+func TestWatchEvents(t *testing.T) {
+	for _, subscribe := range []bool{false, true} {
+		name := "poll"
+		if subscribe {
+			name = "subscribe"
+		}
+		t.Run(name, func(t *testing.T) {
+			key, owner := newTestKey(t)
+			chain := newTestChain(t, owner)
+			tmAddr, tm := chain.DeployTreasureManager(key, owner)
+			c := chain.NewCaller(key, tmAddr, ContractCallerConfig{})
+			// events of the deployment are not watched
+			from := chain.head().header.Number.Uint64() + 1
+
+			ctx, cancel := context.WithCancel(context.Background())
+			events := make(chan *TreasureManagerEvent, 64)
+			done := make(chan error, 1)
+			go func() {
+				done <- c.WatchEvents(ctx, EventWatcherConfig{FromBlock: from, Subscribe: subscribe, PollInterval: 20 * time.Millisecond},
+					func(e *TreasureManagerEvent) { events <- e })
+			}()
+			defer func() {
+				cancel()
+				require.Nil(t, <-done)
+			}()
+
+			opts := chain.Transactor(key)
+			opts.Value = big.NewInt(1000)
+			deposit := chain.Wait(tm.DepositETH(opts))
+			e := nextEvent(t, events)
+			require.Equal(t, "DepositToken", e.Name)
+			require.Equal(t, deposit.TxHash, e.Raw.TxHash)
+			require.Equal(t, owner.Hex(), e.Fields["sender"])
+			require.Equal(t, "1000", e.Fields["amount"])
+
+			manager := common.HexToAddress(jobsManager)
+			chain.Wait(tm.SetWithdrawManager(chain.Transactor(key), manager))
+			e = nextEvent(t, events)
+			require.Equal(t, "WithdrawManagerUpdate", e.Name)
+			require.False(t, e.Raw.Removed)
+
+			// the manager update is reorged out for a deposit of 2000
+			chain.Rollback(1)
+			opts.Value = big.NewInt(2000)
+			chain.Wait(tm.DepositETH(opts))
+			e = nextEvent(t, events)
+			require.Equal(t, "WithdrawManagerUpdate", e.Name)
+			require.True(t, e.Raw.Removed)
+			e = nextEvent(t, events)
+			require.Equal(t, "DepositToken", e.Name)
+			require.Equal(t, "2000", e.Fields["amount"])
+			require.False(t, e.Raw.Removed)
+
+			// nothing is delivered twice
+			time.Sleep(100 * time.Millisecond)
+			require.Len(t, events, 0)
+		})
+	}
+}
@@ -0,0 +1,206 @@
+package caller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	ethc "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/the-web3/contracts-caller/bindings"
+	common2 "github.com/the-web3/contracts-caller/common"
+	"github.com/the-web3/contracts-caller/txmgr"
+)
+
+// LoadTokenList reads the desired token whitelist from a file or an http(s)
+// URL. The list is either a JSON array of addresses or one address per line,
+// with # starting a comment.
+func LoadTokenList(ctx context.Context, source string) ([]ethc.Address, error) {
+	var (
+		data []byte
+		err  error
+	)
+	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		data, err = fetchTokenList(ctx, source)
+	} else {
+		data, err = os.ReadFile(source)
+	}
+	if err != nil {
+		return nil, err
+	}
+	tokens, err := parseTokenList(data)
+	if err != nil {
+		return nil, fmt.Errorf("token list %s: %w", source, err)
+	}
+	return tokens, nil
+}
+
+func fetchTokenList(ctx context.Context, url string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("get token list %s: %s", url, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+func parseTokenList(data []byte) ([]ethc.Address, error) {
+	var entries []string
+	if trimmed := strings.TrimSpace(string(data)); strings.HasPrefix(trimmed, "[") {
+		if err := json.Unmarshal([]byte(trimmed), &entries); err != nil {
+			return nil, err
+		}
+	} else {
+		for _, line := range strings.Split(trimmed, "\n") {
+			if i := strings.Index(line, "#"); i >= 0 {
+				line = line[:i]
+			}
+			if line = strings.TrimSpace(line); line != "" {
+				entries = append(entries, line)
+			}
+		}
+	}
+
+	seen := make(map[ethc.Address]bool, len(entries))
+	tokens := make([]ethc.Address, 0, len(entries))
+	for _, entry := range entries {
+		token, err := common2.ParseAddress(entry)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[token] {
+			seen[token] = true
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens, nil
+}
+
+// WhitelistPlan is the difference between a desired token whitelist and the
+// one on chain. The contract can only add tokens, so Extra is reported but
+// never acted on.
+type WhitelistPlan struct {
+	Desired []ethc.Address `json:"desired"`
+	OnChain []ethc.Address `json:"onChain"`
+	// Missing are desired tokens to add with setTokenWhiteList.
+	Missing []ethc.Address `json:"missing"`
+	// Extra are whitelisted tokens not in the desired list.
+	Extra []ethc.Address `json:"extra"`
+}
+
+// InSync tells whether applying the plan would send nothing.
+func (p *WhitelistPlan) InSync() bool {
+	return len(p.Missing) == 0
+}
+
+// PlanTokenWhitelist compares desired with the whitelist of tm. It needs no
+// wallet, so a plan can be made by anyone with an RPC endpoint.
+func PlanTokenWhitelist(ctx context.Context, tm *bindings.TreasureManagerCaller, desired []ethc.Address) (*WhitelistPlan, error) {
+	onChain, err := readTokenWhitelist(ctx, tm)
+	if err != nil {
+		return nil, err
+	}
+	plan := &WhitelistPlan{Desired: desired, OnChain: onChain, Missing: []ethc.Address{}, Extra: []ethc.Address{}}
+	listed := make(map[ethc.Address]bool, len(onChain))
+	for _, token := range onChain {
+		listed[token] = true
+	}
+	wanted := make(map[ethc.Address]bool, len(desired))
+	for _, token := range desired {
+		wanted[token] = true
+		if !listed[token] {
+			plan.Missing = append(plan.Missing, token)
+		}
+	}
+	for _, token := range onChain {
+		if !wanted[token] {
+			plan.Extra = append(plan.Extra, token)
+		}
+	}
+	return plan, nil
+}
+
+// readTokenWhitelist returns getTokenWhiteList(), or walks tokenWhiteList(i)
+// until it reverts when the whole array is too large to return in one call.
+func readTokenWhitelist(ctx context.Context, tm *bindings.TreasureManagerCaller) ([]ethc.Address, error) {
+	opts := &bind.CallOpts{Context: ctx}
+	tokens, err := tm.GetTokenWhiteList(opts)
+	if err == nil {
+		return tokens, nil
+	}
+	log.Warn("Contract caller getTokenWhiteList fail, reading tokenWhiteList by index", "err", err)
+
+	tokens = nil
+	for i := int64(0); ; i++ {
+		token, err := tm.TokenWhiteList(opts, big.NewInt(i))
+		if err != nil {
+			// reading past the end reverts with an out of bounds panic
+			if txmgr.RevertData(err) != nil || strings.Contains(err.Error(), "execution reverted") {
+				return tokens, nil
+			}
+			return nil, fmt.Errorf("tokenWhiteList(%d): %w", i, err)
+		}
+		tokens = append(tokens, token)
+	}
+}
+
+// ApplyTokenWhitelist adds every missing token of plan, one transaction at a
+// time, and stops at the first failure. The receipts of the tokens added so
+// far are returned either way.
+func (c *ContractCaller) ApplyTokenWhitelist(ctx context.Context, plan *WhitelistPlan) ([]*types.Receipt, error) {
+	var receipts []*types.Receipt
+	for _, token := range plan.Missing {
+		receipt, err := c.SetTokenWhiteList(ctx, token)
+		if err != nil {
+			return receipts, fmt.Errorf("setTokenWhiteList %s: %w", token, err)
+		}
+		log.Info("Contract caller token whitelisted", "token", token, "TxHash", receipt.TxHash)
+		receipts = append(receipts, receipt)
+	}
+	return receipts, nil
+}
+
+// reconcileTokenWhitelist reloads the desired list on every run, so edits to
+// the file or URL are picked up without a restart.
+func (c *ContractCaller) reconcileTokenWhitelist(ctx context.Context) (string, error) {
+	desired, err := LoadTokenList(ctx, c.Cfg.TokenWhitelist)
+	if err != nil {
+		return "", err
+	}
+	plan, err := PlanTokenWhitelist(ctx, &c.TreasureManagerContract.TreasureManagerCaller, desired)
+	if err != nil {
+		return "", err
+	}
+	if len(plan.Extra) > 0 {
+		log.Warn("Contract caller whitelisted tokens not in the desired list, they cannot be removed", "tokens", plan.Extra)
+	}
+	if plan.InSync() {
+		return ReconcileInSync, nil
+	}
+	if c.Cfg.ObserveOnly {
+		return "", fmt.Errorf("%w: tokens missing from the whitelist %v", ErrStateDrift, plan.Missing)
+	}
+	receipts, err := c.ApplyTokenWhitelist(ctx, plan)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s %d tokens", ReconcileCorrected, len(receipts)), nil
+}
@@ -0,0 +1,126 @@
+package caller
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	usdt = common.HexToAddress("0xdAC17F958D2ee523a2206206994597C13D831ec7")
+	usdc = common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+)
+
+func TestParseTokenList(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want []common.Address
+		err  bool
+	}{
+		{name: "json", data: fmt.Sprintf(`["%s", "%s"]`, usdt.Hex(), usdc.Hex()), want: []common.Address{usdt, usdc}},
+		{name: "lines", data: fmt.Sprintf("# stablecoins\n%s\n\n  %s  # circle\n", usdt.Hex(), usdc.Hex()), want: []common.Address{usdt, usdc}},
+		{name: "duplicates", data: fmt.Sprintf("%s\n%s\n%s\n", usdt.Hex(), usdc.Hex(), usdt.Hex()), want: []common.Address{usdt, usdc}},
+		{name: "empty", data: "# nothing yet\n", want: []common.Address{}},
+		{name: "bad address", data: "usdt\n", err: true},
+		{name: "bad json", data: `["usdt"`, err: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokens, err := parseTokenList([]byte(tt.data))
+			if tt.err {
+				require.Error(t, err)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, tt.want, tokens)
+		})
+	}
+}
+
+func TestLoadTokenList(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "tokens.txt")
+	require.Nil(t, os.WriteFile(path, []byte(usdt.Hex()+"\n"), 0o600))
+	tokens, err := LoadTokenList(ctx, path)
+	require.Nil(t, err)
+	require.Equal(t, []common.Address{usdt}, tokens)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/tokens.json" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprintf(w, `["%s"]`, usdc.Hex())
+	}))
+	defer srv.Close()
+	tokens, err = LoadTokenList(ctx, srv.URL+"/tokens.json")
+	require.Nil(t, err)
+	require.Equal(t, []common.Address{usdc}, tokens)
+
+	_, err = LoadTokenList(ctx, srv.URL+"/missing.json")
+	require.ErrorContains(t, err, "404")
+	_, err = LoadTokenList(ctx, filepath.Join(t.TempDir(), "missing.txt"))
+	require.Error(t, err)
+}
+
+func TestReconcileTokenWhitelist(t *testing.T) {
+	tests := []struct {
+		name        string
+		listed      []common.Address
+		observeOnly bool
+		result      string
+		err         error
+		extra       []common.Address
+	}{
+		{name: "missing tokens", result: ReconcileCorrected + " 2 tokens"},
+		{name: "in sync", listed: []common.Address{usdt, usdc}, result: ReconcileInSync},
+		{name: "partly listed", listed: []common.Address{usdc}, result: ReconcileCorrected + " 1 tokens"},
+		{name: "observe only", observeOnly: true, err: ErrStateDrift},
+		// the contract cannot unlist a token, extra ones are only reported
+		{name: "extra token", listed: []common.Address{usdt, usdc, common.HexToAddress(jobsManager)}, result: ReconcileInSync, extra: []common.Address{common.HexToAddress(jobsManager)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, owner := newTestKey(t)
+			chain := newTestChain(t, owner)
+			tmAddr, tm := chain.DeployTreasureManager(key, owner)
+			eth, err := tm.EthAddress(&bind.CallOpts{})
+			require.Nil(t, err)
+			for _, token := range tt.listed {
+				chain.Wait(tm.SetTokenWhiteList(chain.Transactor(key), token))
+			}
+
+			path := filepath.Join(t.TempDir(), "tokens.txt")
+			require.Nil(t, os.WriteFile(path, []byte(fmt.Sprintf("%s\n%s\n%s\n", eth.Hex(), usdt.Hex(), usdc.Hex())), 0o600))
+			c := chain.NewCaller(key, tmAddr, ContractCallerConfig{TokenWhitelist: path, ObserveOnly: tt.observeOnly})
+			require.Len(t, c.reconcilers, 1)
+
+			ctx := context.Background()
+			result, err := c.reconcileTokenWhitelist(ctx)
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+				require.ErrorContains(t, err, usdt.Hex())
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, tt.result, result)
+
+			desired, err := LoadTokenList(ctx, path)
+			require.Nil(t, err)
+			plan, err := PlanTokenWhitelist(ctx, &tm.TreasureManagerCaller, desired)
+			require.Nil(t, err)
+			require.True(t, plan.InSync())
+			require.ElementsMatch(t, tt.extra, plan.Extra)
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package caller
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"sync"
+	"time"
+
+	ethc "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/ethdb/leveldb"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+)
+
+type WithdrawalStatus string
+
+const (
+	WithdrawalAwaitingApproval WithdrawalStatus = "awaiting-approval"
+	WithdrawalApproved         WithdrawalStatus = "approved"
+	// WithdrawalPending is recorded before the withdrawal is sent, so a
+	// crash leaves a trace that is resolved against the chain on rerun.
+	WithdrawalPending  WithdrawalStatus = "pending"
+	WithdrawalExecuted WithdrawalStatus = "executed"
+	WithdrawalRejected WithdrawalStatus = "rejected"
+	WithdrawalFailed   WithdrawalStatus = "failed"
+)
+
+var ErrWithdrawalNotFound = errors.New("caller: withdrawal not found")
+
+// Withdrawal is one withdrawal request and what became of it.
+type Withdrawal struct {
+	ID          string           `json:"id"`
+	Token       ethc.Address     `json:"token"`
+	To          ethc.Address     `json:"to"`
+	Amount      *big.Int         `json:"amount"`
+	Reason      string           `json:"reason,omitempty"`
+	RequestedBy string           `json:"requestedBy"`
+	ApprovedBy  string           `json:"approvedBy,omitempty"`
+	RejectedBy  string           `json:"rejectedBy,omitempty"`
+	Status      WithdrawalStatus `json:"status"`
+	// ProposalID is the proposal sending the withdrawal once approvals are
+	// required.
+	ProposalID string    `json:"proposalId,omitempty"`
+	CreatedAt  time.Time `json:"createdAt"`
+	// SentAt is when the withdrawal was first sent, what counts it against
+	// the daily limit of its token.
+	SentAt time.Time `json:"sentAt,omitempty"`
+	// StartBlock is the head when the withdrawal was first sent, where its
+	// event is looked for when the record is still pending.
+	StartBlock uint64 `json:"startBlock,omitempty"`
+	// Sent is the transaction sending it, nil until one is signed.
+	Sent        *SentTx   `json:"sent,omitempty"`
+	TxHash      ethc.Hash `json:"txHash,omitempty"`
+	BlockNumber uint64    `json:"blockNumber,omitempty"`
+	Error       string    `json:"error,omitempty"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// WithdrawalStore durably records withdrawal requests, their approvals and
+// the transactions that executed them.
+type WithdrawalStore interface {
+	Get(id string) (*Withdrawal, error)
+	Put(w *Withdrawal) error
+	// List returns every withdrawal, oldest first.
+	List() ([]*Withdrawal, error)
+	Close() error
+}
+
+var withdrawalPrefix = []byte("wdr-")
+
+// KVWithdrawalStore is a WithdrawalStore backed by any go-ethereum
+// key-value store.
+type KVWithdrawalStore struct {
+	db ethdb.KeyValueStore
+	mu sync.Mutex
+}
+
+func NewKVWithdrawalStore(db ethdb.KeyValueStore) *KVWithdrawalStore {
+	return &KVWithdrawalStore{db: db}
+}
+
+// NewLevelDBWithdrawalStore opens (or creates) an on-disk store at path.
+func NewLevelDBWithdrawalStore(path string) (*KVWithdrawalStore, error) {
+	db, err := leveldb.New(path, 16, 16, "", false)
+	if err != nil {
+		return nil, err
+	}
+	return NewKVWithdrawalStore(db), nil
+}
+
+// NewMemoryWithdrawalStore returns a store that does not survive restarts.
+func NewMemoryWithdrawalStore() *KVWithdrawalStore {
+	return NewKVWithdrawalStore(memorydb.New())
+}
+
+// newRecordID returns an id that sorts by creation time, so the List of a
+// store keyed by it is oldest first.
+func newRecordID() (string, error) {
+	var suffix [4]byte
+	if _, err := rand.Read(suffix[:]); err != nil {
+		return "", err
+	}
+	return time.Now().UTC().Format("20060102T150405") + "-" + hex.EncodeToString(suffix[:]), nil
+}
+
+func withdrawalKey(id string) []byte {
+	return append(append([]byte{}, withdrawalPrefix...), id...)
+}
+
+func (s *KVWithdrawalStore) Get(id string) (*Withdrawal, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ok, err := s.db.Has(withdrawalKey(id))
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrWithdrawalNotFound
+	}
+	value, err := s.db.Get(withdrawalKey(id))
+	if err != nil {
+		return nil, err
+	}
+	var w Withdrawal
+	if err := json.Unmarshal(value, &w); err != nil {
+		return nil, err
+	}
+	return &w, nil
+}
+
+func (s *KVWithdrawalStore) Put(w *Withdrawal) error {
+	w.UpdatedAt = time.Now()
+	value, err := json.Marshal(w)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.db.Put(withdrawalKey(w.ID), value)
+}
+
+func (s *KVWithdrawalStore) List() ([]*Withdrawal, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	it := s.db.NewIterator(withdrawalPrefix, nil)
+	defer it.Release()
+
+	var withdrawals []*Withdrawal
+	for it.Next() {
+		var w Withdrawal
+		if err := json.Unmarshal(it.Value(), &w); err != nil {
+			return nil, err
+		}
+		withdrawals = append(withdrawals, &w)
+	}
+	return withdrawals, it.Error()
+}
+
+func (s *KVWithdrawalStore) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,665 @@
+package caller
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	ethc "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/the-web3/contracts-caller/bindings"
+	common2 "github.com/the-web3/contracts-caller/common"
+	"github.com/the-web3/contracts-caller/txmgr"
+)
+
+// WithdrawalPolicyETH names the ETH entry of a WithdrawalPolicy, whatever
+// ethAddress the contract uses for it.
+const WithdrawalPolicyETH = "ETH"
+
+// withdrawalWindow is the rolling window of the daily limits.
+const withdrawalWindow = 24 * time.Hour
+
+// ErrWithdrawalPolicy matches every withdrawal the policy refuses.
+var ErrWithdrawalPolicy = errors.New("withdrawal refused by policy")
+
+// ErrApprovalRefused matches an approval or rejection of a withdrawal that
+// its status or requester does not allow.
+var ErrApprovalRefused = errors.New("withdrawal approval refused")
+
+// WithdrawalPolicy is read from a YAML file like
+//
+//	destinations:
+//	  - "0x70997970C51812dc3A010C7d01b54e8b0b6FA4d6"
+//	tokens:
+//	  ETH:
+//	    dailyLimit: "10000000000000000000"
+//	    approvalThreshold: "1000000000000000000"
+//	    minRemaining: "5000000000000000000"
+//	  "0xdAC17F958D2ee523a2206206994597C13D831ec7":
+//	    dailyLimit: "50000000000"
+//
+// Only the destinations and tokens listed can be withdrawn.
+type WithdrawalPolicy struct {
+	Destinations []string                         `yaml:"destinations"`
+	Tokens       map[string]TokenWithdrawalLimits `yaml:"tokens"`
+
+	destinations map[ethc.Address]bool
+	eth          *withdrawalLimits
+	tokens       map[ethc.Address]*withdrawalLimits
+}
+
+// TokenWithdrawalLimits are amounts in the smallest unit of a token.
+type TokenWithdrawalLimits struct {
+	// DailyLimit caps what is withdrawn of the token in any 24 hours.
+	DailyLimit string `yaml:"dailyLimit"`
+	// ApprovalThreshold is the amount from which a withdrawal needs the
+	// approval of someone other than its requester, never when empty.
+	ApprovalThreshold string `yaml:"approvalThreshold"`
+	// MinRemaining is what tokenBalances must still hold afterwards.
+	MinRemaining string `yaml:"minRemaining"`
+}
+
+type withdrawalLimits struct {
+	dailyLimit        *big.Int
+	approvalThreshold *big.Int
+	minRemaining      *big.Int
+}
+
+func LoadWithdrawalPolicy(path string) (*WithdrawalPolicy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var policy WithdrawalPolicy
+	if err := yaml.Unmarshal(data, &policy); err != nil {
+		return nil, fmt.Errorf("parse withdrawal policy %s: %w", path, err)
+	}
+	if err := policy.compile(); err != nil {
+		return nil, fmt.Errorf("withdrawal policy %s: %w", path, err)
+	}
+	return &policy, nil
+}
+
+func (p *WithdrawalPolicy) compile() error {
+	p.destinations = make(map[ethc.Address]bool, len(p.Destinations))
+	for _, s := range p.Destinations {
+		addr, err := common2.ParseAddress(s)
+		if err != nil {
+			return fmt.Errorf("destination: %w", err)
+		}
+		p.destinations[addr] = true
+	}
+	p.tokens = make(map[ethc.Address]*withdrawalLimits, len(p.Tokens))
+	for name, spec := range p.Tokens {
+		limits, err := spec.parse()
+		if err != nil {
+			return fmt.Errorf("token %s: %w", name, err)
+		}
+		if strings.EqualFold(name, WithdrawalPolicyETH) {
+			p.eth = limits
+			continue
+		}
+		token, err := common2.ParseAddress(name)
+		if err != nil {
+			return fmt.Errorf("token: %w", err)
+		}
+		p.tokens[token] = limits
+	}
+	return nil
+}
+
+func (l TokenWithdrawalLimits) parse() (*withdrawalLimits, error) {
+	parse := func(name, s string) (*big.Int, error) {
+		if s == "" {
+			return nil, nil
+		}
+		amount, ok := new(big.Int).SetString(s, 0)
+		if !ok || amount.Sign() < 0 {
+			return nil, fmt.Errorf("invalid %s: %v", name, s)
+		}
+		return amount, nil
+	}
+	var limits withdrawalLimits
+	var err error
+	if l.DailyLimit == "" {
+		return nil, errors.New("no dailyLimit")
+	}
+	if limits.dailyLimit, err = parse("dailyLimit", l.DailyLimit); err != nil {
+		return nil, err
+	}
+	if limits.approvalThreshold, err = parse("approvalThreshold", l.ApprovalThreshold); err != nil {
+		return nil, err
+	}
+	if limits.minRemaining, err = parse("minRemaining", l.MinRemaining); err != nil {
+		return nil, err
+	}
+	if limits.minRemaining == nil {
+		limits.minRemaining = new(big.Int)
+	}
+	return &limits, nil
+}
+
+func (p *WithdrawalPolicy) limits(token, ethAddress ethc.Address) *withdrawalLimits {
+	if token == ethAddress && p.eth != nil {
+		return p.eth
+	}
+	return p.tokens[token]
+}
+
+// needsApproval is whether a withdrawal of amount needs a second approval.
+func (l *withdrawalLimits) needsApproval(amount *big.Int) bool {
+	return l.approvalThreshold != nil && amount.Cmp(l.approvalThreshold) >= 0
+}
+
+// check returns what keeps w from being withdrawn at all, whatever was
+// withdrawn before it.
+func (p *WithdrawalPolicy) check(w *Withdrawal, ethAddress ethc.Address) []string {
+	var problems []string
+	if w.Amount == nil || w.Amount.Sign() <= 0 {
+		problems = append(problems, "amount must be positive")
+	}
+	if !p.destinations[w.To] {
+		problems = append(problems, fmt.Sprintf("destination %s is not allowed", w.To))
+	}
+	limits := p.limits(w.Token, ethAddress)
+	if limits == nil {
+		problems = append(problems, fmt.Sprintf("token %s is not in the policy", w.Token))
+	} else if w.Amount != nil && w.Amount.Cmp(limits.dailyLimit) > 0 {
+		problems = append(problems, fmt.Sprintf("amount %s is over the daily limit %s", w.Amount, limits.dailyLimit))
+	}
+	return problems
+}
+
+func policyError(problems []string) error {
+	return fmt.Errorf("%w: %s", ErrWithdrawalPolicy, strings.Join(problems, "; "))
+}
+
+// WithdrawalRequest is what a requester asks to withdraw.
+type WithdrawalRequest struct {
+	Token       ethc.Address `json:"token"`
+	To          ethc.Address `json:"to"`
+	Amount      *big.Int     `json:"amount"`
+	RequestedBy string       `json:"requestedBy"`
+	Reason      string       `json:"reason,omitempty"`
+}
+
+// RequestWithdrawal records a withdrawal the policy allows. It is approved
+// right away when under the approval threshold of its token, and awaits the
+// approval of someone else otherwise.
+func RequestWithdrawal(ctx context.Context, tm *bindings.TreasureManagerCaller, policy *WithdrawalPolicy, store WithdrawalStore, req *WithdrawalRequest) (*Withdrawal, error) {
+	if req.RequestedBy == "" {
+		return nil, errors.New("withdrawal request without a requester")
+	}
+	ethAddress, err := tm.EthAddress(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return nil, fmt.Errorf("ethAddress: %w", err)
+	}
+	id, err := newRecordID()
+	if err != nil {
+		return nil, err
+	}
+	w := &Withdrawal{
+		ID:          id,
+		Token:       req.Token,
+		To:          req.To,
+		Amount:      req.Amount,
+		Reason:      req.Reason,
+		RequestedBy: req.RequestedBy,
+		Status:      WithdrawalApproved,
+		CreatedAt:   time.Now(),
+	}
+	if problems := policy.check(w, ethAddress); len(problems) > 0 {
+		return nil, policyError(problems)
+	}
+	if policy.limits(w.Token, ethAddress).needsApproval(w.Amount) {
+		w.Status = WithdrawalAwaitingApproval
+	}
+	log.Info("Contract caller withdrawal requested", "id", w.ID, "token", w.Token, "to", w.To,
+		"amount", w.Amount, "requestedBy", w.RequestedBy, "status", w.Status)
+	return w, store.Put(w)
+}
+
+// ApproveWithdrawal is the second approval of a withdrawal over the
+// threshold, by anyone but its requester.
+func ApproveWithdrawal(store WithdrawalStore, id, approver string) (*Withdrawal, error) {
+	w, err := store.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if w.Status != WithdrawalAwaitingApproval {
+		return nil, errors.Wrapf(ErrApprovalRefused, "withdrawal %s is %s, not %s", id, w.Status, WithdrawalAwaitingApproval)
+	}
+	if approver == "" || approver == w.RequestedBy {
+		return nil, errors.Wrapf(ErrApprovalRefused, "withdrawal %s must be approved by someone other than its requester %s", id, w.RequestedBy)
+	}
+	w.ApprovedBy = approver
+	w.Status = WithdrawalApproved
+	log.Info("Contract caller withdrawal approved", "id", w.ID, "approvedBy", approver)
+	return w, store.Put(w)
+}
+
+// RejectWithdrawal drops a withdrawal that was not sent yet.
+func RejectWithdrawal(store WithdrawalStore, id, by, reason string) (*Withdrawal, error) {
+	w, err := store.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if w.Status != WithdrawalAwaitingApproval && w.Status != WithdrawalApproved {
+		return nil, errors.Wrapf(ErrApprovalRefused, "withdrawal %s is %s and can no longer be rejected", id, w.Status)
+	}
+	w.RejectedBy = by
+	w.Error = reason
+	w.Status = WithdrawalRejected
+	log.Info("Contract caller withdrawal rejected", "id", w.ID, "rejectedBy", by, "reason", reason)
+	return w, store.Put(w)
+}
+
+// ExecuteWithdrawal sends an approved withdrawal once the policy still
+// allows it: its daily limit counts every withdrawal of the token sent in the
+// last 24 hours, and tokenBalances must keep at least minRemaining. The
+// withdrawal is recorded as pending before it is sent and as executed once
+// its WithdrawToken event is in the receipt. A pending withdrawal left by a
+// crash is looked up on chain first, so it is never sent twice, and one
+// still in flight fails with ErrTxInFlight. Once ApprovalThreshold is set,
+// withdrawals go through ProposeWithdrawal and ExecuteWithdrawalProposal.
+func (c *ContractCaller) ExecuteWithdrawal(ctx context.Context, policy *WithdrawalPolicy, store WithdrawalStore, id string) (*Withdrawal, error) {
+	return c.executeWithdrawal(ctx, policy, store, nil, id)
+}
+
+// ProposeWithdrawal records an approved withdrawal the policy allows as a
+// proposal in proposals, for the approvers to sign.
+func (c *ContractCaller) ProposeWithdrawal(ctx context.Context, policy *WithdrawalPolicy, store WithdrawalStore, proposals ProposalStore, id string) (*Withdrawal, *Proposal, error) {
+	if !c.claimWithdrawal(id) {
+		return nil, nil, fmt.Errorf("withdrawal %s is already being executed", id)
+	}
+	defer c.unclaimWithdrawal(id)
+
+	w, err := store.Get(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if w.Status != WithdrawalApproved {
+		return w, nil, fmt.Errorf("withdrawal %s is %s, not %s", id, w.Status, WithdrawalApproved)
+	}
+	if w.ProposalID != "" {
+		p, err := proposals.Get(w.ProposalID)
+		switch {
+		case errors.Is(err, ErrProposalNotFound):
+		case err != nil:
+			return w, nil, err
+		case p.Status == ProposalOpen || p.Status == ProposalSent:
+			return w, p, fmt.Errorf("withdrawal %s is already proposed in %s", id, p.ID)
+		}
+	}
+	method, args, err := c.withdrawalCall(ctx, w)
+	if err != nil {
+		return w, nil, err
+	}
+	if err := c.checkWithdrawal(ctx, policy, store, w); err != nil {
+		return w, nil, err
+	}
+	p, err := c.newProposal(ctx, method, nil, args...)
+	if err != nil {
+		return w, nil, err
+	}
+	p.WithdrawalID = w.ID
+	if err := proposals.Put(p); err != nil {
+		return w, nil, err
+	}
+	w.ProposalID = p.ID
+	log.Info("Contract caller withdrawal proposed", "id", w.ID, "proposal", p.ID)
+	return w, p, store.Put(w)
+}
+
+// ExecuteWithdrawalProposal is ExecuteWithdrawal sending the withdrawal
+// through its proposal in proposals, once that holds enough approvals. The
+// proposal is recorded as executed or failed along with the withdrawal.
+func (c *ContractCaller) ExecuteWithdrawalProposal(ctx context.Context, policy *WithdrawalPolicy, store WithdrawalStore, proposals ProposalStore, id string) (*Withdrawal, error) {
+	return c.executeWithdrawal(ctx, policy, store, proposals, id)
+}
+
+// executeWithdrawal sends the withdrawal id directly when proposals is
+// nil, and through its proposal otherwise.
+func (c *ContractCaller) executeWithdrawal(ctx context.Context, policy *WithdrawalPolicy, store WithdrawalStore, proposals ProposalStore, id string) (*Withdrawal, error) {
+	if !c.claimWithdrawal(id) {
+		return nil, fmt.Errorf("withdrawal %s is already being executed", id)
+	}
+	defer c.unclaimWithdrawal(id)
+
+	w, err := store.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	// a pending withdrawal was sent before, its proposal too
+	resend := w.Status == WithdrawalPending
+	if resend {
+		if err := c.resolvePendingWithdrawal(ctx, store, w); err != nil {
+			return w, err
+		}
+		if w.Status != WithdrawalPending {
+			if proposals != nil && w.ProposalID != "" {
+				return w, c.settleWithdrawalProposal(proposals, w)
+			}
+			return w, nil
+		}
+	}
+	switch w.Status {
+	case WithdrawalApproved, WithdrawalPending:
+	case WithdrawalAwaitingApproval:
+		return w, fmt.Errorf("withdrawal %s is awaiting approval", id)
+	default:
+		return w, fmt.Errorf("withdrawal %s is already %s", id, w.Status)
+	}
+
+	method, args, err := c.withdrawalCall(ctx, w)
+	if err != nil {
+		return w, err
+	}
+	var p *Proposal
+	switch {
+	case proposals != nil:
+		if p, err = c.withdrawalProposal(proposals, w, method, args, resend); err != nil {
+			return w, err
+		}
+	case c.Cfg.ApprovalThreshold > 0:
+		return w, errors.Wrapf(ErrProposalRequired, "%s, propose it instead", method)
+	}
+	if err := c.reserveWithdrawal(ctx, policy, store, w); err != nil {
+		return w, err
+	}
+
+	w.Sent = &SentTx{}
+	ctx = recordSent(ctx, w.Sent, func() error { return store.Put(w) })
+	var receipt *types.Receipt
+	if p == nil {
+		receipt, err = c.transactTreasureManager(ctx, nil, method, args...)
+	} else if p, err = c.claimProposal(proposals, p.ID, w.ID, resend); err == nil {
+		receipt, err = c.sendProposal(ctx, proposals, p)
+	} else {
+		// the proposal was cancelled or expired meanwhile, nothing was sent
+		w.Status = WithdrawalApproved
+		w.Sent = nil
+		if putErr := store.Put(w); putErr != nil {
+			return w, putErr
+		}
+		return w, err
+	}
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrSimulationReverted) || errors.Is(err, txmgr.ErrTxReverted):
+			w.Status = WithdrawalFailed
+			w.Error = err.Error()
+		case errors.Is(err, txmgr.ErrNotBroadcast):
+			// nothing was sent, the withdrawal can be sent or rejected again
+			w.Status = WithdrawalApproved
+			w.Sent = nil
+		default:
+			// a transaction that may still be mined stays pending
+			return w, err
+		}
+		if putErr := store.Put(w); putErr != nil {
+			return w, putErr
+		}
+		return w, err
+	}
+	return w, c.settleWithdrawal(store, w, receipt)
+}
+
+// withdrawalCall is the TreasureManager method and arguments sending w.
+func (c *ContractCaller) withdrawalCall(ctx context.Context, w *Withdrawal) (string, []interface{}, error) {
+	ethAddress, err := c.TreasureManagerContract.EthAddress(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return "", nil, fmt.Errorf("ethAddress: %w", err)
+	}
+	if w.Token == ethAddress {
+		return "withdrawETH", []interface{}{w.To, w.Amount}, nil
+	}
+	return "withdrawERC20", []interface{}{w.Token, w.To, w.Amount}, nil
+}
+
+// withdrawalProposal returns the proposal of w once it can be sent, and
+// sends exactly the call of w.
+func (c *ContractCaller) withdrawalProposal(proposals ProposalStore, w *Withdrawal, method string, args []interface{}, resend bool) (*Proposal, error) {
+	if w.ProposalID == "" {
+		return nil, errors.Wrapf(ErrProposalRequired, "withdrawal %s was not proposed", w.ID)
+	}
+	p, err := proposals.Get(w.ProposalID)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.checkProposal(p, w.ID, resend); err != nil {
+		return p, err
+	}
+	data, err := c.TreasureManagerABI.Pack(method, args...)
+	if err != nil {
+		return p, err
+	}
+	if p.Method != method || !bytes.Equal(p.Data, data) || p.Value.Sign() != 0 {
+		return p, fmt.Errorf("proposal %s does not send withdrawal %s", p.ID, w.ID)
+	}
+	return p, nil
+}
+
+// settleWithdrawalProposal records on the proposal of w what became of w,
+// once its pending transaction was found mined.
+func (c *ContractCaller) settleWithdrawalProposal(proposals ProposalStore, w *Withdrawal) error {
+	p, err := proposals.Get(w.ProposalID)
+	if err != nil {
+		return err
+	}
+	p.Status = ProposalFailed
+	if w.Status == WithdrawalExecuted {
+		p.Status = ProposalExecuted
+	}
+	p.TxHash = w.TxHash
+	p.BlockNumber = w.BlockNumber
+	p.Error = w.Error
+	return proposals.Put(p)
+}
+
+// claimWithdrawal marks the withdrawal id as being executed, false when it
+// already is.
+func (c *ContractCaller) claimWithdrawal(id string) bool {
+	c.withdrawMu.Lock()
+	defer c.withdrawMu.Unlock()
+	if c.withdrawing[id] {
+		return false
+	}
+	if c.withdrawing == nil {
+		c.withdrawing = make(map[string]bool)
+	}
+	c.withdrawing[id] = true
+	return true
+}
+
+func (c *ContractCaller) unclaimWithdrawal(id string) {
+	c.withdrawMu.Lock()
+	defer c.withdrawMu.Unlock()
+	delete(c.withdrawing, id)
+}
+
+// reserveWithdrawal checks w against the policy and records it as pending
+// in one go, so withdrawals executed side by side each count the others
+// against the daily limit.
+func (c *ContractCaller) reserveWithdrawal(ctx context.Context, policy *WithdrawalPolicy, store WithdrawalStore, w *Withdrawal) error {
+	c.withdrawLimitMu.Lock()
+	defer c.withdrawLimitMu.Unlock()
+	if err := c.checkWithdrawal(ctx, policy, store, w); err != nil {
+		return err
+	}
+	head, err := c.Cfg.ChainClient.BlockNumber(ctx)
+	if err != nil {
+		return err
+	}
+	if w.SentAt.IsZero() {
+		w.SentAt = time.Now()
+	}
+	w.Status = WithdrawalPending
+	w.StartBlock = head
+	return store.Put(w)
+}
+
+// settleWithdrawal records the outcome of the withdrawal mined with receipt.
+func (c *ContractCaller) settleWithdrawal(store WithdrawalStore, w *Withdrawal, receipt *types.Receipt) error {
+	w.TxHash = receipt.TxHash
+	w.BlockNumber = receipt.BlockNumber.Uint64()
+	switch {
+	case receipt.Status == types.ReceiptStatusFailed:
+		w.Status = WithdrawalFailed
+		w.Error = txmgr.ErrTxReverted.Error()
+	case !c.hasWithdrawEvent(receipt, w):
+		return fmt.Errorf("no matching WithdrawToken event in %s", receipt.TxHash)
+	default:
+		w.Status = WithdrawalExecuted
+		w.Error = ""
+		log.Info("Contract caller withdrawal executed", "id", w.ID, "token", w.Token, "to", w.To,
+			"amount", w.Amount, "TxHash", w.TxHash)
+	}
+	return store.Put(w)
+}
+
+// checkWithdrawal applies the whole policy to w right before it is sent,
+// since the policy and the treasury may have changed since its request.
+func (c *ContractCaller) checkWithdrawal(ctx context.Context, policy *WithdrawalPolicy, store WithdrawalStore, w *Withdrawal) error {
+	opts := &bind.CallOpts{Context: ctx}
+	tm := c.TreasureManagerContract
+	ethAddress, err := tm.EthAddress(opts)
+	if err != nil {
+		return fmt.Errorf("ethAddress: %w", err)
+	}
+	problems := policy.check(w, ethAddress)
+	if len(problems) > 0 {
+		return policyError(problems)
+	}
+	limits := policy.limits(w.Token, ethAddress)
+	if limits.needsApproval(w.Amount) && w.ApprovedBy == "" {
+		problems = append(problems, fmt.Sprintf("amount %s needs a second approval from %s", w.Amount, limits.approvalThreshold))
+	}
+
+	withdrawn, err := withdrawnSince(store, w, time.Now().Add(-withdrawalWindow))
+	if err != nil {
+		return err
+	}
+	if total := new(big.Int).Add(withdrawn, w.Amount); total.Cmp(limits.dailyLimit) > 0 {
+		problems = append(problems, fmt.Sprintf("withdrawing %s after %s in the last 24h is over the daily limit %s", w.Amount, withdrawn, limits.dailyLimit))
+	}
+
+	balance, err := tm.TokenBalances(opts, w.Token)
+	if err != nil {
+		return fmt.Errorf("tokenBalances(%s): %w", w.Token, err)
+	}
+	if remaining := new(big.Int).Sub(balance, w.Amount); remaining.Cmp(limits.minRemaining) < 0 {
+		problems = append(problems, fmt.Sprintf("withdrawing %s of %s would leave less than %s", w.Amount, balance, limits.minRemaining))
+	}
+	if len(problems) > 0 {
+		return policyError(problems)
+	}
+	return nil
+}
+
+// withdrawnSince sums the other withdrawals of the token of w sent after
+// since. Pending ones count, as they may be mined any time.
+func withdrawnSince(store WithdrawalStore, w *Withdrawal, since time.Time) (*big.Int, error) {
+	withdrawals, err := store.List()
+	if err != nil {
+		return nil, err
+	}
+	total := new(big.Int)
+	for _, other := range withdrawals {
+		if other.ID == w.ID || other.Token != w.Token || other.SentAt.Before(since) {
+			continue
+		}
+		if other.Status == WithdrawalPending || other.Status == WithdrawalExecuted {
+			total.Add(total, other.Amount)
+		}
+	}
+	return total, nil
+}
+
+func (c *ContractCaller) hasWithdrawEvent(receipt *types.Receipt, w *Withdrawal) bool {
+	for _, l := range receipt.Logs {
+		if l.Address != c.Cfg.TreasureManagerAddr {
+			continue
+		}
+		event, err := c.TreasureManagerContract.ParseWithdrawToken(*l)
+		if err != nil {
+			continue
+		}
+		if c.matchesWithdrawal(event, w) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *ContractCaller) matchesWithdrawal(event *bindings.TreasureManagerWithdrawToken, w *Withdrawal) bool {
+	return event.TokenAddress == w.Token && event.Sender == c.treasureCaller() &&
+		event.WithdrawAddress == w.To && event.Amount.Cmp(w.Amount) == 0
+}
+
+// resolvePendingWithdrawal finds out what became of a pending w. The
+// transactions recorded as sent for it are looked up first: a mined one
+// settles w, and one that may still be mined keeps it pending with
+// ErrTxInFlight. Its Safe transaction is executed once it holds enough
+// signatures. Without sent transactions, the WithdrawToken event of w sent
+// by this caller since w.StartBlock, which no other withdrawal owns, is
+// looked for. Otherwise w is left for the withdrawal to be sent again.
+func (c *ContractCaller) resolvePendingWithdrawal(ctx context.Context, store WithdrawalStore, w *Withdrawal) error {
+	if w.Sent.known() {
+		receipt, err := c.resolveSentTx(ctx, w.Sent, func() error { return store.Put(w) })
+		switch {
+		case err != nil:
+			return errors.Wrapf(err, "withdrawal %s", w.ID)
+		case receipt != nil:
+			log.Info("Contract caller pending withdrawal mined", "id", w.ID, "TxHash", receipt.TxHash)
+			return c.settleWithdrawal(store, w, receipt)
+		}
+		log.Warn("Contract caller pending withdrawal dropped, sending again", "id", w.ID, "nonce", w.Sent.Nonce,
+			"safeTxHash", w.Sent.SafeTxHash)
+		return nil
+	}
+
+	withdrawals, err := store.List()
+	if err != nil {
+		return err
+	}
+	owned := make(map[ethc.Hash]bool)
+	for _, other := range withdrawals {
+		if other.Status == WithdrawalExecuted {
+			owned[other.TxHash] = true
+		}
+	}
+
+	it, err := c.TreasureManagerContract.FilterWithdrawToken(
+		&bind.FilterOpts{Start: w.StartBlock, Context: ctx}, []ethc.Address{w.Token})
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+	for it.Next() {
+		if owned[it.Event.Raw.TxHash] || !c.matchesWithdrawal(it.Event, w) {
+			continue
+		}
+		w.Status = WithdrawalExecuted
+		w.TxHash = it.Event.Raw.TxHash
+		w.BlockNumber = it.Event.Raw.BlockNumber
+		w.Error = ""
+		log.Info("Contract caller pending withdrawal found on chain", "id", w.ID, "TxHash", w.TxHash)
+		return store.Put(w)
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+	log.Warn("Contract caller pending withdrawal not on chain, sending again", "id", w.ID)
+	return nil
+}
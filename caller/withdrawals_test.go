@@ -0,0 +1,353 @@
+package caller
+
+import (
+	"context"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/the-web3/contracts-caller/bindings"
+)
+
+// ether is n ETH in wei.
+func ether(n int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(n), big.NewInt(params.Ether))
+}
+
+// testPolicy allows withdrawing up to 10 ETH a day to alice, those of 3 ETH
+// or more with a second approval, and keeps 1 ETH in the treasure.
+func testPolicy(t *testing.T) *WithdrawalPolicy {
+	path := filepath.Join(t.TempDir(), "policy.yaml")
+	require.Nil(t, os.WriteFile(path, []byte(`
+destinations:
+  - "0x70997970C51812dc3A010C7d01b54e8b0b6FA4d6"
+tokens:
+  ETH:
+    dailyLimit: "10000000000000000000"
+    approvalThreshold: "3000000000000000000"
+    minRemaining: "1000000000000000000"
+`), 0o600))
+	policy, err := LoadWithdrawalPolicy(path)
+	require.Nil(t, err)
+	return policy
+}
+
+// withdrawCount is how many WithdrawToken events tm emitted.
+func withdrawCount(t *testing.T, tm *bindings.TreasureManager) int {
+	it, err := tm.FilterWithdrawToken(&bind.FilterOpts{}, nil)
+	require.Nil(t, err)
+	defer it.Close()
+	count := 0
+	for it.Next() {
+		count++
+	}
+	require.Nil(t, it.Error())
+	return count
+}
+
+func TestLoadWithdrawalPolicy(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy string
+		err    string
+	}{
+		{
+			name:   "token without a daily limit",
+			policy: "tokens:\n  ETH:\n    approvalThreshold: \"1\"\n",
+			err:    "token ETH: no dailyLimit",
+		},
+		{
+			name:   "negative amount",
+			policy: "tokens:\n  ETH:\n    dailyLimit: \"-1\"\n",
+			err:    "invalid dailyLimit",
+		},
+		{
+			name:   "bad destination",
+			policy: "destinations: [\"0x1234\"]\n",
+			err:    "destination",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "policy.yaml")
+			require.Nil(t, os.WriteFile(path, []byte(tt.policy), 0o600))
+			_, err := LoadWithdrawalPolicy(path)
+			require.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func TestRequestWithdrawal(t *testing.T) {
+	key, owner := newTestKey(t)
+	chain := newTestChain(t, owner)
+	_, tm := chain.DeployTreasureManager(key, owner)
+	eth, err := tm.EthAddress(nil)
+	require.Nil(t, err)
+	policy := testPolicy(t)
+
+	tests := []struct {
+		name   string
+		req    *WithdrawalRequest
+		status WithdrawalStatus
+		err    string
+	}{
+		{
+			name:   "under the threshold",
+			req:    &WithdrawalRequest{Token: eth, To: alice, Amount: ether(1), RequestedBy: "ops"},
+			status: WithdrawalApproved,
+		},
+		{
+			name:   "at the threshold",
+			req:    &WithdrawalRequest{Token: eth, To: alice, Amount: ether(3), RequestedBy: "ops"},
+			status: WithdrawalAwaitingApproval,
+		},
+		{
+			name: "destination not allowed",
+			req:  &WithdrawalRequest{Token: eth, To: bob, Amount: ether(1), RequestedBy: "ops"},
+			err:  "destination " + bob.Hex() + " is not allowed",
+		},
+		{
+			name: "token not in the policy",
+			req:  &WithdrawalRequest{Token: usdt, To: alice, Amount: ether(1), RequestedBy: "ops"},
+			err:  "is not in the policy",
+		},
+		{
+			name: "over the daily limit",
+			req:  &WithdrawalRequest{Token: eth, To: alice, Amount: ether(11), RequestedBy: "ops"},
+			err:  "over the daily limit",
+		},
+		{
+			name: "zero amount",
+			req:  &WithdrawalRequest{Token: eth, To: alice, Amount: new(big.Int), RequestedBy: "ops"},
+			err:  "amount must be positive",
+		},
+		{
+			name: "no requester",
+			req:  &WithdrawalRequest{Token: eth, To: alice, Amount: ether(1)},
+			err:  "without a requester",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := NewMemoryWithdrawalStore()
+			w, err := RequestWithdrawal(context.Background(), &tm.TreasureManagerCaller, policy, store, tt.req)
+			if tt.err != "" {
+				require.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, tt.status, w.Status)
+			stored, err := store.Get(w.ID)
+			require.Nil(t, err)
+			require.Equal(t, tt.status, stored.Status)
+		})
+	}
+}
+
+func TestApproveWithdrawal(t *testing.T) {
+	store := NewMemoryWithdrawalStore()
+	w := &Withdrawal{ID: "w1", Amount: ether(5), RequestedBy: "ops", Status: WithdrawalAwaitingApproval}
+	require.Nil(t, store.Put(w))
+
+	_, err := ApproveWithdrawal(store, "w1", "ops")
+	require.ErrorContains(t, err, "someone other than its requester")
+	_, err = ApproveWithdrawal(store, "w1", "")
+	require.ErrorContains(t, err, "someone other than its requester")
+
+	w, err = ApproveWithdrawal(store, "w1", "treasurer")
+	require.Nil(t, err)
+	require.Equal(t, WithdrawalApproved, w.Status)
+	require.Equal(t, "treasurer", w.ApprovedBy)
+	_, err = ApproveWithdrawal(store, "w1", "auditor")
+	require.ErrorContains(t, err, "is approved, not awaiting-approval")
+
+	w, err = RejectWithdrawal(store, "w1", "auditor", "wrong amount")
+	require.Nil(t, err)
+	require.Equal(t, WithdrawalRejected, w.Status)
+	_, err = RejectWithdrawal(store, "w1", "auditor", "wrong amount")
+	require.ErrorContains(t, err, "can no longer be rejected")
+}
+
+// withdrawalCaller deploys a treasure of 10 ETH and returns a caller
+// managing it with cfg, and a store holding approved withdrawals of amounts
+// to alice, with IDs a, b, c and so on.
+func withdrawalCaller(t *testing.T, cfg ContractCallerConfig, amounts ...int64) (*testChain, *bindings.TreasureManager, *ContractCaller, WithdrawalStore) {
+	key, owner := newTestKey(t)
+	chain := newTestChain(t, owner)
+	tmAddr, tm := chain.DeployTreasureManager(key, owner)
+	c := chain.NewCaller(key, tmAddr, cfg)
+	eth, err := tm.EthAddress(nil)
+	require.Nil(t, err)
+	store := NewMemoryWithdrawalStore()
+	for i, amount := range amounts {
+		require.Nil(t, store.Put(&Withdrawal{
+			ID:          string(rune('a' + i)),
+			Token:       eth,
+			To:          alice,
+			Amount:      ether(amount),
+			RequestedBy: "ops",
+			ApprovedBy:  "treasurer",
+			Status:      WithdrawalApproved,
+		}))
+	}
+	return chain, tm, c, store
+}
+
+func TestExecuteWithdrawal(t *testing.T) {
+	tests := []struct {
+		name    string
+		amounts []int64
+		// approvedBy overrides the approval of the last withdrawal
+		approvedBy *string
+		executed   int
+		err        string
+	}{
+		{
+			name:     "executed",
+			amounts:  []int64{4},
+			executed: 1,
+		},
+		{
+			name:       "approval missing",
+			amounts:    []int64{4},
+			approvedBy: new(string),
+			err:        "needs a second approval",
+		},
+		{
+			name:     "daily limit",
+			amounts:  []int64{4, 4, 4},
+			executed: 2,
+			err:      "after 8000000000000000000 in the last 24h is over the daily limit",
+		},
+		{
+			name:     "min remaining",
+			amounts:  []int64{2, 2, 2, 2, 2},
+			executed: 4,
+			err:      "would leave less than 1000000000000000000",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, tm, c, store := withdrawalCaller(t, ContractCallerConfig{}, tt.amounts...)
+			last := string(rune('a' + len(tt.amounts) - 1))
+			if tt.approvedBy != nil {
+				w, err := store.Get(last)
+				require.Nil(t, err)
+				w.ApprovedBy = *tt.approvedBy
+				require.Nil(t, store.Put(w))
+			}
+			policy := testPolicy(t)
+			for i := range tt.amounts {
+				id := string(rune('a' + i))
+				w, err := c.ExecuteWithdrawal(context.Background(), policy, store, id)
+				if id == last && tt.err != "" {
+					require.ErrorIs(t, err, ErrWithdrawalPolicy)
+					require.ErrorContains(t, err, tt.err)
+					require.Equal(t, WithdrawalApproved, w.Status)
+					continue
+				}
+				require.Nil(t, err)
+				require.Equal(t, WithdrawalExecuted, w.Status)
+
+				_, err = c.ExecuteWithdrawal(context.Background(), policy, store, id)
+				require.ErrorContains(t, err, "is already executed")
+			}
+			require.Equal(t, tt.executed, withdrawCount(t, tm))
+		})
+	}
+}
+
+func TestExecuteWithdrawalConcurrently(t *testing.T) {
+	// only two of them fit in the daily limit
+	_, tm, c, store := withdrawalCaller(t, ContractCallerConfig{}, 4, 4, 4)
+	policy := testPolicy(t)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 6)
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+			_, err := c.ExecuteWithdrawal(context.Background(), policy, store, id)
+			errs <- err
+		}(string(rune('a' + i%3)))
+	}
+	wg.Wait()
+	close(errs)
+	failed := 0
+	for err := range errs {
+		if err != nil {
+			failed++
+		}
+	}
+	require.Equal(t, 4, failed)
+	require.Equal(t, 2, withdrawCount(t, tm))
+}
+
+func TestResumeWithdrawal(t *testing.T) {
+	tests := []struct {
+		name string
+		// interrupt is given the withdrawal left pending as by a crash, and
+		// decides what becomes of its transaction before the rerun
+		interrupt func(chain *testChain, sent *SentTx)
+		inFlight  bool
+	}{
+		{
+			name:      "in flight",
+			interrupt: func(chain *testChain, sent *SentTx) {},
+			inFlight:  true,
+		},
+		{
+			name:      "mined meanwhile",
+			interrupt: func(chain *testChain, sent *SentTx) { chain.Resume() },
+		},
+		{
+			name: "dropped",
+			interrupt: func(chain *testChain, sent *SentTx) {
+				chain.Drop(sent.TxHashes[0])
+				chain.Resume()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chain, tm, c, store := withdrawalCaller(t, ContractCallerConfig{}, 4)
+			policy := testPolicy(t)
+
+			chain.Pause()
+			ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+			_, err := c.ExecuteWithdrawal(ctx, policy, store, "a")
+			cancel()
+			require.ErrorIs(t, err, context.DeadlineExceeded)
+			w, err := store.Get("a")
+			require.Nil(t, err)
+			require.Equal(t, WithdrawalPending, w.Status)
+			require.Len(t, w.Sent.TxHashes, 1)
+			tt.interrupt(chain, w.Sent)
+
+			w, err = c.ExecuteWithdrawal(context.Background(), policy, store, "a")
+			if tt.inFlight {
+				require.ErrorIs(t, err, ErrTxInFlight)
+				require.Equal(t, WithdrawalPending, w.Status)
+				require.Equal(t, 0, withdrawCount(t, tm))
+				chain.Resume()
+				w, err = c.ExecuteWithdrawal(context.Background(), policy, store, "a")
+			}
+			require.Nil(t, err)
+			require.Equal(t, WithdrawalExecuted, w.Status)
+			require.Equal(t, 1, withdrawCount(t, tm))
+			receipt, err := chain.client.TransactionReceipt(context.Background(), w.TxHash)
+			require.Nil(t, err)
+			require.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+		})
+	}
+}
@@ -29,6 +29,24 @@ func main() {
 	app.Usage = "Contracts caller template project"
 	app.Description = "Contracts caller template service, every one can develop self contracts caller base this project"
 	app.Action = contracts_caller.Main(GitVersion)
+	app.Commands = append([]cli.Command{
+		{
+			Name:      "kms-address",
+			Usage:     "Print the address of a Google Cloud KMS key version, using --hsm-creden if set",
+			ArgsUsage: "[projects/.../cryptoKeyVersions/N]",
+			Action:    contracts_caller.KMSAddress,
+		},
+		contracts_caller.TreasureManagerCommand(),
+		contracts_caller.QueryCommand(),
+		contracts_caller.WhitelistCommand(),
+		contracts_caller.RolesCommand(),
+		contracts_caller.EventsCommand(),
+		contracts_caller.LedgerCommand(),
+		contracts_caller.RewardsCommand(),
+		contracts_caller.WithdrawCommand(),
+		contracts_caller.ProposalsCommand(),
+		contracts_caller.SafeCommand(),
+	}, contracts_caller.ContractCommands()...)
 	err := app.Run(os.Args)
 	if err != nil {
 		log.Crit("Contracts Caller Application failed", "message", err)
@@ -0,0 +1,50 @@
+package challenger
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	kms "cloud.google.com/go/kms/apiv1"
+	"github.com/urfave/cli"
+	"google.golang.org/api/option"
+
+	"github.com/the-web3/contracts-caller/flags"
+	"github.com/the-web3/contracts-caller/hsm"
+)
+
+// KMSAddress prints the Ethereum address derived from the public key of a
+// Google Cloud KMS key version, the value --hsm-address has to be set to.
+// The key version is the first argument or --hsm-api-name.
+func KMSAddress(cliCtx *cli.Context) error {
+	keyName := cliCtx.Args().First()
+	if keyName == "" {
+		keyName = cliCtx.GlobalString(flags.HsmAPINameFlag.Name)
+	}
+	if keyName == "" {
+		return errors.New("kms-address: no key version given")
+	}
+
+	var opts []option.ClientOption
+	if creden := cliCtx.GlobalString(flags.HsmCredenFlag.Name); creden != "" {
+		credentials, err := hex.DecodeString(creden)
+		if err != nil {
+			return err
+		}
+		opts = append(opts, option.WithCredentialsJSON(credentials))
+	}
+	ctx := context.Background()
+	client, err := kms.NewKeyManagementClient(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	addr, err := hsm.GoogleKMSKeyAddress(ctx, client, keyName)
+	if err != nil {
+		return err
+	}
+	fmt.Println(addr.Hex())
+	return nil
+}
@@ -0,0 +1,243 @@
+package common
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// LoadABI reads either a bare ABI JSON array or a compiler artifact with
+// the ABI under "abi", like abi/TreasureManager.sol/TreasureManager.json.
+func LoadABI(path string) (*abi.ABI, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '{' {
+		var artifact struct {
+			ABI json.RawMessage `json:"abi"`
+		}
+		if err := json.Unmarshal(data, &artifact); err != nil {
+			return nil, fmt.Errorf("parse artifact %s: %w", path, err)
+		}
+		if len(artifact.ABI) == 0 {
+			return nil, fmt.Errorf("artifact %s has no abi", path)
+		}
+		data = artifact.ABI
+	}
+	parsed, err := abi.JSON(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("parse abi %s: %w", path, err)
+	}
+	return &parsed, nil
+}
+
+// FindMethod looks a method up by name, by overload name (transfer0) or by
+// signature (transfer(address,uint256)).
+func FindMethod(contractABI *abi.ABI, name string) (*abi.Method, error) {
+	if method, ok := contractABI.Methods[name]; ok {
+		return &method, nil
+	}
+	for _, method := range contractABI.Methods {
+		if method.Sig == strings.ReplaceAll(name, " ", "") {
+			return &method, nil
+		}
+	}
+	return nil, fmt.Errorf("method %s not found in abi", name)
+}
+
+// ParseABIArgs converts command line strings into the Go values abi.Pack
+// expects for args. Addresses, integers (decimal or 0x), bools, strings,
+// bytes and bytesN (0x hex) are written as is; arrays and tuples as JSON,
+// e.g. ["0xab..","0xcd.."] or [1,"0xab.."] or {"amount":1,"to":"0xab.."}.
+func ParseABIArgs(args abi.Arguments, values []string) ([]interface{}, error) {
+	if len(values) != len(args) {
+		return nil, fmt.Errorf("want %d arguments, got %d", len(args), len(values))
+	}
+	parsed := make([]interface{}, len(args))
+	for i, arg := range args {
+		v, err := parseABIValue(arg.Type, values[i])
+		if err != nil {
+			name := arg.Name
+			if name == "" {
+				name = fmt.Sprintf("#%d", i+1)
+			}
+			return nil, fmt.Errorf("argument %s (%s): %w", name, arg.Type, err)
+		}
+		parsed[i] = v.Interface()
+	}
+	return parsed, nil
+}
+
+func parseABIValue(t abi.Type, s string) (reflect.Value, error) {
+	switch t.T {
+	case abi.AddressTy:
+		addr, err := ParseAddress(s)
+		return reflect.ValueOf(addr), err
+
+	case abi.BoolTy:
+		switch s {
+		case "true":
+			return reflect.ValueOf(true), nil
+		case "false":
+			return reflect.ValueOf(false), nil
+		}
+		return reflect.Value{}, fmt.Errorf("invalid bool: %v", s)
+
+	case abi.StringTy:
+		return reflect.ValueOf(s), nil
+
+	case abi.IntTy, abi.UintTy:
+		n, ok := new(big.Int).SetString(s, 0)
+		if !ok {
+			return reflect.Value{}, fmt.Errorf("invalid integer: %v", s)
+		}
+		if t.T == abi.UintTy && n.Sign() < 0 {
+			return reflect.Value{}, fmt.Errorf("negative unsigned integer: %v", s)
+		}
+		bits := n.BitLen()
+		if t.T == abi.IntTy {
+			bits++ // sign
+		}
+		if bits > t.Size {
+			return reflect.Value{}, fmt.Errorf("integer %v overflows %d bits", s, t.Size)
+		}
+		goType := t.GetType()
+		if goType.Kind() == reflect.Ptr { // *big.Int
+			return reflect.ValueOf(n), nil
+		}
+		if t.T == abi.IntTy {
+			return reflect.ValueOf(n.Int64()).Convert(goType), nil
+		}
+		return reflect.ValueOf(n.Uint64()).Convert(goType), nil
+
+	case abi.BytesTy:
+		raw, err := hexutil.Decode(s)
+		return reflect.ValueOf(raw), err
+
+	case abi.FixedBytesTy:
+		raw, err := hexutil.Decode(s)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		if len(raw) != t.Size {
+			return reflect.Value{}, fmt.Errorf("want %d bytes, got %d", t.Size, len(raw))
+		}
+		v := reflect.New(t.GetType()).Elem()
+		reflect.Copy(v, reflect.ValueOf(raw))
+		return v, nil
+
+	case abi.SliceTy, abi.ArrayTy:
+		var elems []json.RawMessage
+		if err := json.Unmarshal([]byte(s), &elems); err != nil {
+			return reflect.Value{}, fmt.Errorf("want a JSON array: %w", err)
+		}
+		if t.T == abi.ArrayTy && len(elems) != t.Size {
+			return reflect.Value{}, fmt.Errorf("want %d elements, got %d", t.Size, len(elems))
+		}
+		var v reflect.Value
+		if t.T == abi.SliceTy {
+			v = reflect.MakeSlice(t.GetType(), len(elems), len(elems))
+		} else {
+			v = reflect.New(t.GetType()).Elem()
+		}
+		for i, elem := range elems {
+			ev, err := parseABIValue(*t.Elem, jsonScalar(elem))
+			if err != nil {
+				return reflect.Value{}, fmt.Errorf("element %d: %w", i, err)
+			}
+			v.Index(i).Set(ev)
+		}
+		return v, nil
+
+	case abi.TupleTy:
+		fields := make([]json.RawMessage, len(t.TupleElems))
+		trimmed := strings.TrimSpace(s)
+		if strings.HasPrefix(trimmed, "{") {
+			var byName map[string]json.RawMessage
+			if err := json.Unmarshal([]byte(trimmed), &byName); err != nil {
+				return reflect.Value{}, fmt.Errorf("want a JSON object or array: %w", err)
+			}
+			for i, name := range t.TupleRawNames {
+				field, ok := byName[name]
+				if !ok {
+					return reflect.Value{}, fmt.Errorf("missing tuple field %s", name)
+				}
+				fields[i] = field
+			}
+		} else {
+			var byIndex []json.RawMessage
+			if err := json.Unmarshal([]byte(trimmed), &byIndex); err != nil {
+				return reflect.Value{}, fmt.Errorf("want a JSON object or array: %w", err)
+			}
+			if len(byIndex) != len(fields) {
+				return reflect.Value{}, fmt.Errorf("want %d tuple fields, got %d", len(fields), len(byIndex))
+			}
+			copy(fields, byIndex)
+		}
+		v := reflect.New(t.GetType()).Elem()
+		for i, elem := range t.TupleElems {
+			fv, err := parseABIValue(*elem, jsonScalar(fields[i]))
+			if err != nil {
+				return reflect.Value{}, fmt.Errorf("field %s: %w", t.TupleRawNames[i], err)
+			}
+			v.Field(i).Set(fv)
+		}
+		return v, nil
+
+	default:
+		return reflect.Value{}, fmt.Errorf("unsupported type %s", t)
+	}
+}
+
+// jsonScalar unquotes JSON strings and keeps numbers, bools, arrays and
+// objects as written, which is how they are parsed at the top level.
+func jsonScalar(raw json.RawMessage) string {
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+	return strings.TrimSpace(string(raw))
+}
+
+// ABIValueToJSON converts a value unpacked for t into plain JSON types:
+// integers as decimal strings, addresses and bytes as hex, tuples as
+// objects keyed by field name.
+func ABIValueToJSON(t abi.Type, v interface{}) interface{} {
+	rv := reflect.ValueOf(v)
+	switch t.T {
+	case abi.SliceTy, abi.ArrayTy:
+		out := make([]interface{}, rv.Len())
+		for i := range out {
+			out[i] = ABIValueToJSON(*t.Elem, rv.Index(i).Interface())
+		}
+		return out
+	case abi.TupleTy:
+		out := make(map[string]interface{}, len(t.TupleElems))
+		for i, elem := range t.TupleElems {
+			out[t.TupleRawNames[i]] = ABIValueToJSON(*elem, rv.Field(i).Interface())
+		}
+		return out
+	case abi.FixedBytesTy:
+		raw := make([]byte, rv.Len())
+		reflect.Copy(reflect.ValueOf(raw), rv)
+		return hexutil.Encode(raw)
+	case abi.IntTy, abi.UintTy:
+		return fmt.Sprint(v)
+	case abi.AddressTy:
+		return v.(common.Address).Hex()
+	case abi.BytesTy:
+		return hexutil.Encode(v.([]byte))
+	default:
+		return v
+	}
+}
@@ -0,0 +1,74 @@
+package common_test
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+
+	common2 "github.com/the-web3/contracts-caller/common"
+)
+
+const argsABI = `[{"type":"function","name":"f","stateMutability":"nonpayable","inputs":[
+	{"name":"to","type":"address"},
+	{"name":"amount","type":"uint256"},
+	{"name":"small","type":"uint8"},
+	{"name":"delta","type":"int64"},
+	{"name":"role","type":"bytes32"},
+	{"name":"data","type":"bytes"},
+	{"name":"ok","type":"bool"},
+	{"name":"tokens","type":"address[]"},
+	{"name":"pair","type":"uint256[2]"},
+	{"name":"order","type":"tuple","components":[
+		{"name":"maker","type":"address"},
+		{"name":"amounts","type":"uint256[]"}
+	]}
+],"outputs":[]}]`
+
+func TestParseABIArgs(t *testing.T) {
+	parsed, err := abi.JSON(strings.NewReader(argsABI))
+	require.Nil(t, err)
+	method, err := common2.FindMethod(&parsed, "f(address,uint256,uint8,int64,bytes32,bytes,bool,address[],uint256[2],(address,uint256[]))")
+	require.Nil(t, err)
+
+	addr := "0x0B306BF915C4d645ff596e518fAf3F9669b97016"
+	role := "0x" + strings.Repeat("ab", 32)
+	args, err := common2.ParseABIArgs(method.Inputs, []string{
+		addr, "1000000000000000000", "0xff", "-5", role, "0x0102", "true",
+		`["` + addr + `","` + addr + `"]`,
+		`[1, "2"]`,
+		`{"maker":"` + addr + `","amounts":[3,4]}`,
+	})
+	require.Nil(t, err)
+	require.Equal(t, common.HexToAddress(addr), args[0])
+	require.Equal(t, big.NewInt(1e18), args[1])
+	require.Equal(t, uint8(255), args[2])
+	require.Equal(t, int64(-5), args[3])
+	require.Equal(t, [32]byte(common.HexToHash(role)), args[4])
+
+	data, err := parsed.Pack("f", args...)
+	require.Nil(t, err)
+	decoded, err := method.Inputs.Unpack(data[4:])
+	require.Nil(t, err)
+	require.Equal(t, "4", common2.ABIValueToJSON(method.Inputs[9].Type, decoded[9]).(map[string]interface{})["amounts"].([]interface{})[1])
+
+	_, err = common2.ParseABIArgs(method.Inputs[2:3], []string{"256"})
+	require.NotNil(t, err)
+	_, err = common2.ParseABIArgs(method.Inputs[4:5], []string{"0x01"})
+	require.NotNil(t, err)
+	_, err = common2.ParseABIArgs(method.Inputs[8:9], []string{"[1]"})
+	require.NotNil(t, err)
+	_, err = common2.ParseABIArgs(method.Inputs, nil)
+	require.NotNil(t, err)
+}
+
+func TestLoadABIArtifact(t *testing.T) {
+	parsed, err := common2.LoadABI("../abi/TreasureManager.sol/TreasureManager.json")
+	require.Nil(t, err)
+	_, err = common2.FindMethod(parsed, "grantRewards")
+	require.Nil(t, err)
+}
@@ -1,31 +1,27 @@
 package common
 
 import (
-	"context"
 	"crypto/ecdsa"
-	"encoding/hex"
 	"errors"
 	"fmt"
 	"math/big"
+	"os"
 	"strings"
 
-	kms "cloud.google.com/go/kms/apiv1"
 	"github.com/decred/dcrd/hdkeychain/v3"
 	"github.com/tyler-smith/go-bip39"
-	"google.golang.org/api/option"
 
 	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
-
-	hsm "github.com/the-web3/contracts-caller/hsm"
 )
 
 var (
-	ErrCannotGetPrivateKey = errors.New("invalid combination of private key or mnemonic + hdpath")
+	ErrCannotGetPrivateKey = errors.New("invalid combination of private key, mnemonic + hdpath or keystore")
 )
 
 func ParseAddress(address string) (common.Address, error) {
@@ -35,16 +31,20 @@ func ParseAddress(address string) (common.Address, error) {
 	return common.Address{}, fmt.Errorf("invalid address: %v", address)
 }
 
-func GetConfiguredPrivateKey(mnemonic, hdPath, privKeyStr, password string) (*ecdsa.PrivateKey, error) {
+func GetConfiguredPrivateKey(mnemonic, hdPath, privKeyStr, password, keystorePath, keystorePasswordFile string) (*ecdsa.PrivateKey, error) {
 
 	useMnemonic := mnemonic != "" && hdPath != ""
 	usePrivKeyStr := privKeyStr != ""
+	useKeystore := keystorePath != ""
 
 	switch {
-	case useMnemonic && !usePrivKeyStr:
+	case useKeystore && !useMnemonic && !usePrivKeyStr:
+		return LoadKeystoreKey(keystorePath, keystorePasswordFile)
+
+	case useMnemonic && !usePrivKeyStr && !useKeystore:
 		return DerivePrivateKey(mnemonic, hdPath, password)
 
-	case usePrivKeyStr && !useMnemonic:
+	case usePrivKeyStr && !useMnemonic && !useKeystore:
 		return ParsePrivateKeyStr(privKeyStr)
 
 	default:
@@ -52,6 +52,29 @@ func GetConfiguredPrivateKey(mnemonic, hdPath, privKeyStr, password string) (*ec
 	}
 }
 
+// LoadKeystoreKey decrypts a go-ethereum (JSON v3) keystore file. The
+// password is the first line of passwordFile, so the password itself never
+// shows up in the process arguments or environment.
+func LoadKeystoreKey(keystorePath, passwordFile string) (*ecdsa.PrivateKey, error) {
+	keyJSON, err := os.ReadFile(keystorePath)
+	if err != nil {
+		return nil, fmt.Errorf("read keystore: %w", err)
+	}
+	var password string
+	if passwordFile != "" {
+		raw, err := os.ReadFile(passwordFile)
+		if err != nil {
+			return nil, fmt.Errorf("read keystore password: %w", err)
+		}
+		password = strings.TrimRight(strings.SplitN(string(raw), "\n", 2)[0], "\r")
+	}
+	key, err := keystore.DecryptKey(keyJSON, password)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt keystore %s: %w", keystorePath, err)
+	}
+	return key.PrivateKey, nil
+}
+
 type fakeNetworkParams struct{}
 
 func (f fakeNetworkParams) HDPrivKeyVersion() [4]byte {
@@ -100,7 +123,7 @@ func ParsePrivateKeyStr(privKeyStr string) (*ecdsa.PrivateKey, error) {
 
 func ParseWalletPrivKeyAndContractAddr(name string, mnemonic string, hdPath string, privKeyStr string, contractAddrStr string, password string) (*ecdsa.PrivateKey, common.Address, error) {
 
-	privKey, err := GetConfiguredPrivateKey(mnemonic, hdPath, privKeyStr, password)
+	privKey, err := GetConfiguredPrivateKey(mnemonic, hdPath, privKeyStr, password, "", "")
 	if err != nil {
 		return nil, common.Address{}, err
 	}
@@ -132,19 +155,3 @@ func PrivateKeySignerFn(key *ecdsa.PrivateKey, chainID *big.Int) bind.SignerFn {
 		return tx.WithSignature(signer, signature)
 	}
 }
-
-func NewHSMTransactOpts(ctx context.Context, hsmAPIName string, hsmAddress string, chainID *big.Int, hsmCreden string) (*bind.TransactOpts, error) {
-	proBytes, err := hex.DecodeString(hsmCreden)
-	apikey := option.WithCredentialsJSON(proBytes)
-	client, err := kms.NewKeyManagementClient(ctx, apikey)
-	if err != nil {
-		return nil, err
-	}
-	mk := &hsm.ManagedKey{
-		KeyName:      hsmAPIName,
-		EthereumAddr: common.HexToAddress(hsmAddress),
-		Gclient:      client,
-	}
-	opts, err := mk.NewEthereumTransactorrWithChainID(ctx, chainID)
-	return opts, nil
-}
@@ -0,0 +1,86 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+var ErrNoRevertData = errors.New("no revert data")
+
+// DecodeRevert turns revert data into a readable reason. Error(string) and
+// Panic(uint256) are tried first, then the custom errors of the given ABIs,
+// e.g. "AccessControlUnauthorizedAccount(account: 0x.., neededRole: 0x..)".
+func DecodeRevert(data []byte, abis ...*abi.ABI) (string, error) {
+	if len(data) == 0 {
+		return "", ErrNoRevertData
+	}
+	if reason, err := abi.UnpackRevert(data); err == nil {
+		return reason, nil
+	}
+	if len(data) < 4 {
+		return "", fmt.Errorf("revert data too short: %s", hexutil.Encode(data))
+	}
+
+	var selector [4]byte
+	copy(selector[:], data[:4])
+	for _, parsed := range abis {
+		if parsed == nil {
+			continue
+		}
+		abiErr, err := parsed.ErrorByID(selector)
+		if err != nil {
+			continue
+		}
+		unpacked, err := abiErr.Unpack(data)
+		if err != nil {
+			return "", fmt.Errorf("unpack %s: %w", abiErr.Name, err)
+		}
+		values, _ := unpacked.([]interface{})
+		args := make([]string, 0, len(abiErr.Inputs))
+		for i, input := range abiErr.Inputs {
+			if i >= len(values) {
+				break
+			}
+			arg := FormatABIValue(values[i])
+			if input.Name != "" {
+				arg = input.Name + ": " + arg
+			}
+			args = append(args, arg)
+		}
+		return fmt.Sprintf("%s(%s)", abiErr.Name, strings.Join(args, ", ")), nil
+	}
+	return "", fmt.Errorf("unknown revert selector %s", hexutil.Encode(selector[:]))
+}
+
+// FormatABIValue renders a value unpacked by the abi package the way it is
+// usually written in Solidity tooling.
+func FormatABIValue(v interface{}) string {
+	switch v := v.(type) {
+	case common.Address:
+		return v.Hex()
+	case common.Hash:
+		return v.Hex()
+	case [32]byte:
+		return hexutil.Encode(v[:])
+	case [4]byte:
+		return hexutil.Encode(v[:])
+	case []byte:
+		return hexutil.Encode(v)
+	case *big.Int:
+		return v.String()
+	case []common.Address:
+		parts := make([]string, len(v))
+		for i, addr := range v {
+			parts[i] = addr.Hex()
+		}
+		return "[" + strings.Join(parts, ",") + "]"
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
@@ -0,0 +1,50 @@
+package common_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/the-web3/contracts-caller/bindings"
+	common2 "github.com/the-web3/contracts-caller/common"
+)
+
+func TestDecodeRevertErrorString(t *testing.T) {
+	typ, err := abi.NewType("string", "", nil)
+	require.Nil(t, err)
+	packed, err := abi.Arguments{{Type: typ}}.Pack("insufficient balance")
+	require.Nil(t, err)
+	data := append(crypto.Keccak256([]byte("Error(string)"))[:4], packed...)
+
+	reason, err := common2.DecodeRevert(data)
+	require.Nil(t, err)
+	require.Equal(t, "insufficient balance", reason)
+}
+
+func TestDecodeRevertCustomError(t *testing.T) {
+	parsed, err := bindings.TreasureManagerMetaData.GetAbi()
+	require.Nil(t, err)
+
+	account := common.HexToAddress("0xa0Ee7A142d267C1f36714E4a8F75612F20a79720")
+	role := crypto.Keccak256Hash([]byte("WITHDRAW_ROLE"))
+	abiErr := parsed.Errors["AccessControlUnauthorizedAccount"]
+	packed, err := abiErr.Inputs.Pack(account, role)
+	require.Nil(t, err)
+	data := append(abiErr.ID.Bytes()[:4], packed...)
+
+	reason, err := common2.DecodeRevert(data, parsed)
+	require.Nil(t, err)
+	require.Equal(t, "AccessControlUnauthorizedAccount(account: "+account.Hex()+", neededRole: "+role.Hex()+")", reason)
+}
+
+func TestDecodeRevertUnknown(t *testing.T) {
+	_, err := common2.DecodeRevert(nil)
+	require.Equal(t, common2.ErrNoRevertData, err)
+
+	_, err = common2.DecodeRevert([]byte{1, 2, 3, 4})
+	require.NotNil(t, err)
+}
@@ -0,0 +1,32 @@
+package common
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const DefaultAdminRole = "DEFAULT_ADMIN_ROLE"
+
+// ParseRole accepts an AccessControl role as 32-byte hex, or as its name,
+// hashed like the contract does with keccak256("NAME"). DEFAULT_ADMIN_ROLE
+// is the zero hash.
+func ParseRole(role string) ([32]byte, error) {
+	switch {
+	case strings.HasPrefix(role, "0x"):
+		raw, err := hexutil.Decode(role)
+		if err != nil || len(raw) != 32 {
+			return [32]byte{}, fmt.Errorf("invalid role: %v", role)
+		}
+		return common.BytesToHash(raw), nil
+	case role == "":
+		return [32]byte{}, fmt.Errorf("invalid role: %v", role)
+	case role == DefaultAdminRole:
+		return [32]byte{}, nil
+	default:
+		return crypto.Keccak256Hash([]byte(role)), nil
+	}
+}
@@ -0,0 +1,31 @@
+package common_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/crypto"
+
+	common2 "github.com/the-web3/contracts-caller/common"
+)
+
+func TestParseRole(t *testing.T) {
+	role, err := common2.ParseRole(common2.DefaultAdminRole)
+	require.Nil(t, err)
+	require.Equal(t, [32]byte{}, role)
+
+	role, err = common2.ParseRole("WITHDRAW_ROLE")
+	require.Nil(t, err)
+	require.Equal(t, [32]byte(crypto.Keccak256Hash([]byte("WITHDRAW_ROLE"))), role)
+
+	hash := crypto.Keccak256Hash([]byte("x"))
+	role, err = common2.ParseRole(hash.Hex())
+	require.Nil(t, err)
+	require.Equal(t, [32]byte(hash), role)
+
+	_, err = common2.ParseRole("0x1234")
+	require.NotNil(t, err)
+	_, err = common2.ParseRole("")
+	require.NotNil(t, err)
+}
@@ -11,6 +11,8 @@ import (
 type Config struct {
 	ChainRpcUrl                    string
 	ChainId                        uint64
+	Keystore                       string
+	KeystorePasswordFile           string
 	PrivateKey                     string
 	Mnemonic                       string
 	SequencerHDPath                string
@@ -21,20 +23,81 @@ type Config struct {
 	ResubmissionTimeout            time.Duration
 	NumConfirmations               uint64
 	SafeAbortNonceTooLowCount      uint64
+	AllowPlaintextKey              bool
 
 	EnableHsm  bool
 	HsmAPIName string
 	HsmCreden  string
 	HsmAddress string
+
+	AWSKMSKeyID    string
+	AWSKMSRegion   string
+	AWSKMSEndpoint string
+
+	VaultAddr    string
+	VaultToken   string
+	VaultMount   string
+	VaultKeyName string
+
+	PKCS11Module     string
+	PKCS11TokenLabel string
+	PKCS11Pin        string
+	PKCS11KeyLabel   string
+
+	JournalPath string
+
+	FeePolicy            string
+	MaxGasTipCap         uint64
+	MaxGasFeeCap         uint64
+	FeeBumpStep          uint64
+	FeeBumpFactor        float64
+	FeeHistoryPercentile float64
+
+	ForceSend bool
+
+	JobsFile string
+
+	ObserveOnly    bool
+	TokenWhitelist string
+
+	IndexerDB            string
+	IndexerStartBlock    uint64
+	IndexerChunkSize     uint64
+	IndexerConfirmations uint64
+	LedgerCheckInterval  time.Duration
+
+	RewardsDB string
+
+	WithdrawalsDB    string
+	WithdrawalPolicy string
+
+	ProposalsDB       string
+	Approvers         []string
+	ApprovalThreshold int
+	ProposalTTL       time.Duration
+
+	SafeAddress string
+	SafeDB      string
+
+	AdminListen  string
+	AdminTokens  string
+	OperationsDB string
+
+	WatchEvents    bool
+	WatchFromBlock uint64
 }
 
 func NewConfig(ctx *cli.Context) (Config, error) {
 	cfg := Config{
 		ChainRpcUrl:                    ctx.GlobalString(flags.ChainRpcUrlFlag.Name),
 		ChainId:                        ctx.GlobalUint64(flags.ChainIdFlag.Name),
+		Keystore:                       ctx.GlobalString(flags.KeystoreFlag.Name),
+		KeystorePasswordFile:           ctx.GlobalString(flags.KeystorePasswordFileFlag.Name),
 		PrivateKey:                     ctx.GlobalString(flags.PrivateKeyFlag.Name),
 		Mnemonic:                       ctx.GlobalString(flags.MnemonicFlag.Name),
+		SequencerHDPath:                ctx.GlobalString(flags.CallerHDPathFlag.Name),
 		Passphrase:                     ctx.GlobalString(flags.PassphraseFlag.Name),
+		AllowPlaintextKey:              ctx.GlobalBool(flags.AllowPlaintextKeyFlag.Name),
 		TreasureManagerContractAddress: ctx.GlobalString(flags.TreasureManagerContractAddressFlag.Name),
 		WithdrawManagerAddress:         ctx.GlobalString(flags.WithdrawManagerAddressFlag.Name),
 		NumConfirmations:               ctx.GlobalUint64(flags.NumConfirmationsFlag.Name),
@@ -44,6 +107,47 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 		HsmAddress:                     ctx.GlobalString(flags.HsmAddressFlag.Name),
 		HsmAPIName:                     ctx.GlobalString(flags.HsmAPINameFlag.Name),
 		HsmCreden:                      ctx.GlobalString(flags.HsmCredenFlag.Name),
+		AWSKMSKeyID:                    ctx.GlobalString(flags.AWSKMSKeyIDFlag.Name),
+		AWSKMSRegion:                   ctx.GlobalString(flags.AWSKMSRegionFlag.Name),
+		AWSKMSEndpoint:                 ctx.GlobalString(flags.AWSKMSEndpointFlag.Name),
+		VaultAddr:                      ctx.GlobalString(flags.VaultAddrFlag.Name),
+		VaultToken:                     ctx.GlobalString(flags.VaultTokenFlag.Name),
+		VaultMount:                     ctx.GlobalString(flags.VaultMountFlag.Name),
+		VaultKeyName:                   ctx.GlobalString(flags.VaultKeyNameFlag.Name),
+		PKCS11Module:                   ctx.GlobalString(flags.PKCS11ModuleFlag.Name),
+		PKCS11TokenLabel:               ctx.GlobalString(flags.PKCS11TokenLabelFlag.Name),
+		PKCS11Pin:                      ctx.GlobalString(flags.PKCS11PinFlag.Name),
+		PKCS11KeyLabel:                 ctx.GlobalString(flags.PKCS11KeyLabelFlag.Name),
+		JournalPath:                    ctx.GlobalString(flags.JournalPathFlag.Name),
+		FeePolicy:                      ctx.GlobalString(flags.FeePolicyFlag.Name),
+		MaxGasTipCap:                   ctx.GlobalUint64(flags.MaxGasTipCapFlag.Name),
+		MaxGasFeeCap:                   ctx.GlobalUint64(flags.MaxGasFeeCapFlag.Name),
+		FeeBumpStep:                    ctx.GlobalUint64(flags.FeeBumpStepFlag.Name),
+		FeeBumpFactor:                  ctx.GlobalFloat64(flags.FeeBumpFactorFlag.Name),
+		FeeHistoryPercentile:           ctx.GlobalFloat64(flags.FeeHistoryPercentileFlag.Name),
+		ForceSend:                      ctx.GlobalBool(flags.ForceSendFlag.Name),
+		JobsFile:                       ctx.GlobalString(flags.JobsFileFlag.Name),
+		ObserveOnly:                    ctx.GlobalBool(flags.ObserveOnlyFlag.Name),
+		TokenWhitelist:                 ctx.GlobalString(flags.TokenWhitelistFlag.Name),
+		IndexerDB:                      ctx.GlobalString(flags.IndexerDBFlag.Name),
+		IndexerStartBlock:              ctx.GlobalUint64(flags.IndexerStartBlockFlag.Name),
+		IndexerChunkSize:               ctx.GlobalUint64(flags.IndexerChunkSizeFlag.Name),
+		IndexerConfirmations:           ctx.GlobalUint64(flags.IndexerConfirmationsFlag.Name),
+		LedgerCheckInterval:            ctx.GlobalDuration(flags.LedgerCheckIntervalFlag.Name),
+		RewardsDB:                      ctx.GlobalString(flags.RewardsDBFlag.Name),
+		WithdrawalsDB:                  ctx.GlobalString(flags.WithdrawalsDBFlag.Name),
+		WithdrawalPolicy:               ctx.GlobalString(flags.WithdrawalPolicyFlag.Name),
+		ProposalsDB:                    ctx.GlobalString(flags.ProposalsDBFlag.Name),
+		Approvers:                      ctx.GlobalStringSlice(flags.ApproversFlag.Name),
+		ApprovalThreshold:              ctx.GlobalInt(flags.ApprovalThresholdFlag.Name),
+		ProposalTTL:                    ctx.GlobalDuration(flags.ProposalTTLFlag.Name),
+		SafeAddress:                    ctx.GlobalString(flags.SafeAddressFlag.Name),
+		SafeDB:                         ctx.GlobalString(flags.SafeDBFlag.Name),
+		AdminListen:                    ctx.GlobalString(flags.AdminListenFlag.Name),
+		AdminTokens:                    ctx.GlobalString(flags.AdminTokensFlag.Name),
+		OperationsDB:                   ctx.GlobalString(flags.OperationsDBFlag.Name),
+		WatchEvents:                    ctx.GlobalBool(flags.WatchEventsFlag.Name),
+		WatchFromBlock:                 ctx.GlobalUint64(flags.WatchFromBlockFlag.Name),
 	}
 	return cfg, nil
 }
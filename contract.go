@@ -0,0 +1,199 @@
+package challenger
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/urfave/cli"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	ethc "github.com/ethereum/go-ethereum/common"
+
+	"github.com/the-web3/contracts-caller/caller"
+	common2 "github.com/the-web3/contracts-caller/common"
+	"github.com/the-web3/contracts-caller/ethereumcli"
+	"github.com/the-web3/contracts-caller/flags"
+	"github.com/the-web3/contracts-caller/txmgr"
+)
+
+const contractArgsUsage = "<abi-file> <contract> <method> [args...]"
+
+// ContractCommands are `call` and `send`, driven by an ABI file so any
+// contract can be used without generating bindings.
+func ContractCommands() []cli.Command {
+	return []cli.Command{
+		{
+			Name: "call",
+			Usage: "Call a view of any contract. Arrays and tuples are given as " +
+				"JSON, e.g. '[\"0xab..\",\"0xcd..\"]' or '{\"to\":\"0xab..\",\"amount\":1}'",
+			ArgsUsage: contractArgsUsage,
+			Flags:     []cli.Flag{flags.QueryBlockFlag, flags.QueryFromFlag},
+			Action:    runContractCall,
+		},
+		{
+			Name: "send",
+			Usage: "Send a transaction to any contract with the caller wallet, " +
+				"arguments as for call",
+			ArgsUsage: contractArgsUsage,
+			Flags:     []cli.Flag{flags.ValueFlag, flags.GasLimitFlag},
+			Action:    runContractSend,
+		},
+	}
+}
+
+type contractInvocation struct {
+	abi     *abi.ABI
+	address ethc.Address
+	method  *abi.Method
+	data    []byte
+}
+
+func parseContractInvocation(cliCtx *cli.Context) (*contractInvocation, error) {
+	args := cliCtx.Args()
+	if len(args) < 3 {
+		return nil, fmt.Errorf("usage: %s %s", cliCtx.Command.Name, contractArgsUsage)
+	}
+	contractABI, err := common2.LoadABI(args[0])
+	if err != nil {
+		return nil, err
+	}
+	address, err := common2.ParseAddress(args[1])
+	if err != nil {
+		return nil, err
+	}
+	method, err := common2.FindMethod(contractABI, args[2])
+	if err != nil {
+		return nil, err
+	}
+	values, err := common2.ParseABIArgs(method.Inputs, args[3:])
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", method.Sig, err)
+	}
+	data, err := contractABI.Pack(method.Name, values...)
+	if err != nil {
+		return nil, err
+	}
+	return &contractInvocation{abi: contractABI, address: address, method: method, data: data}, nil
+}
+
+// callError explains a call the contract reverted with the reason decoded
+// from the ABI file, and passes other errors through.
+func (inv *contractInvocation) callError(err error) error {
+	if reason, decodeErr := common2.DecodeRevert(txmgr.RevertData(err), inv.abi); decodeErr == nil {
+		return fmt.Errorf("%s reverted: %s", inv.method.Sig, reason)
+	}
+	return err
+}
+
+// decodeOutput unpacks what a call returned by output name, or by position
+// for unnamed outputs.
+func (inv *contractInvocation) decodeOutput(output []byte) (map[string]interface{}, error) {
+	values, err := inv.method.Outputs.Unpack(output)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]interface{}, len(values))
+	for i, out := range inv.method.Outputs {
+		name := out.Name
+		if name == "" {
+			name = strconv.Itoa(i)
+		}
+		result[name] = common2.ABIValueToJSON(out.Type, values[i])
+	}
+	return result, nil
+}
+
+// value parses the wei sent along, refusing it for a method that is not
+// payable.
+func (inv *contractInvocation) value(s string) (*big.Int, error) {
+	if s == "" {
+		return nil, nil
+	}
+	v, ok := new(big.Int).SetString(s, 0)
+	if !ok || v.Sign() < 0 {
+		return nil, fmt.Errorf("invalid value: %v", s)
+	}
+	if v.Sign() > 0 && !inv.method.IsPayable() {
+		return nil, fmt.Errorf("%s is not payable", inv.method.Sig)
+	}
+	return v, nil
+}
+
+func runContractCall(cliCtx *cli.Context) error {
+	inv, err := parseContractInvocation(cliCtx)
+	if err != nil {
+		return err
+	}
+	var from ethc.Address
+	if s := cliCtx.String(flags.QueryFromFlag.Name); s != "" {
+		if from, err = common2.ParseAddress(s); err != nil {
+			return err
+		}
+	}
+
+	cfg, err := NewConfig(cliCtx)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	chainClient, err := ethereumcli.EthClientWithTimeout(ctx, cfg.ChainRpcUrl)
+	if err != nil {
+		return err
+	}
+	defer chainClient.Close()
+	block, err := ResolveBlock(ctx, chainClient, cliCtx.String(flags.QueryBlockFlag.Name))
+	if err != nil {
+		return err
+	}
+
+	to := inv.address
+	output, err := chainClient.CallContract(ctx, ethereum.CallMsg{From: from, To: &to, Data: inv.data}, block)
+	if err != nil {
+		return inv.callError(err)
+	}
+	result, err := inv.decodeOutput(output)
+	if err != nil {
+		return err
+	}
+	return printJSON(struct {
+		Contract string                 `json:"contract"`
+		Method   string                 `json:"method"`
+		Block    uint64                 `json:"block"`
+		Result   map[string]interface{} `json:"result"`
+	}{inv.address.Hex(), inv.method.Sig, block.Uint64(), result})
+}
+
+func runContractSend(cliCtx *cli.Context) error {
+	inv, err := parseContractInvocation(cliCtx)
+	if err != nil {
+		return err
+	}
+	value, err := inv.value(cliCtx.String(flags.ValueFlag.Name))
+	if err != nil {
+		return err
+	}
+
+	cfg, err := NewConfig(cliCtx)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cCaller, closeCaller, err := newContractCaller(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer closeCaller()
+
+	receipt, err := cCaller.Transact(ctx, &caller.TxRequest{
+		To:       inv.address,
+		Data:     inv.data,
+		Value:    value,
+		GasLimit: cliCtx.Uint64(flags.GasLimitFlag.Name),
+		ABI:      inv.abi,
+	})
+	return printTxResult(inv.method.Sig, cCaller.WalletAddr, inv.address, receipt, err)
+}
@@ -0,0 +1,232 @@
+package challenger
+
+import (
+	"errors"
+	"flag"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	ethc "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const vaultABI = `[
+	{"type":"function","name":"transfer","stateMutability":"nonpayable",
+		"inputs":[{"name":"to","type":"address"},{"name":"amount","type":"uint256"}],
+		"outputs":[{"name":"","type":"bool"}]},
+	{"type":"function","name":"deposit","stateMutability":"payable","inputs":[],"outputs":[]},
+	{"type":"function","name":"setTokens","stateMutability":"nonpayable",
+		"inputs":[{"name":"tokens","type":"address[]"}],"outputs":[]},
+	{"type":"function","name":"placeOrder","stateMutability":"nonpayable",
+		"inputs":[{"name":"order","type":"tuple","components":[
+			{"name":"maker","type":"address"},{"name":"amounts","type":"uint256[]"}]}],
+		"outputs":[]},
+	{"type":"function","name":"getOrder","stateMutability":"view",
+		"inputs":[{"name":"id","type":"uint256"}],
+		"outputs":[
+			{"name":"order","type":"tuple","components":[
+				{"name":"maker","type":"address"},{"name":"amounts","type":"uint256[]"}]},
+			{"name":"","type":"bytes32"}]},
+	{"type":"error","name":"Unauthorized","inputs":[{"name":"account","type":"address"}]}
+]`
+
+type order struct {
+	Maker   ethc.Address
+	Amounts []*big.Int
+}
+
+func vaultABIFile(t *testing.T) (string, *abi.ABI) {
+	path := filepath.Join(t.TempDir(), "vault.json")
+	require.Nil(t, os.WriteFile(path, []byte(vaultABI), 0o644))
+	parsed, err := abi.JSON(strings.NewReader(vaultABI))
+	require.Nil(t, err)
+	return path, &parsed
+}
+
+// contractContext is the context of a call or send command run with args.
+func contractContext(t *testing.T, name string, args ...string) *cli.Context {
+	set := flag.NewFlagSet(name, flag.ContinueOnError)
+	require.Nil(t, set.Parse(args))
+	cliCtx := cli.NewContext(nil, set, nil)
+	cliCtx.Command.Name = name
+	return cliCtx
+}
+
+// contractRevertError carries revert data the way a node does.
+type contractRevertError struct {
+	data []byte
+}
+
+func (e *contractRevertError) Error() string          { return "execution reverted" }
+func (e *contractRevertError) ErrorCode() int         { return 3 }
+func (e *contractRevertError) ErrorData() interface{} { return hexutil.Encode(e.data) }
+
+func TestParseContractInvocation(t *testing.T) {
+	path, parsed := vaultABIFile(t)
+	contract := testToken
+	to := ethc.HexToAddress(testAccount)
+
+	tests := []struct {
+		name string
+		args []string
+		// packed is what the arguments should encode to
+		packed func() ([]byte, error)
+		err    string
+	}{
+		{
+			name: "scalars",
+			args: []string{path, contract, "transfer", testAccount, "1000000000000000000"},
+			packed: func() ([]byte, error) {
+				return parsed.Pack("transfer", to, big.NewInt(1e18))
+			},
+		},
+		{
+			name: "signature and hex amount",
+			args: []string{path, contract, "transfer(address, uint256)", testAccount, "0x10"},
+			packed: func() ([]byte, error) {
+				return parsed.Pack("transfer", to, big.NewInt(16))
+			},
+		},
+		{
+			name: "array as JSON",
+			args: []string{path, contract, "setTokens", `["` + testToken + `","` + testAccount + `"]`},
+			packed: func() ([]byte, error) {
+				return parsed.Pack("setTokens", []ethc.Address{ethc.HexToAddress(testToken), to})
+			},
+		},
+		{
+			name: "tuple as JSON",
+			args: []string{path, contract, "placeOrder", `{"maker":"` + testAccount + `","amounts":[1,"2"]}`},
+			packed: func() ([]byte, error) {
+				return parsed.Pack("placeOrder", order{Maker: to, Amounts: []*big.Int{big.NewInt(1), big.NewInt(2)}})
+			},
+		},
+		{
+			name: "no arguments",
+			args: []string{path, contract, "deposit"},
+			packed: func() ([]byte, error) {
+				return parsed.Pack("deposit")
+			},
+		},
+		{
+			name: "too few",
+			args: []string{path, contract},
+			err:  "usage: send " + contractArgsUsage,
+		},
+		{
+			name: "missing abi file",
+			args: []string{filepath.Join(t.TempDir(), "missing.json"), contract, "deposit"},
+			err:  "no such file or directory",
+		},
+		{
+			name: "invalid contract",
+			args: []string{path, "vault", "deposit"},
+			err:  "invalid address: vault",
+		},
+		{
+			name: "unknown method",
+			args: []string{path, contract, "withdraw"},
+			err:  "method withdraw not found in abi",
+		},
+		{
+			name: "invalid argument",
+			args: []string{path, contract, "transfer", testAccount, "ten"},
+			err:  "transfer(address,uint256): ",
+		},
+		{
+			name: "missing argument",
+			args: []string{path, contract, "transfer", testAccount},
+			err:  "transfer(address,uint256): ",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inv, err := parseContractInvocation(contractContext(t, "send", tt.args...))
+			if tt.err != "" {
+				require.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, ethc.HexToAddress(contract), inv.address)
+			want, err := tt.packed()
+			require.Nil(t, err)
+			require.Equal(t, hexutil.Encode(want), hexutil.Encode(inv.data))
+		})
+	}
+}
+
+func TestContractCallOutput(t *testing.T) {
+	path, parsed := vaultABIFile(t)
+	inv, err := parseContractInvocation(contractContext(t, "call", path, testToken, "getOrder", "7"))
+	require.Nil(t, err)
+
+	// named outputs are keyed by name, unnamed ones by position
+	hash := ethc.HexToHash("0xabcd")
+	output, err := parsed.Methods["getOrder"].Outputs.Pack(
+		order{Maker: ethc.HexToAddress(testAccount), Amounts: []*big.Int{big.NewInt(1), big.NewInt(1e18)}}, hash)
+	require.Nil(t, err)
+	result, err := inv.decodeOutput(output)
+	require.Nil(t, err)
+	require.Equal(t, map[string]interface{}{
+		"order": map[string]interface{}{
+			"maker":   ethc.HexToAddress(testAccount).Hex(),
+			"amounts": []interface{}{"1", "1000000000000000000"},
+		},
+		"1": hash.Hex(),
+	}, result)
+
+	_, err = inv.decodeOutput([]byte{1, 2, 3})
+	require.NotNil(t, err)
+
+	// a revert is explained with the errors of the ABI file
+	abiErr := parsed.Errors["Unauthorized"]
+	packed, err := abiErr.Inputs.Pack(ethc.HexToAddress(testAccount))
+	require.Nil(t, err)
+	err = inv.callError(&contractRevertError{data: append(abiErr.ID.Bytes()[:4], packed...)})
+	require.EqualError(t, err, "getOrder(uint256) reverted: Unauthorized(account: "+ethc.HexToAddress(testAccount).Hex()+")")
+
+	nodeErr := errors.New("connection refused")
+	require.Equal(t, nodeErr, inv.callError(nodeErr))
+}
+
+func TestContractSendValue(t *testing.T) {
+	path, _ := vaultABIFile(t)
+	deposit, err := parseContractInvocation(contractContext(t, "send", path, testToken, "deposit"))
+	require.Nil(t, err)
+	transfer, err := parseContractInvocation(contractContext(t, "send", path, testToken, "transfer", testAccount, "1"))
+	require.Nil(t, err)
+
+	tests := []struct {
+		name  string
+		inv   *contractInvocation
+		value string
+		want  *big.Int
+		err   string
+	}{
+		{name: "none", inv: transfer},
+		{name: "payable", inv: deposit, value: "1000000000000000000", want: big.NewInt(1e18)},
+		{name: "hex", inv: deposit, value: "0x10", want: big.NewInt(16)},
+		{name: "zero to a method not payable", inv: transfer, value: "0", want: new(big.Int)},
+		{name: "not payable", inv: transfer, value: "1", err: "transfer(address,uint256) is not payable"},
+		{name: "negative", inv: deposit, value: "-1", err: "invalid value: -1"},
+		{name: "invalid", inv: deposit, value: "1 ether", err: "invalid value: 1 ether"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := tt.inv.value(tt.value)
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, tt.want, value)
+		})
+	}
+}
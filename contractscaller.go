@@ -2,15 +2,24 @@ package challenger
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
 
 	"github.com/urfave/cli"
 
-	"github.com/ethereum/go-ethereum/common"
+	ethc "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/the-web3/contracts-caller/caller"
 	common2 "github.com/the-web3/contracts-caller/common"
 	"github.com/the-web3/contracts-caller/ethereumcli"
+	"github.com/the-web3/contracts-caller/signer"
+	"github.com/the-web3/contracts-caller/txmgr"
 )
 
 func Main(gitVersion string) func(ctx *cli.Context) error {
@@ -21,47 +30,240 @@ func Main(gitVersion string) func(ctx *cli.Context) error {
 		}
 		ctx, cancel := context.WithCancel(context.Background())
 		defer cancel()
-		callerPrivateKey, _, err := common2.ParseWalletPrivKeyAndContractAddr(
-			"ContractCaller", cfg.Mnemonic, cfg.SequencerHDPath,
-			cfg.PrivateKey, cfg.TreasureManagerContractAddress, cfg.Passphrase,
-		)
+		cCaller, closeCaller, err := newContractCaller(ctx, cfg)
 		if err != nil {
 			return err
 		}
-		chainClient, err := ethereumcli.EthClientWithTimeout(ctx, cfg.ChainRpcUrl)
-		if err != nil {
-			return err
+		defer closeCaller()
+		if cfg.IndexerDB != "" {
+			ix, store, err := newIndexer(cfg, cCaller.Cfg.ChainClient)
+			if err != nil {
+				return err
+			}
+			defer store.Close()
+			ix.Start(ctx)
+			defer ix.Stop()
+			log.Info("Contract caller indexer start", "db", cfg.IndexerDB, "startBlock", cfg.IndexerStartBlock)
+			if cfg.LedgerCheckInterval > 0 {
+				go runLedgerChecks(ctx, cfg.LedgerCheckInterval, store, cCaller.Cfg.ChainClient, cCaller.Cfg.TreasureManagerAddr)
+			}
 		}
-		log.Info("Contract Caller Client init success")
-
-		chainID, err := chainClient.ChainID(ctx)
-		if err != nil {
-			return err
+		if cfg.IndexerDB == "" && cfg.LedgerCheckInterval > 0 {
+			log.Warn("Contract caller ledger checks need --indexer-db, not checking")
 		}
-		callerConfig := &caller.ContractCallerConfig{
-			ChainClient:               chainClient,
-			ChainID:                   chainID,
-			TreasureManagerAddr:       common.Address(common.HexToAddress(cfg.TreasureManagerContractAddress)),
-			WithdrawManageAddr:        cfg.WithdrawManagerAddress,
-			PrivateKey:                callerPrivateKey,
-			LoopInterval:              cfg.LoopInterval,
-			NumConfirmations:          cfg.NumConfirmations,
-			SafeAbortNonceTooLowCount: cfg.SafeAbortNonceTooLowCount,
-			EnableHsm:                 cfg.EnableHsm,
-			HsmCreden:                 cfg.HsmCreden,
-			HsmAPIName:                cfg.HsmAPIName,
-			HsmAddress:                cfg.HsmAddress,
-		}
-		log.Info("Contract caller hsm", "EnableHsm", cfg.EnableHsm, "HsmAPIName", cfg.HsmAPIName, "HsmAddress", cfg.HsmAddress)
-		cCaller, err := caller.NewContractCaller(ctx, callerConfig)
-		if err != nil {
-			return err
+		if cfg.WatchEvents {
+			go watchEvents(ctx, cCaller, cfg)
 		}
 		if err := cCaller.Start(); err != nil {
 			return err
 		}
 		log.Info("Contract caller service start")
 		defer cCaller.Stop()
+		if cfg.AdminListen != "" {
+			stopAdminAPI, err := startAdminAPI(ctx, cfg, cCaller)
+			if err != nil {
+				return err
+			}
+			defer stopAdminAPI()
+		}
+
+		interrupt := make(chan os.Signal, 1)
+		signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
+		<-interrupt
+		log.Info("Contract caller service stopping")
 		return nil
 	}
 }
+
+// newContractCaller wires the signer, chain client, journal and fee policy
+// from cfg into a ContractCaller. The returned func releases the signer and
+// journal once the caller is no longer used.
+func newContractCaller(ctx context.Context, cfg Config) (*caller.ContractCaller, func(), error) {
+	var closers []io.Closer
+	closeAll := func() {
+		for i := len(closers) - 1; i >= 0; i-- {
+			closers[i].Close()
+		}
+	}
+	fail := func(err error) (*caller.ContractCaller, func(), error) {
+		closeAll()
+		return nil, nil, err
+	}
+
+	callerSigner, err := newSigner(ctx, cfg)
+	if err != nil {
+		return fail(err)
+	}
+	if closer, ok := callerSigner.(io.Closer); ok {
+		closers = append(closers, closer)
+	}
+	approvers, err := parseApprovers(cfg)
+	if err != nil {
+		return fail(err)
+	}
+	contractAddress, err := common2.ParseAddress(cfg.TreasureManagerContractAddress)
+	if err != nil {
+		return fail(err)
+	}
+	log.Info("ContractCaller wallet params parsed successfully", "wallet_address",
+		callerSigner.Address(), "contract_address", contractAddress)
+	chainClient, err := ethereumcli.EthClientWithTimeout(ctx, cfg.ChainRpcUrl)
+	if err != nil {
+		return fail(err)
+	}
+	log.Info("Contract Caller Client init success")
+
+	var journal txmgr.Journal
+	if cfg.JournalPath != "" {
+		kvJournal, err := txmgr.NewLevelDBJournal(cfg.JournalPath)
+		if err != nil {
+			return fail(err)
+		}
+		closers = append(closers, kvJournal)
+		journal = kvJournal
+	}
+
+	chainID, err := chainClient.ChainID(ctx)
+	if err != nil {
+		return fail(err)
+	}
+	feePolicy, err := txmgr.NewFeePolicy(txmgr.FeePolicyConfig{
+		Strategy:     cfg.FeePolicy,
+		MaxGasTipCap: new(big.Int).SetUint64(cfg.MaxGasTipCap),
+		MaxGasFeeCap: new(big.Int).SetUint64(cfg.MaxGasFeeCap),
+		BumpStep:     new(big.Int).SetUint64(cfg.FeeBumpStep),
+		BumpFactor:   cfg.FeeBumpFactor,
+		Percentile:   cfg.FeeHistoryPercentile,
+	}, chainClient)
+	if err != nil {
+		return fail(err)
+	}
+	var safeAddress ethc.Address
+	var safeStore caller.SafeTxStore
+	if cfg.SafeAddress != "" {
+		if safeAddress, err = common2.ParseAddress(cfg.SafeAddress); err != nil {
+			return fail(err)
+		}
+		if cfg.SafeDB != "" {
+			kvStore, err := caller.NewLevelDBSafeTxStore(cfg.SafeDB)
+			if err != nil {
+				return fail(err)
+			}
+			closers = append(closers, kvStore)
+			safeStore = kvStore
+		}
+	}
+	var jobs []caller.JobSpec
+	if cfg.JobsFile != "" {
+		if jobs, err = caller.LoadJobs(cfg.JobsFile); err != nil {
+			return fail(err)
+		}
+	}
+	callerConfig := &caller.ContractCallerConfig{
+		ChainClient:               chainClient,
+		ChainID:                   chainID,
+		TreasureManagerAddr:       contractAddress,
+		WithdrawManageAddr:        cfg.WithdrawManagerAddress,
+		Signer:                    callerSigner,
+		LoopInterval:              cfg.LoopInterval,
+		NumConfirmations:          cfg.NumConfirmations,
+		SafeAbortNonceTooLowCount: cfg.SafeAbortNonceTooLowCount,
+		Journal:                   journal,
+		FeePolicy:                 feePolicy,
+		ForceSend:                 cfg.ForceSend,
+		Jobs:                      jobs,
+		ObserveOnly:               cfg.ObserveOnly,
+		TokenWhitelist:            cfg.TokenWhitelist,
+		ApprovalThreshold:         cfg.ApprovalThreshold,
+		Approvers:                 approvers,
+		ProposalTTL:               cfg.ProposalTTL,
+		SafeAddress:               safeAddress,
+		SafeTxStore:               safeStore,
+	}
+	log.Info("Contract caller hsm", "EnableHsm", cfg.EnableHsm, "HsmAPIName", cfg.HsmAPIName, "HsmAddress", cfg.HsmAddress)
+	cCaller, err := caller.NewContractCaller(ctx, callerConfig)
+	if err != nil {
+		return fail(err)
+	}
+	return cCaller, closeAll, nil
+}
+
+// newSigner opens the wallet key configured in cfg and checks it signs for
+// its address. A signer that is an io.Closer is closed by the caller.
+func newSigner(ctx context.Context, cfg Config) (signer.Signer, error) {
+	s, err := signer.New(ctx, signer.Config{
+		Keystore:             cfg.Keystore,
+		KeystorePasswordFile: cfg.KeystorePasswordFile,
+
+		PrivateKey:        cfg.PrivateKey,
+		Mnemonic:          cfg.Mnemonic,
+		HDPath:            cfg.SequencerHDPath,
+		Passphrase:        cfg.Passphrase,
+		AllowPlaintextKey: cfg.AllowPlaintextKey,
+
+		EnableHsm:  cfg.EnableHsm,
+		HsmAPIName: cfg.HsmAPIName,
+		HsmAddress: cfg.HsmAddress,
+		HsmCreden:  cfg.HsmCreden,
+
+		AWSKMSKeyID:    cfg.AWSKMSKeyID,
+		AWSKMSRegion:   cfg.AWSKMSRegion,
+		AWSKMSEndpoint: cfg.AWSKMSEndpoint,
+
+		VaultAddr:    cfg.VaultAddr,
+		VaultToken:   cfg.VaultToken,
+		VaultMount:   cfg.VaultMount,
+		VaultKeyName: cfg.VaultKeyName,
+
+		PKCS11Module:     cfg.PKCS11Module,
+		PKCS11TokenLabel: cfg.PKCS11TokenLabel,
+		PKCS11Pin:        cfg.PKCS11Pin,
+		PKCS11KeyLabel:   cfg.PKCS11KeyLabel,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := signer.Check(ctx, s); err != nil {
+		if closer, ok := s.(io.Closer); ok {
+			closer.Close()
+		}
+		return nil, err
+	}
+	return s, nil
+}
+
+// parseApprovers reads --approvers, each given repeated or comma separated.
+func parseApprovers(cfg Config) ([]ethc.Address, error) {
+	var approvers []ethc.Address
+	for _, value := range cfg.Approvers {
+		for _, s := range strings.Split(value, ",") {
+			if s = strings.TrimSpace(s); s == "" {
+				continue
+			}
+			addr, err := common2.ParseAddress(s)
+			if err != nil {
+				return nil, fmt.Errorf("approver: %w", err)
+			}
+			approvers = append(approvers, addr)
+		}
+	}
+	return approvers, nil
+}
+
+// watchEvents logs the TreasureManager events until ctx is done, through
+// subscriptions when the RPC endpoint supports them.
+func watchEvents(ctx context.Context, cCaller *caller.ContractCaller, cfg Config) {
+	url := cfg.ChainRpcUrl
+	subscribe := !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://")
+	log.Info("Contract caller watch events", "subscribe", subscribe, "fromBlock", cfg.WatchFromBlock)
+	err := cCaller.WatchEvents(ctx, caller.EventWatcherConfig{
+		FromBlock: cfg.WatchFromBlock,
+		Subscribe: subscribe,
+	}, func(e *caller.TreasureManagerEvent) {
+		log.Info("Contract caller event", "name", e.Name, "block", e.Raw.BlockNumber,
+			"TxHash", e.Raw.TxHash, "removed", e.Raw.Removed)
+	})
+	if err != nil {
+		log.Error("Contract caller watch events fail", "err", err)
+	}
+}
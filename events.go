@@ -0,0 +1,126 @@
+package challenger
+
+import (
+	"context"
+	"errors"
+
+	"github.com/urfave/cli"
+
+	"github.com/the-web3/contracts-caller/bindings"
+	common2 "github.com/the-web3/contracts-caller/common"
+	"github.com/the-web3/contracts-caller/ethereumcli"
+	"github.com/the-web3/contracts-caller/flags"
+	"github.com/the-web3/contracts-caller/indexer"
+)
+
+// EventsCommand is `events sync|list` over the store of --indexer-db. The
+// store is locked by a running service, so these are for when it is not.
+func EventsCommand() cli.Command {
+	return cli.Command{
+		Name:  "events",
+		Usage: "Index TreasureManager events into --indexer-db and read them back",
+		Subcommands: []cli.Command{
+			{
+				Name:   "sync",
+				Usage:  "Index every block up to the head once and exit",
+				Action: runEventsSync,
+			},
+			{
+				Name:   "list",
+				Usage:  "Print the indexed events as JSON",
+				Flags:  []cli.Flag{flags.EventNameFlag, flags.FromBlockFlag, flags.ToBlockFlag},
+				Action: runEventsList,
+			},
+		},
+	}
+}
+
+// newIndexer opens the store of cfg and builds an indexer of the
+// TreasureManager on it. The store is to be closed once the indexer stopped.
+func newIndexer(cfg Config, client indexer.ChainClient) (*indexer.Indexer, *indexer.KVStore, error) {
+	if cfg.IndexerDB == "" {
+		return nil, nil, errors.New("no --indexer-db configured")
+	}
+	contractAddress, err := common2.ParseAddress(cfg.TreasureManagerContractAddress)
+	if err != nil {
+		return nil, nil, err
+	}
+	tmABI, err := bindings.TreasureManagerMetaData.GetAbi()
+	if err != nil {
+		return nil, nil, err
+	}
+	store, err := indexer.NewLevelDBStore(cfg.IndexerDB)
+	if err != nil {
+		return nil, nil, err
+	}
+	ix, err := indexer.New(indexer.Config{
+		Client:        client,
+		Contract:      contractAddress,
+		ABI:           tmABI,
+		Store:         store,
+		StartBlock:    cfg.IndexerStartBlock,
+		ChunkSize:     cfg.IndexerChunkSize,
+		Confirmations: cfg.IndexerConfirmations,
+		PollInterval:  cfg.LoopInterval,
+	})
+	if err != nil {
+		store.Close()
+		return nil, nil, err
+	}
+	return ix, store, nil
+}
+
+func runEventsSync(cliCtx *cli.Context) error {
+	cfg, err := NewConfig(cliCtx)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	chainClient, err := ethereumcli.EthClientWithTimeout(ctx, cfg.ChainRpcUrl)
+	if err != nil {
+		return err
+	}
+	defer chainClient.Close()
+	ix, store, err := newIndexer(cfg, chainClient)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	if err := ix.Sync(ctx); err != nil {
+		return err
+	}
+	checkpoint, err := store.Checkpoint()
+	if err != nil {
+		return err
+	}
+	return printJSON(checkpoint)
+}
+
+func runEventsList(cliCtx *cli.Context) error {
+	cfg, err := NewConfig(cliCtx)
+	if err != nil {
+		return err
+	}
+	if cfg.IndexerDB == "" {
+		return errors.New("no --indexer-db configured")
+	}
+	store, err := indexer.NewLevelDBStore(cfg.IndexerDB)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	events, err := store.Events(indexer.EventQuery{
+		Name:      cliCtx.String(flags.EventNameFlag.Name),
+		FromBlock: cliCtx.Uint64(flags.FromBlockFlag.Name),
+		ToBlock:   cliCtx.Uint64(flags.ToBlockFlag.Name),
+	})
+	if err != nil {
+		return err
+	}
+	if events == nil {
+		events = []*indexer.Event{}
+	}
+	return printJSON(events)
+}
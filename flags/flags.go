@@ -26,9 +26,24 @@ var (
 	}
 	PrivateKeyFlag = cli.StringFlag{
 		Name:   "private-key",
-		Usage:  "Ethereum private key for node operator",
+		Usage:  "Plaintext Ethereum private key for node operator, dev chains only, needs allow-plaintext-key",
 		EnvVar: prefixEnvVar("PRIVATE_KEY"),
-		Value:  "0xac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80",
+	}
+	KeystoreFlag = cli.StringFlag{
+		Name:   "keystore",
+		Usage:  "Path of the go-ethereum encrypted keystore (JSON v3) file of the caller wallet",
+		EnvVar: prefixEnvVar("KEYSTORE"),
+	}
+	KeystorePasswordFileFlag = cli.StringFlag{
+		Name:   "keystore-password-file",
+		Usage:  "File whose first line is the password of the keystore",
+		EnvVar: prefixEnvVar("KEYSTORE_PASSWORD_FILE"),
+	}
+	AllowPlaintextKeyFlag = cli.BoolFlag{
+		Name: "allow-plaintext-key",
+		Usage: "Allow the wallet to come from private-key or mnemonic, " +
+			"which is only meant for dev chains",
+		EnvVar: prefixEnvVar("ALLOW_PLAINTEXT_KEY"),
 	}
 	TreasureManagerContractAddressFlag = cli.StringFlag{
 		Name:   "treasure-manage-address",
@@ -37,10 +52,10 @@ var (
 		Value:  "0x0B306BF915C4d645ff596e518fAf3F9669b97016",
 	}
 	WithdrawManagerAddressFlag = cli.StringFlag{
-		Name:   "treasure-manage-address",
-		Usage:  "Address of the treasure manager contract",
+		Name: "withdraw-manager-address",
+		Usage: "Desired withdraw manager of the treasure manager contract, " +
+			"kept in place on every loop; not managed when empty",
 		EnvVar: prefixEnvVar("WITHDRAW_MANAGER_ADDRESS"),
-		Value:  "0xa0Ee7A142d267C1f36714E4a8F75612F20a79720",
 	}
 	LoopIntervalFlag = cli.DurationFlag{
 		Name:   "loop-interval",
@@ -100,12 +115,294 @@ var (
 		Usage:  "the creden of hsm key",
 		EnvVar: prefixEnvVar("HSM_CREDEN"),
 	}
+	AWSKMSKeyIDFlag = cli.StringFlag{
+		Name:   "aws-kms-key-id",
+		Usage:  "Key ID, ARN or alias of an ECC_SECG_P256K1 AWS KMS key to sign with",
+		EnvVar: prefixEnvVar("AWS_KMS_KEY_ID"),
+	}
+	AWSKMSRegionFlag = cli.StringFlag{
+		Name:   "aws-kms-region",
+		Usage:  "AWS region of the KMS key, defaults to the AWS SDK configuration",
+		EnvVar: prefixEnvVar("AWS_KMS_REGION"),
+	}
+	AWSKMSEndpointFlag = cli.StringFlag{
+		Name:   "aws-kms-endpoint",
+		Usage:  "Override of the AWS KMS endpoint, e.g. a local-kms or LocalStack URL",
+		EnvVar: prefixEnvVar("AWS_KMS_ENDPOINT"),
+	}
+	VaultAddrFlag = cli.StringFlag{
+		Name:   "vault-addr",
+		Usage:  "Address of the HashiCorp Vault server holding the signing key",
+		EnvVar: prefixEnvVar("VAULT_ADDR"),
+	}
+	VaultTokenFlag = cli.StringFlag{
+		Name:   "vault-token",
+		Usage:  "Vault token allowed to read and sign with the transit key",
+		EnvVar: prefixEnvVar("VAULT_TOKEN"),
+	}
+	VaultMountFlag = cli.StringFlag{
+		Name:   "vault-transit-mount",
+		Usage:  "Mount path of the Vault transit engine",
+		EnvVar: prefixEnvVar("VAULT_TRANSIT_MOUNT"),
+		Value:  "transit",
+	}
+	VaultKeyNameFlag = cli.StringFlag{
+		Name: "vault-key-name",
+		Usage: "Name of the secp256k1 transit key to sign with, hsm-address " +
+			"must be set to its address",
+		EnvVar: prefixEnvVar("VAULT_KEY_NAME"),
+	}
+	PKCS11ModuleFlag = cli.StringFlag{
+		Name:   "pkcs11-module",
+		Usage:  "Path of the PKCS#11 module, e.g. /usr/lib/softhsm/libsofthsm2.so",
+		EnvVar: prefixEnvVar("PKCS11_MODULE"),
+	}
+	PKCS11TokenLabelFlag = cli.StringFlag{
+		Name:   "pkcs11-token-label",
+		Usage:  "Label of the PKCS#11 token holding the signing key",
+		EnvVar: prefixEnvVar("PKCS11_TOKEN_LABEL"),
+	}
+	PKCS11PinFlag = cli.StringFlag{
+		Name:   "pkcs11-pin",
+		Usage:  "User PIN of the PKCS#11 token",
+		EnvVar: prefixEnvVar("PKCS11_PIN"),
+	}
+	PKCS11KeyLabelFlag = cli.StringFlag{
+		Name:   "pkcs11-key-label",
+		Usage:  "Label of the secp256k1 key pair on the PKCS#11 token",
+		EnvVar: prefixEnvVar("PKCS11_KEY_LABEL"),
+	}
+	JournalPathFlag = cli.StringFlag{
+		Name: "journal-path",
+		Usage: "Directory of the on-disk transaction journal used to resume " +
+			"in-flight transactions after a restart, disabled when empty",
+		EnvVar: prefixEnvVar("JOURNAL_PATH"),
+	}
+	FeePolicyFlag = cli.StringFlag{
+		Name:   "fee-policy",
+		Usage:  "Fee bumping strategy for resubmissions: geth, linear, exponential or fee-history",
+		EnvVar: prefixEnvVar("FEE_POLICY"),
+		Value:  "geth",
+	}
+	MaxGasTipCapFlag = cli.Uint64Flag{
+		Name:   "max-gas-tip-cap",
+		Usage:  "Hard upper bound in wei of the priority fee of any attempt, unlimited when zero",
+		EnvVar: prefixEnvVar("MAX_GAS_TIP_CAP"),
+	}
+	MaxGasFeeCapFlag = cli.Uint64Flag{
+		Name:   "max-gas-fee-cap",
+		Usage:  "Hard upper bound in wei of the fee cap of any attempt, unlimited when zero",
+		EnvVar: prefixEnvVar("MAX_GAS_FEE_CAP"),
+	}
+	FeeBumpStepFlag = cli.Uint64Flag{
+		Name:   "fee-bump-step",
+		Usage:  "Wei added to the fees on every resubmission by the linear fee policy",
+		EnvVar: prefixEnvVar("FEE_BUMP_STEP"),
+		Value:  1000000000,
+	}
+	FeeBumpFactorFlag = cli.Float64Flag{
+		Name:   "fee-bump-factor",
+		Usage:  "Fee multiplier on every resubmission of the exponential fee policy",
+		EnvVar: prefixEnvVar("FEE_BUMP_FACTOR"),
+		Value:  1.25,
+	}
+	FeeHistoryPercentileFlag = cli.Float64Flag{
+		Name:   "fee-history-percentile",
+		Usage:  "Percentile of recent priority fees the fee-history fee policy tips at",
+		EnvVar: prefixEnvVar("FEE_HISTORY_PERCENTILE"),
+		Value:  60,
+	}
+	ObserveOnlyFlag = cli.BoolFlag{
+		Name: "observe-only",
+		Usage: "Only report contract state drifting from the desired state, " +
+			"never send transactions to correct it",
+		EnvVar: prefixEnvVar("OBSERVE_ONLY"),
+	}
+	TokenWhitelistFlag = cli.StringFlag{
+		Name: "token-whitelist",
+		Usage: "File or http(s) URL of the desired token whitelist, missing " +
+			"tokens are added on every loop; not managed when empty",
+		EnvVar: prefixEnvVar("TOKEN_WHITELIST"),
+	}
+	IndexerDBFlag = cli.StringFlag{
+		Name: "indexer-db",
+		Usage: "Directory of the on-disk store of indexed TreasureManager events, " +
+			"the indexer is disabled when empty",
+		EnvVar: prefixEnvVar("INDEXER_DB"),
+	}
+	IndexerStartBlockFlag = cli.Uint64Flag{
+		Name:   "indexer-start-block",
+		Usage:  "First block indexed into an empty store, e.g. the deployment block",
+		EnvVar: prefixEnvVar("INDEXER_START_BLOCK"),
+	}
+	IndexerChunkSizeFlag = cli.Uint64Flag{
+		Name:   "indexer-chunk-size",
+		Usage:  "Block range of one eth_getLogs while indexing",
+		EnvVar: prefixEnvVar("INDEXER_CHUNK_SIZE"),
+		Value:  2000,
+	}
+	IndexerConfirmationsFlag = cli.Uint64Flag{
+		Name:   "indexer-confirmations",
+		Usage:  "Number of blocks the indexer stays behind the head",
+		EnvVar: prefixEnvVar("INDEXER_CONFIRMATIONS"),
+	}
+	LedgerCheckIntervalFlag = cli.DurationFlag{
+		Name: "ledger-check-interval",
+		Usage: "How often the indexed events are reconciled with the contract " +
+			"balances, needs --indexer-db; disabled when 0",
+		EnvVar: prefixEnvVar("LEDGER_CHECK_INTERVAL"),
+	}
+	RewardsDBFlag = cli.StringFlag{
+		Name: "rewards-db",
+		Usage: "Directory of the on-disk record of granted rewards, keeping " +
+			"reruns of a reward schedule from granting twice",
+		EnvVar: prefixEnvVar("REWARDS_DB"),
+	}
+	WithdrawalsDBFlag = cli.StringFlag{
+		Name: "withdrawals-db",
+		Usage: "Directory of the on-disk record of withdrawal requests, " +
+			"their approvals and executions",
+		EnvVar: prefixEnvVar("WITHDRAWALS_DB"),
+	}
+	WithdrawalPolicyFlag = cli.StringFlag{
+		Name: "withdrawal-policy",
+		Usage: "YAML file of the allowed destinations and the daily limit, " +
+			"approval threshold and minimum remaining balance of each token",
+		EnvVar: prefixEnvVar("WITHDRAWAL_POLICY"),
+	}
+	ProposalsDBFlag = cli.StringFlag{
+		Name:   "proposals-db",
+		Usage:  "Directory of the on-disk record of proposals and their approvals",
+		EnvVar: prefixEnvVar("PROPOSALS_DB"),
+	}
+	ApproversFlag = cli.StringSliceFlag{
+		Name: "approvers",
+		Usage: "Accounts whose EIP-712 signatures approve a proposal, " +
+			"repeated or comma separated",
+		EnvVar: prefixEnvVar("APPROVERS"),
+	}
+	ApprovalThresholdFlag = cli.IntFlag{
+		Name: "approval-threshold",
+		Usage: "Approvals a withdrawal, ownership transfer, grantRole or " +
+			"renounceOwnership needs before it is sent; sent directly when 0",
+		EnvVar: prefixEnvVar("APPROVAL_THRESHOLD"),
+	}
+	ProposalTTLFlag = cli.DurationFlag{
+		Name:   "proposal-ttl",
+		Usage:  "How long a proposal can collect approvals, 24h when 0",
+		EnvVar: prefixEnvVar("PROPOSAL_TTL"),
+	}
+	SafeAddressFlag = cli.StringFlag{
+		Name: "safe-address",
+		Usage: "Safe owning the TreasureManager; every write to it is then " +
+			"signed as a Safe transaction and executed once enough owners signed it",
+		EnvVar: prefixEnvVar("SAFE_ADDRESS"),
+	}
+	SafeDBFlag = cli.StringFlag{
+		Name:   "safe-db",
+		Usage:  "Directory of the on-disk record of Safe transactions and their signatures",
+		EnvVar: prefixEnvVar("SAFE_DB"),
+	}
+	AdminListenFlag = cli.StringFlag{
+		Name: "admin-listen",
+		Usage: "Address the admin HTTP/JSON-RPC API is served on, e.g. " +
+			"127.0.0.1:8090; not served when empty",
+		EnvVar: prefixEnvVar("ADMIN_LISTEN"),
+	}
+	AdminTokensFlag = cli.StringFlag{
+		Name:   "admin-tokens",
+		Usage:  "YAML file of the admin API clients and their bearer tokens",
+		EnvVar: prefixEnvVar("ADMIN_TOKENS"),
+	}
+	OperationsDBFlag = cli.StringFlag{
+		Name: "operations-db",
+		Usage: "Directory of the on-disk record of the operations submitted " +
+			"to the admin API; kept in memory when empty",
+		EnvVar: prefixEnvVar("OPERATIONS_DB"),
+	}
+	WatchEventsFlag = cli.BoolFlag{
+		Name: "watch-events",
+		Usage: "Log every TreasureManager event, subscribing over a ws:// or " +
+			"IPC --chain-rpc-url and polling every --loop-interval otherwise",
+		EnvVar: prefixEnvVar("WATCH_EVENTS"),
+	}
+	WatchFromBlockFlag = cli.Uint64Flag{
+		Name:   "watch-from-block",
+		Usage:  "First block whose events are watched, the head at start when 0",
+		EnvVar: prefixEnvVar("WATCH_FROM_BLOCK"),
+	}
+	JobsFileFlag = cli.StringFlag{
+		Name: "jobs-file",
+		Usage: "YAML file of the jobs the caller runs on their own schedules, " +
+			"logging the TreasureManager state every loop-interval when empty",
+		EnvVar: prefixEnvVar("JOBS_FILE"),
+	}
+	ForceSendFlag = cli.BoolFlag{
+		Name: "force-send",
+		Usage: "Broadcast transactions even when the pre-flight simulation " +
+			"says they would revert",
+		EnvVar: prefixEnvVar("FORCE_SEND"),
+	}
+)
+
+// Flags of the query subcommands.
+var (
+	QueryBlockFlag = cli.StringFlag{
+		Name:  "block",
+		Usage: "Block to query at: a number, latest, safe or finalized",
+		Value: "latest",
+	}
+	QueryOutputFlag = cli.StringFlag{
+		Name:  "output",
+		Usage: "Output format: json, table or csv",
+		Value: "json",
+	}
+	EventNameFlag = cli.StringFlag{
+		Name:  "name",
+		Usage: "Only events of this name, e.g. DepositToken",
+	}
+	FromBlockFlag = cli.Uint64Flag{
+		Name:  "from-block",
+		Usage: "First block of the range",
+	}
+	ToBlockFlag = cli.Uint64Flag{
+		Name:  "to-block",
+		Usage: "Last block of the range, the last indexed when 0",
+	}
+	QueryFromFlag = cli.StringFlag{
+		Name:  "from",
+		Usage: "Address the call is made from, for views reading msg.sender",
+	}
+)
+
+// Flags of the withdraw subcommands.
+var (
+	WithdrawAsFlag = cli.StringFlag{
+		Name:   "as",
+		Usage:  "Name the request, approval or rejection is recorded under",
+		EnvVar: "USER",
+	}
+	WithdrawReasonFlag = cli.StringFlag{
+		Name:  "reason",
+		Usage: "Why the withdrawal is requested or rejected",
+	}
+)
+
+// Flags of the send subcommand.
+var (
+	ValueFlag = cli.StringFlag{
+		Name:  "value",
+		Usage: "Wei sent along with the call of a payable method",
+	}
+	GasLimitFlag = cli.Uint64Flag{
+		Name:  "gas-limit",
+		Usage: "Gas limit of the transaction, estimated when zero",
+	}
 )
 
 var requiredFlags = []cli.Flag{
 	ChainRpcUrlFlag,
 	ChainIdFlag,
-	PrivateKeyFlag,
 	NumConfirmationsFlag,
 	SafeAbortNonceTooLowCountFlag,
 	TreasureManagerContractAddressFlag,
@@ -113,13 +410,59 @@ var requiredFlags = []cli.Flag{
 }
 
 var optionalFlags = []cli.Flag{
+	WithdrawManagerAddressFlag,
+	ObserveOnlyFlag,
+	TokenWhitelistFlag,
+	KeystoreFlag,
+	KeystorePasswordFileFlag,
+	PrivateKeyFlag,
 	MnemonicFlag,
 	CallerHDPathFlag,
 	PassphraseFlag,
+	AllowPlaintextKeyFlag,
 	EnableHsmFlag,
 	HsmAddressFlag,
 	HsmAPINameFlag,
 	HsmCredenFlag,
+	AWSKMSKeyIDFlag,
+	AWSKMSRegionFlag,
+	AWSKMSEndpointFlag,
+	VaultAddrFlag,
+	VaultTokenFlag,
+	VaultMountFlag,
+	VaultKeyNameFlag,
+	PKCS11ModuleFlag,
+	PKCS11TokenLabelFlag,
+	PKCS11PinFlag,
+	PKCS11KeyLabelFlag,
+	JournalPathFlag,
+	FeePolicyFlag,
+	MaxGasTipCapFlag,
+	MaxGasFeeCapFlag,
+	FeeBumpStepFlag,
+	FeeBumpFactorFlag,
+	FeeHistoryPercentileFlag,
+	ForceSendFlag,
+	JobsFileFlag,
+	IndexerDBFlag,
+	IndexerStartBlockFlag,
+	IndexerChunkSizeFlag,
+	IndexerConfirmationsFlag,
+	LedgerCheckIntervalFlag,
+	RewardsDBFlag,
+	WithdrawalsDBFlag,
+	WithdrawalPolicyFlag,
+	ProposalsDBFlag,
+	ApproversFlag,
+	ApprovalThresholdFlag,
+	ProposalTTLFlag,
+	SafeAddressFlag,
+	SafeDBFlag,
+	AdminListenFlag,
+	AdminTokensFlag,
+	OperationsDBFlag,
+	WatchEventsFlag,
+	WatchFromBlockFlag,
 }
 
 func init() {
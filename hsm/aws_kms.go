@@ -0,0 +1,91 @@
+package hsm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	kmstypes "github.com/aws/aws-sdk-go-v2/service/kms/types"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// AWSKMSClient is the subset of the AWS KMS API used for signing; it is
+// satisfied by *kms.Client.
+type AWSKMSClient interface {
+	Sign(ctx context.Context, params *kms.SignInput, optFns ...func(*kms.Options)) (*kms.SignOutput, error)
+	GetPublicKey(ctx context.Context, params *kms.GetPublicKeyInput, optFns ...func(*kms.Options)) (*kms.GetPublicKeyOutput, error)
+}
+
+// AWSManagedKey represents an ECC_SECG_P256K1 key from AWS KMS.
+type AWSManagedKey struct {
+	// Key ID, ARN or alias of the asymmetric signing key.
+	// This field is read-only.
+	KeyID string
+	// Derived from the public key at construction.
+	// This field is read-only.
+	EthereumAddr common.Address
+
+	Client AWSKMSClient
+}
+
+// NewAWSKMSClient loads the default AWS credential chain. A non-empty
+// endpoint overrides the service URL, e.g. for local-kms or LocalStack.
+func NewAWSKMSClient(ctx context.Context, region, endpoint string) (*kms.Client, error) {
+	var opts []func(*config.LoadOptions) error
+	if region != "" {
+		opts = append(opts, config.WithRegion(region))
+	}
+	cfg, err := config.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("AWS config: %w", err)
+	}
+	return kms.NewFromConfig(cfg, func(o *kms.Options) {
+		if endpoint != "" {
+			o.BaseEndpoint = aws.String(endpoint)
+		}
+	}), nil
+}
+
+// NewAWSManagedKey executes a fail-fast initialization: the key must exist,
+// be a secp256k1 signing key, and its address is derived from the public key.
+func NewAWSManagedKey(ctx context.Context, client AWSKMSClient, keyID string) (*AWSManagedKey, error) {
+	resp, err := client.GetPublicKey(ctx, &kms.GetPublicKeyInput{KeyId: aws.String(keyID)})
+	if err != nil {
+		return nil, fmt.Errorf("AWS KMS get public key: %w", err)
+	}
+	if resp.KeySpec != kmstypes.KeySpecEccSecgP256k1 {
+		return nil, fmt.Errorf("AWS KMS key %s has spec %s, want %s", keyID, resp.KeySpec, kmstypes.KeySpecEccSecgP256k1)
+	}
+	pub, err := ParsePublicKeyDER(resp.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("AWS KMS: %w", err)
+	}
+	return &AWSManagedKey{
+		KeyID:        keyID,
+		EthereumAddr: crypto.PubkeyToAddress(*pub),
+		Client:       client,
+	}, nil
+}
+
+// SignHash returns the signature bytes.
+func (mk *AWSManagedKey) SignHash(ctx context.Context, hash common.Hash) ([]byte, error) {
+	resp, err := mk.Client.Sign(ctx, &kms.SignInput{
+		KeyId:            aws.String(mk.KeyID),
+		Message:          hash[:],
+		MessageType:      kmstypes.MessageTypeDigest,
+		SigningAlgorithm: kmstypes.SigningAlgorithmSpecEcdsaSha256,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("AWS KMS sign operation: %w", err)
+	}
+
+	sig, err := DERToRecoverable(resp.Signature, hash, mk.EthereumAddr)
+	if err != nil {
+		return nil, fmt.Errorf("AWS KMS: %w", err)
+	}
+	return sig, nil
+}
@@ -0,0 +1,88 @@
+package hsm
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/asn1"
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	kmstypes "github.com/aws/aws-sdk-go-v2/service/kms/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// fakeAWSKMS answers like AWS KMS does: DER signatures with either s value.
+type fakeAWSKMS struct {
+	t     *testing.T
+	key   *ecdsa.PrivateKey
+	highS bool
+}
+
+func (f *fakeAWSKMS) Sign(ctx context.Context, in *kms.SignInput, _ ...func(*kms.Options)) (*kms.SignOutput, error) {
+	require.Equal(f.t, kmstypes.MessageTypeDigest, in.MessageType)
+	sig, err := crypto.Sign(in.Message, f.key)
+	if err != nil {
+		return nil, err
+	}
+	s := new(big.Int).SetBytes(sig[32:64])
+	if f.highS {
+		s.Sub(secp256k1N, s)
+	}
+	der, err := asn1.Marshal(struct{ R, S *big.Int }{new(big.Int).SetBytes(sig[:32]), s})
+	if err != nil {
+		return nil, err
+	}
+	return &kms.SignOutput{Signature: der}, nil
+}
+
+func (f *fakeAWSKMS) GetPublicKey(ctx context.Context, in *kms.GetPublicKeyInput, _ ...func(*kms.Options)) (*kms.GetPublicKeyOutput, error) {
+	return &kms.GetPublicKeyOutput{
+		KeySpec:   kmstypes.KeySpecEccSecgP256k1,
+		PublicKey: marshalSPKI(f.t, crypto.FromECDSAPub(&f.key.PublicKey)),
+	}, nil
+}
+
+func TestAWSManagedKeySignHash(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.Nil(t, err)
+	ctx := context.Background()
+	hash := crypto.Keccak256Hash([]byte("aws"))
+
+	for _, highS := range []bool{false, true} {
+		mk, err := NewAWSManagedKey(ctx, &fakeAWSKMS{t: t, key: key, highS: highS}, "alias/test")
+		require.Nil(t, err)
+		require.Equal(t, crypto.PubkeyToAddress(key.PublicKey), mk.EthereumAddr)
+
+		sig, err := mk.SignHash(ctx, hash)
+		require.Nil(t, err)
+		pub, err := crypto.SigToPub(hash[:], sig)
+		require.Nil(t, err)
+		require.Equal(t, mk.EthereumAddr, crypto.PubkeyToAddress(*pub))
+	}
+}
+
+// Runs against local-kms or LocalStack, e.g.
+//
+//	AWS_KMS_ENDPOINT=http://localhost:4566 AWS_KMS_KEY_ID=<id> go test ./hsm -run Emulator
+func TestAWSManagedKeyEmulator(t *testing.T) {
+	endpoint, keyID := os.Getenv("AWS_KMS_ENDPOINT"), os.Getenv("AWS_KMS_KEY_ID")
+	if endpoint == "" || keyID == "" {
+		t.Skip("AWS_KMS_ENDPOINT and AWS_KMS_KEY_ID not set")
+	}
+	ctx := context.Background()
+	client, err := NewAWSKMSClient(ctx, os.Getenv("AWS_REGION"), endpoint)
+	require.Nil(t, err)
+	mk, err := NewAWSManagedKey(ctx, client, keyID)
+	require.Nil(t, err)
+
+	hash := crypto.Keccak256Hash([]byte("emulator"))
+	sig, err := mk.SignHash(ctx, hash)
+	require.Nil(t, err)
+	pub, err := crypto.SigToPub(hash[:], sig)
+	require.Nil(t, err)
+	require.Equal(t, mk.EthereumAddr, crypto.PubkeyToAddress(*pub))
+}
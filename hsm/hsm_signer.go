@@ -4,19 +4,16 @@ package hsm
 
 import (
 	"context"
-	"encoding/asn1"
 	"fmt"
 	"math/big"
 
 	kms "cloud.google.com/go/kms/apiv1"
-	btcecdsa "github.com/btcsuite/btcd/btcec/v2/ecdsa"
 	kmspb "google.golang.org/genproto/googleapis/cloud/kms/v1"
 
 	// lots of poor naming in go-ethereumcli 👾
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/crypto"
 )
 
 // ManagedKey represents a key from the Key Management Service (KMS).
@@ -32,16 +29,43 @@ type ManagedKey struct {
 }
 
 // NewManagedKey executes a fail-fast initialization.
-// Key names from the Google cloud are slash-separated paths.
+// Key names from the Google cloud are slash-separated paths. The address is
+// derived from the public key in KMS; when address is set as well it must
+// match, otherwise the first signature would fail recovery.
 func NewManagedKey(ctx context.Context, client *kms.KeyManagementClient, address string, keyName string) (*ManagedKey, error) {
-	addr := common.HexToAddress(address)
+	derived, err := GoogleKMSKeyAddress(ctx, client, keyName)
+	if err != nil {
+		return nil, err
+	}
+	if address != "" && common.HexToAddress(address) != derived {
+		return nil, fmt.Errorf("Google KMS key %s is address %s, configured hsm address %s", keyName, derived, address)
+	}
 
 	return &ManagedKey{
 		KeyName:      keyName,
-		EthereumAddr: addr,
+		EthereumAddr: derived,
+		Gclient:      client,
 	}, nil
 }
 
+// GoogleKMSKeyAddress derives the Ethereum address of a key version from its
+// public key.
+func GoogleKMSKeyAddress(ctx context.Context, client *kms.KeyManagementClient, keyName string) (common.Address, error) {
+	resp, err := client.GetPublicKey(ctx, &kmspb.GetPublicKeyRequest{Name: keyName})
+	if err != nil {
+		return common.Address{}, fmt.Errorf("Google KMS get public key: %w", err)
+	}
+	if resp.Algorithm != kmspb.CryptoKeyVersion_EC_SIGN_SECP256K1_SHA256 {
+		return common.Address{}, fmt.Errorf("Google KMS key %s has algorithm %s, want %s",
+			keyName, resp.Algorithm, kmspb.CryptoKeyVersion_EC_SIGN_SECP256K1_SHA256)
+	}
+	addr, err := PublicKeyPEMToAddress([]byte(resp.Pem))
+	if err != nil {
+		return common.Address{}, fmt.Errorf("Google KMS: %w", err)
+	}
+	return addr, nil
+}
+
 // NewEthereumTransactor returns a KMS-backed instance. Ctx applies to the
 // entire lifespan of the bind.TransactOpts.
 func (mk *ManagedKey) NewEthereumTransactorrWithChainID(ctx context.Context, chainID *big.Int) (*bind.TransactOpts, error) {
@@ -98,62 +122,13 @@ func (mk *ManagedKey) SignHash(ctx context.Context, hash common.Hash) ([]byte, e
 		},
 	}
 	resp, err := mk.Gclient.AsymmetricSign(ctx, &req)
-	if err != nil {
-		return nil, err
-	}
 	if err != nil {
 		return nil, fmt.Errorf("Google KMS asymmetric sign operation: %w", err)
 	}
 
-	// parse signature
-	var params struct{ R, S *big.Int }
-	_, err = asn1.Unmarshal(resp.Signature, &params)
+	sig, err := DERToRecoverable(resp.Signature, hash, mk.EthereumAddr)
 	if err != nil {
-		return nil, fmt.Errorf("Google KMS asymmetric signature encoding: %w", err)
-	}
-	var rLen, sLen int // byte size
-	if params.R != nil {
-		rLen = (params.R.BitLen() + 7) / 8
-	}
-	if params.S != nil {
-		sLen = (params.S.BitLen() + 7) / 8
-	}
-	if rLen == 0 || rLen > 32 || sLen == 0 || sLen > 32 {
-		return nil, fmt.Errorf("Google KMS asymmetric signature with %d-byte r and %d-byte s denied on size", rLen, sLen)
+		return nil, fmt.Errorf("Google KMS: %w", err)
 	}
-
-	// Need uncompressed signature with "recovery ID" at end:
-	// https://bitcointalk.org/index.php?topic=5249677.0
-	// https://ethereum.stackexchange.com/a/53182/39582
-	var sig [66]byte // + 1-byte header + 1-byte tailer
-	params.R.FillBytes(sig[33-rLen : 33])
-	params.S.FillBytes(sig[65-sLen : 65])
-
-	// brute force try includes KMS verification
-	var recoverErr error
-	for recoveryID := byte(0); recoveryID < 2; recoveryID++ {
-		sig[0] = recoveryID + 27 // BitCoin header
-		btcsig := sig[:65]       // exclude Ethereum 'v' parameter
-		pubKey, _, err := btcecdsa.RecoverCompact(btcsig, hash[:])
-		if err != nil {
-			recoverErr = err
-			continue
-		}
-
-		if pubKeyAddr(pubKey.SerializeUncompressed()) == mk.EthereumAddr {
-			// sign the transaction
-			sig[65] = recoveryID // Ethereum 'v' parameter
-			return sig[1:], nil  // exclude BitCoin header
-		}
-	}
-	// recoverErr can be nil, but that's OK
-	return nil, fmt.Errorf("Google KMS asymmetric signature address recovery mis: %w", recoverErr)
-}
-
-// PubKeyAddr returns the Ethereum address for (uncompressed-)key bytes.
-func pubKeyAddr(bytes []byte) common.Address {
-	digest := crypto.Keccak256(bytes[1:])
-	var addr common.Address
-	copy(addr[:], digest[12:])
-	return addr
+	return sig, nil
 }
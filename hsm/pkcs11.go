@@ -0,0 +1,179 @@
+//go:build pkcs11
+
+package hsm
+
+import (
+	"context"
+	"encoding/asn1"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/miekg/pkcs11"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// PKCS11Key represents a secp256k1 key pair on a PKCS#11 token, e.g. a
+// network HSM or SoftHSM2. Private and public key objects share KeyLabel.
+type PKCS11Key struct {
+	// Derived from the public key object at construction.
+	// This field is read-only.
+	EthereumAddr common.Address
+
+	mu      sync.Mutex // PKCS#11 sessions are not safe for concurrent use
+	p       *pkcs11.Ctx
+	session pkcs11.SessionHandle
+	priv    pkcs11.ObjectHandle
+}
+
+// NewPKCS11Key loads module, logs into the token labelled tokenLabel and
+// looks up the key pair labelled keyLabel. When address is set, it must
+// match the public key on the token.
+func NewPKCS11Key(module, tokenLabel, pin, keyLabel, address string) (*PKCS11Key, error) {
+	p := pkcs11.New(module)
+	if p == nil {
+		return nil, fmt.Errorf("PKCS#11 module %s cannot be loaded", module)
+	}
+	if err := p.Initialize(); err != nil {
+		p.Destroy()
+		return nil, fmt.Errorf("PKCS#11 initialize: %w", err)
+	}
+	k := &PKCS11Key{p: p}
+	if err := k.open(tokenLabel, pin, keyLabel); err != nil {
+		k.Close()
+		return nil, err
+	}
+	if address != "" && common.HexToAddress(address) != k.EthereumAddr {
+		k.Close()
+		return nil, fmt.Errorf("PKCS#11 key %s is address %s, configured %s", keyLabel, k.EthereumAddr, address)
+	}
+	return k, nil
+}
+
+func (k *PKCS11Key) open(tokenLabel, pin, keyLabel string) error {
+	slots, err := k.p.GetSlotList(true)
+	if err != nil {
+		return fmt.Errorf("PKCS#11 slot list: %w", err)
+	}
+	slot, found := uint(0), false
+	for _, s := range slots {
+		info, err := k.p.GetTokenInfo(s)
+		if err == nil && info.Label == tokenLabel {
+			slot, found = s, true
+			break
+		}
+	}
+	if !found {
+		return fmt.Errorf("PKCS#11 token %q not found", tokenLabel)
+	}
+
+	k.session, err = k.p.OpenSession(slot, pkcs11.CKF_SERIAL_SESSION|pkcs11.CKF_RW_SESSION)
+	if err != nil {
+		return fmt.Errorf("PKCS#11 open session: %w", err)
+	}
+	if err := k.p.Login(k.session, pkcs11.CKU_USER, pin); err != nil {
+		return fmt.Errorf("PKCS#11 login: %w", err)
+	}
+
+	if k.priv, err = k.findObject(pkcs11.CKO_PRIVATE_KEY, keyLabel); err != nil {
+		return err
+	}
+	pub, err := k.findObject(pkcs11.CKO_PUBLIC_KEY, keyLabel)
+	if err != nil {
+		return err
+	}
+	attrs, err := k.p.GetAttributeValue(k.session, pub, []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_EC_PARAMS, nil),
+		pkcs11.NewAttribute(pkcs11.CKA_EC_POINT, nil),
+	})
+	if err != nil {
+		return fmt.Errorf("PKCS#11 public key attributes: %w", err)
+	}
+	var curve asn1.ObjectIdentifier
+	if _, err := asn1.Unmarshal(attrs[0].Value, &curve); err != nil || !curve.Equal(oidSecp256k1) {
+		return fmt.Errorf("PKCS#11 key %s is not on secp256k1", keyLabel)
+	}
+	// CKA_EC_POINT is a DER OCTET STRING, though some modules return the
+	// raw point.
+	point := attrs[1].Value
+	var raw []byte
+	if rest, err := asn1.Unmarshal(point, &raw); err == nil && len(rest) == 0 {
+		point = raw
+	}
+	pubKey, err := crypto.UnmarshalPubkey(point)
+	if err != nil {
+		return fmt.Errorf("PKCS#11 public key encoding: %w", err)
+	}
+	k.EthereumAddr = crypto.PubkeyToAddress(*pubKey)
+	return nil
+}
+
+func (k *PKCS11Key) findObject(class uint, label string) (pkcs11.ObjectHandle, error) {
+	template := []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, class),
+		pkcs11.NewAttribute(pkcs11.CKA_KEY_TYPE, pkcs11.CKK_EC),
+		pkcs11.NewAttribute(pkcs11.CKA_LABEL, label),
+	}
+	if err := k.p.FindObjectsInit(k.session, template); err != nil {
+		return 0, fmt.Errorf("PKCS#11 find objects: %w", err)
+	}
+	objs, _, err := k.p.FindObjects(k.session, 1)
+	if finalErr := k.p.FindObjectsFinal(k.session); err == nil {
+		err = finalErr
+	}
+	if err != nil {
+		return 0, fmt.Errorf("PKCS#11 find objects: %w", err)
+	}
+	if len(objs) == 0 {
+		return 0, fmt.Errorf("PKCS#11 key %q (class %d) not found", label, class)
+	}
+	return objs[0], nil
+}
+
+// HealthCheck fails when the session to the token was lost.
+func (k *PKCS11Key) HealthCheck(ctx context.Context) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if _, err := k.p.GetSessionInfo(k.session); err != nil {
+		return fmt.Errorf("PKCS#11 session: %w", err)
+	}
+	return nil
+}
+
+// SignHash returns the signature bytes.
+func (k *PKCS11Key) SignHash(ctx context.Context, hash common.Hash) ([]byte, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if err := k.p.SignInit(k.session, []*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_ECDSA, nil)}, k.priv); err != nil {
+		return nil, fmt.Errorf("PKCS#11 sign init: %w", err)
+	}
+	raw, err := k.p.Sign(k.session, hash[:])
+	if err != nil {
+		return nil, fmt.Errorf("PKCS#11 sign operation: %w", err)
+	}
+	// CKM_ECDSA returns r || s, each the size of the curve order
+	if len(raw) != 64 {
+		return nil, fmt.Errorf("PKCS#11 signature of %d bytes denied on size", len(raw))
+	}
+	sig, err := RSToRecoverable(new(big.Int).SetBytes(raw[:32]), new(big.Int).SetBytes(raw[32:]), hash, k.EthereumAddr)
+	if err != nil {
+		return nil, fmt.Errorf("PKCS#11: %w", err)
+	}
+	return sig, nil
+}
+
+// Close logs out and unloads the module.
+func (k *PKCS11Key) Close() error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if k.session != 0 {
+		k.p.Logout(k.session)
+		k.p.CloseSession(k.session)
+		k.session = 0
+	}
+	k.p.Finalize()
+	k.p.Destroy()
+	return nil
+}
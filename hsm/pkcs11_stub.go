@@ -0,0 +1,35 @@
+//go:build !pkcs11
+
+package hsm
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ErrPKCS11Unsupported is returned by binaries built without cgo and the
+// pkcs11 build tag.
+var ErrPKCS11Unsupported = errors.New("PKCS#11 support not compiled in, rebuild with -tags pkcs11")
+
+// PKCS11Key is a placeholder, see pkcs11.go.
+type PKCS11Key struct {
+	EthereumAddr common.Address
+}
+
+func NewPKCS11Key(module, tokenLabel, pin, keyLabel, address string) (*PKCS11Key, error) {
+	return nil, ErrPKCS11Unsupported
+}
+
+func (k *PKCS11Key) HealthCheck(ctx context.Context) error {
+	return ErrPKCS11Unsupported
+}
+
+func (k *PKCS11Key) SignHash(ctx context.Context, hash common.Hash) ([]byte, error) {
+	return nil, ErrPKCS11Unsupported
+}
+
+func (k *PKCS11Key) Close() error {
+	return nil
+}
@@ -0,0 +1,37 @@
+//go:build pkcs11
+
+package hsm
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Runs against SoftHSM2 with a secp256k1 key pair, e.g.
+//
+//	softhsm2-util --init-token --free --label caller --pin 1234 --so-pin 1234
+//	pkcs11-tool --module $PKCS11_MODULE --token-label caller --login --pin 1234 \
+//		--keypairgen --key-type EC:secp256k1 --label caller
+//	PKCS11_MODULE=/usr/lib/softhsm/libsofthsm2.so go test -tags pkcs11 ./hsm -run PKCS11
+func TestPKCS11KeySignHash(t *testing.T) {
+	module := os.Getenv("PKCS11_MODULE")
+	if module == "" {
+		t.Skip("PKCS11_MODULE not set")
+	}
+	k, err := NewPKCS11Key(module, "caller", "1234", "caller", "")
+	require.Nil(t, err)
+	defer k.Close()
+	require.Nil(t, k.HealthCheck(context.Background()))
+
+	hash := crypto.Keccak256Hash([]byte("pkcs11"))
+	sig, err := k.SignHash(context.Background(), hash)
+	require.Nil(t, err)
+	pub, err := crypto.SigToPub(hash[:], sig)
+	require.Nil(t, err)
+	require.Equal(t, k.EthereumAddr, crypto.PubkeyToAddress(*pub))
+}
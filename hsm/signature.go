@@ -0,0 +1,129 @@
+package hsm
+
+import (
+	"crypto/ecdsa"
+	"encoding/asn1"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+
+	btcecdsa "github.com/btcsuite/btcd/btcec/v2/ecdsa"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	secp256k1N     = crypto.S256().Params().N
+	secp256k1HalfN = new(big.Int).Rsh(secp256k1N, 1)
+
+	oidPublicKeyECDSA = asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}
+	oidSecp256k1      = asn1.ObjectIdentifier{1, 3, 132, 0, 10}
+)
+
+// DERToRecoverable converts an ASN.1 DER ECDSA signature, as returned by
+// cloud KMS services, into the 65-byte [R || S || V] Ethereum signature of
+// hash made by addr.
+func DERToRecoverable(der []byte, hash common.Hash, addr common.Address) ([]byte, error) {
+	var params struct{ R, S *big.Int }
+	if _, err := asn1.Unmarshal(der, &params); err != nil {
+		return nil, fmt.Errorf("asymmetric signature encoding: %w", err)
+	}
+	return RSToRecoverable(params.R, params.S, hash, addr)
+}
+
+// RSToRecoverable normalizes (r, s) to the lower half of the curve order, as
+// Ethereum demands, and finds the recovery id that yields addr.
+func RSToRecoverable(r, s *big.Int, hash common.Hash, addr common.Address) ([]byte, error) {
+	var rLen, sLen int // byte size
+	if r != nil {
+		rLen = (r.BitLen() + 7) / 8
+	}
+	if s != nil {
+		sLen = (s.BitLen() + 7) / 8
+	}
+	if rLen == 0 || rLen > 32 || sLen == 0 || sLen > 32 {
+		return nil, fmt.Errorf("asymmetric signature with %d-byte r and %d-byte s denied on size", rLen, sLen)
+	}
+
+	// KMS services are free to return either s or n-s, only the low one is
+	// a valid Ethereum signature (EIP-2).
+	if s.Cmp(secp256k1HalfN) > 0 {
+		s = new(big.Int).Sub(secp256k1N, s)
+		sLen = (s.BitLen() + 7) / 8
+	}
+
+	// Need uncompressed signature with "recovery ID" at end:
+	// https://bitcointalk.org/index.php?topic=5249677.0
+	// https://ethereum.stackexchange.com/a/53182/39582
+	var sig [66]byte // + 1-byte header + 1-byte tailer
+	r.FillBytes(sig[33-rLen : 33])
+	s.FillBytes(sig[65-sLen : 65])
+
+	// brute force try includes KMS verification
+	var recoverErr error
+	for recoveryID := byte(0); recoveryID < 2; recoveryID++ {
+		sig[0] = recoveryID + 27 // BitCoin header
+		btcsig := sig[:65]       // exclude Ethereum 'v' parameter
+		pubKey, _, err := btcecdsa.RecoverCompact(btcsig, hash[:])
+		if err != nil {
+			recoverErr = err
+			continue
+		}
+
+		if pubKeyAddr(pubKey.SerializeUncompressed()) == addr {
+			sig[65] = recoveryID // Ethereum 'v' parameter
+			return sig[1:], nil  // exclude BitCoin header
+		}
+	}
+	// recoverErr can be nil, but that's OK
+	return nil, fmt.Errorf("asymmetric signature address recovery mis: %w", recoverErr)
+}
+
+// ParsePublicKeyDER parses a DER SubjectPublicKeyInfo holding a secp256k1
+// key, which crypto/x509 refuses since the curve is not in the standard
+// library.
+func ParsePublicKeyDER(der []byte) (*ecdsa.PublicKey, error) {
+	var spki struct {
+		Algorithm struct {
+			Algorithm  asn1.ObjectIdentifier
+			Parameters asn1.ObjectIdentifier
+		}
+		PublicKey asn1.BitString
+	}
+	rest, err := asn1.Unmarshal(der, &spki)
+	if err != nil {
+		return nil, fmt.Errorf("public key encoding: %w", err)
+	}
+	if len(rest) > 0 {
+		return nil, fmt.Errorf("public key encoding: %d trailing bytes", len(rest))
+	}
+	if !spki.Algorithm.Algorithm.Equal(oidPublicKeyECDSA) || !spki.Algorithm.Parameters.Equal(oidSecp256k1) {
+		return nil, fmt.Errorf("public key is %v on curve %v, want a secp256k1 EC key",
+			spki.Algorithm.Algorithm, spki.Algorithm.Parameters)
+	}
+	return crypto.UnmarshalPubkey(spki.PublicKey.Bytes)
+}
+
+// PublicKeyPEMToAddress derives the Ethereum address of a PEM encoded
+// secp256k1 public key.
+func PublicKeyPEMToAddress(data []byte) (common.Address, error) {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "PUBLIC KEY" {
+		return common.Address{}, errors.New("public key is not a PEM PUBLIC KEY block")
+	}
+	pub, err := ParsePublicKeyDER(block.Bytes)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return crypto.PubkeyToAddress(*pub), nil
+}
+
+// PubKeyAddr returns the Ethereum address for (uncompressed-)key bytes.
+func pubKeyAddr(bytes []byte) common.Address {
+	digest := crypto.Keccak256(bytes[1:])
+	var addr common.Address
+	copy(addr[:], digest[12:])
+	return addr
+}
@@ -0,0 +1,82 @@
+package hsm
+
+import (
+	"encoding/asn1"
+	"encoding/pem"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestDERToRecoverableNormalizesHighS(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.Nil(t, err)
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+	hash := crypto.Keccak256Hash([]byte("contracts-caller"))
+
+	sig, err := crypto.Sign(hash[:], key)
+	require.Nil(t, err)
+	r := new(big.Int).SetBytes(sig[:32])
+	s := new(big.Int).SetBytes(sig[32:64])
+
+	for _, candidate := range []*big.Int{s, new(big.Int).Sub(secp256k1N, s)} {
+		der, err := asn1.Marshal(struct{ R, S *big.Int }{r, candidate})
+		require.Nil(t, err)
+
+		got, err := DERToRecoverable(der, hash, addr)
+		require.Nil(t, err)
+		require.Equal(t, sig, got)
+	}
+
+	der, err := asn1.Marshal(struct{ R, S *big.Int }{r, s})
+	require.Nil(t, err)
+	other, err := crypto.GenerateKey()
+	require.Nil(t, err)
+	_, err = DERToRecoverable(der, hash, crypto.PubkeyToAddress(other.PublicKey))
+	require.NotNil(t, err)
+}
+
+func TestParsePublicKeyDER(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.Nil(t, err)
+
+	der := marshalSPKI(t, crypto.FromECDSAPub(&key.PublicKey))
+	pub, err := ParsePublicKeyDER(der)
+	require.Nil(t, err)
+	require.Equal(t, crypto.PubkeyToAddress(key.PublicKey), crypto.PubkeyToAddress(*pub))
+
+	_, err = ParsePublicKeyDER(der[:len(der)-1])
+	require.NotNil(t, err)
+}
+
+func marshalSPKI(t *testing.T, point []byte) []byte {
+	type algorithm struct {
+		Algorithm  asn1.ObjectIdentifier
+		Parameters asn1.ObjectIdentifier
+	}
+	der, err := asn1.Marshal(struct {
+		Algorithm algorithm
+		PublicKey asn1.BitString
+	}{
+		Algorithm: algorithm{oidPublicKeyECDSA, oidSecp256k1},
+		PublicKey: asn1.BitString{Bytes: point, BitLength: 8 * len(point)},
+	})
+	require.Nil(t, err)
+	return der
+}
+
+func TestPublicKeyPEMToAddress(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.Nil(t, err)
+
+	data := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: marshalSPKI(t, crypto.FromECDSAPub(&key.PublicKey))})
+	addr, err := PublicKeyPEMToAddress(data)
+	require.Nil(t, err)
+	require.Equal(t, crypto.PubkeyToAddress(key.PublicKey), addr)
+
+	_, err = PublicKeyPEMToAddress([]byte("not pem"))
+	require.NotNil(t, err)
+}
@@ -0,0 +1,72 @@
+package hsm
+
+import (
+	"context"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestVaultTransitKeySignHash(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.Nil(t, err)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/transit/keys/caller", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "token" {
+			http.Error(w, `{"errors":["permission denied"]}`, http.StatusForbidden)
+			return
+		}
+		w.Write([]byte(`{"data":{"type":"ecdsa-secp256k1","supports_signing":true}}`))
+	})
+	mux.HandleFunc("/v1/transit/sign/caller", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Input     string `json:"input"`
+			Prehashed bool   `json:"prehashed"`
+		}
+		require.Nil(t, json.NewDecoder(r.Body).Decode(&req))
+		require.True(t, req.Prehashed)
+		digest, err := base64.StdEncoding.DecodeString(req.Input)
+		require.Nil(t, err)
+		sig, err := crypto.Sign(digest, key)
+		require.Nil(t, err)
+		der, err := asn1.Marshal(struct{ R, S *big.Int }{
+			new(big.Int).SetBytes(sig[:32]),
+			new(big.Int).Sub(secp256k1N, new(big.Int).SetBytes(sig[32:64])),
+		})
+		require.Nil(t, err)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]string{"signature": "vault:v1:" + base64.StdEncoding.EncodeToString(der)},
+		})
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	ctx := context.Background()
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+	_, err = NewVaultTransitKey(ctx, srv.URL, "", "caller", "wrong", addr.Hex())
+	require.NotNil(t, err)
+
+	vk, err := NewVaultTransitKey(ctx, srv.URL, "", "caller", "token", addr.Hex())
+	require.Nil(t, err)
+	hash := crypto.Keccak256Hash([]byte("vault"))
+	sig, err := vk.SignHash(ctx, hash)
+	require.Nil(t, err)
+	pub, err := crypto.SigToPub(hash[:], sig)
+	require.Nil(t, err)
+	require.Equal(t, addr, crypto.PubkeyToAddress(*pub))
+
+	other, err := crypto.GenerateKey()
+	require.Nil(t, err)
+	vk.EthereumAddr = crypto.PubkeyToAddress(other.PublicKey)
+	_, err = vk.SignHash(ctx, hash)
+	require.NotNil(t, err)
+}
@@ -0,0 +1,268 @@
+package indexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	common2 "github.com/the-web3/contracts-caller/common"
+)
+
+var (
+	// ErrReorgTooDeep means no recent checkpoint is on the canonical chain
+	// anymore; the store has to be rebuilt from the start block.
+	ErrReorgTooDeep = errors.New("indexer: reorg deeper than the checkpoint history")
+	// errChunkReorged means the chain changed while a chunk was read; the
+	// chunk is retried on the next sync.
+	errChunkReorged = errors.New("indexer: chain reorged while reading a chunk")
+)
+
+// ChainClient is the part of ethclient.Client the indexer needs.
+type ChainClient interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error)
+}
+
+type Config struct {
+	Client   ChainClient
+	Contract common.Address
+	// ABI decodes the logs of Contract.
+	ABI   *abi.ABI
+	Store Store
+	// StartBlock is the first block indexed when the store is empty.
+	StartBlock uint64
+	// ChunkSize is the block range of one eth_getLogs.
+	ChunkSize uint64
+	// Confirmations keeps the indexer this many blocks behind the head.
+	Confirmations uint64
+	// PollInterval is how often new heads are looked for.
+	PollInterval time.Duration
+}
+
+// Indexer backfills the logs of a contract from a start block in chunks,
+// then follows new heads, rolling the store back to the common ancestor when
+// the chain reorgs.
+type Indexer struct {
+	cfg    Config
+	cancel func()
+	wg     sync.WaitGroup
+}
+
+func New(cfg Config) (*Indexer, error) {
+	if cfg.Client == nil || cfg.Store == nil || cfg.ABI == nil {
+		return nil, errors.New("indexer: client, store and abi are required")
+	}
+	if cfg.ChunkSize == 0 {
+		cfg.ChunkSize = 2000
+	}
+	if cfg.PollInterval == 0 {
+		cfg.PollInterval = 5 * time.Second
+	}
+	return &Indexer{cfg: cfg}, nil
+}
+
+func (ix *Indexer) Start(ctx context.Context) {
+	ctx, ix.cancel = context.WithCancel(ctx)
+	ix.wg.Add(1)
+	go ix.loop(ctx)
+}
+
+func (ix *Indexer) Stop() {
+	if ix.cancel != nil {
+		ix.cancel()
+	}
+	ix.wg.Wait()
+}
+
+func (ix *Indexer) loop(ctx context.Context) {
+	defer ix.wg.Done()
+	ticker := time.NewTicker(ix.cfg.PollInterval)
+	defer ticker.Stop()
+	for {
+		if err := ix.Sync(ctx); err != nil && ctx.Err() == nil {
+			log.Error("ContractsCaller indexer sync fail", "err", err)
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Sync indexes every block up to the head less Confirmations, first undoing
+// any reorg of the blocks already indexed.
+func (ix *Indexer) Sync(ctx context.Context) error {
+	head, err := ix.cfg.Client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if head.Number.Uint64() < ix.cfg.Confirmations {
+		return nil
+	}
+	target := head.Number.Uint64() - ix.cfg.Confirmations
+
+	next := ix.cfg.StartBlock
+	checkpoint, err := ix.cfg.Store.Checkpoint()
+	switch {
+	case errors.Is(err, ErrNoCheckpoint):
+	case err != nil:
+		return err
+	default:
+		if checkpoint, err = ix.checkReorg(ctx, checkpoint); err != nil {
+			return err
+		}
+		next = checkpoint.Number + 1
+	}
+
+	for from := next; from <= target; {
+		to := from + ix.cfg.ChunkSize - 1
+		if to > target {
+			to = target
+		}
+		count, err := ix.indexChunk(ctx, from, to)
+		if errors.Is(err, errChunkReorged) {
+			log.Warn("ContractsCaller indexer chain reorged during chunk, retrying", "from", from, "to", to)
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		log.Debug("ContractsCaller indexer indexed chunk", "from", from, "to", to, "events", count)
+		from = to + 1
+	}
+	return nil
+}
+
+// checkReorg returns checkpoint if it is still canonical, or rolls the store
+// back to the newest checkpoint that is.
+func (ix *Indexer) checkReorg(ctx context.Context, checkpoint *Checkpoint) (*Checkpoint, error) {
+	canonical, err := ix.isCanonical(ctx, *checkpoint)
+	if err != nil || canonical {
+		return checkpoint, err
+	}
+	history, err := ix.cfg.Store.History()
+	if err != nil {
+		return nil, err
+	}
+	for _, ancestor := range history {
+		if ancestor.Number >= checkpoint.Number {
+			continue
+		}
+		canonical, err := ix.isCanonical(ctx, ancestor)
+		if err != nil {
+			return nil, err
+		}
+		if canonical {
+			log.Warn("ContractsCaller indexer reorg, rolling back", "from", checkpoint.Number, "to", ancestor.Number, "hash", ancestor.Hash)
+			if err := ix.cfg.Store.Rollback(ancestor); err != nil {
+				return nil, err
+			}
+			return &ancestor, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: checkpoint %d %s", ErrReorgTooDeep, checkpoint.Number, checkpoint.Hash)
+}
+
+func (ix *Indexer) isCanonical(ctx context.Context, checkpoint Checkpoint) (bool, error) {
+	header, err := ix.cfg.Client.HeaderByNumber(ctx, new(big.Int).SetUint64(checkpoint.Number))
+	if errors.Is(err, ethereum.NotFound) {
+		// the chain got shorter than the checkpoint
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return header.Hash() == checkpoint.Hash, nil
+}
+
+// indexChunk commits the events of [from, to] with a checkpoint at to. The
+// header of to is read before and after the logs, so logs of a fork that
+// was reorged in between are never committed.
+func (ix *Indexer) indexChunk(ctx context.Context, from, to uint64) (int, error) {
+	end := new(big.Int).SetUint64(to)
+	before, err := ix.cfg.Client.HeaderByNumber(ctx, end)
+	if err != nil {
+		return 0, err
+	}
+	logs, err := ix.cfg.Client.FilterLogs(ctx, ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(from),
+		ToBlock:   end,
+		Addresses: []common.Address{ix.cfg.Contract},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("filter logs %d-%d: %w", from, to, err)
+	}
+	after, err := ix.cfg.Client.HeaderByNumber(ctx, end)
+	if err != nil {
+		return 0, err
+	}
+	if before.Hash() != after.Hash() {
+		return 0, errChunkReorged
+	}
+
+	events := make([]*Event, 0, len(logs))
+	for _, l := range logs {
+		if l.Removed {
+			continue
+		}
+		event, err := DecodeLog(ix.cfg.ABI, l)
+		if err != nil {
+			log.Warn("ContractsCaller indexer skip undecodable log", "block", l.BlockNumber, "index", l.Index, "err", err)
+			continue
+		}
+		events = append(events, event)
+	}
+	return len(events), ix.cfg.Store.Commit(events, Checkpoint{Number: to, Hash: after.Hash()})
+}
+
+// DecodeLog decodes a log of a contract with its ABI, indexed arguments
+// included.
+func DecodeLog(contractABI *abi.ABI, l types.Log) (*Event, error) {
+	if len(l.Topics) == 0 {
+		return nil, errors.New("anonymous log")
+	}
+	abiEvent, err := contractABI.EventByID(l.Topics[0])
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[string]interface{})
+	if len(l.Data) > 0 {
+		if err := abiEvent.Inputs.UnpackIntoMap(values, l.Data); err != nil {
+			return nil, fmt.Errorf("%s: %w", abiEvent.Name, err)
+		}
+	}
+	var indexed abi.Arguments
+	for _, arg := range abiEvent.Inputs {
+		if arg.Indexed {
+			indexed = append(indexed, arg)
+		}
+	}
+	if err := abi.ParseTopicsIntoMap(values, indexed, l.Topics[1:]); err != nil {
+		return nil, fmt.Errorf("%s: %w", abiEvent.Name, err)
+	}
+
+	fields := make(map[string]interface{}, len(abiEvent.Inputs))
+	for _, arg := range abiEvent.Inputs {
+		fields[arg.Name] = common2.ABIValueToJSON(arg.Type, values[arg.Name])
+	}
+	return &Event{
+		Name:        abiEvent.Name,
+		Contract:    l.Address,
+		BlockNumber: l.BlockNumber,
+		BlockHash:   l.BlockHash,
+		TxHash:      l.TxHash,
+		TxIndex:     l.TxIndex,
+		LogIndex:    l.Index,
+		Fields:      fields,
+	}, nil
+}
@@ -0,0 +1,196 @@
+package indexer_test
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/the-web3/contracts-caller/bindings"
+	"github.com/the-web3/contracts-caller/indexer"
+)
+
+var (
+	contractAddr = common.HexToAddress("0x0B306BF915C4d645ff596e518fAf3F9669b97016")
+	tokenAddr    = common.HexToAddress("0xdAC17F958D2ee523a2206206994597C13D831ec7")
+	senderAddr   = common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")
+)
+
+// fakeChain is a chain of headers, each fork telling its blocks apart by
+// Extra, with the logs of every block.
+type fakeChain struct {
+	mu      sync.Mutex
+	headers []*types.Header
+	logs    map[uint64][]types.Log
+	queries []ethereum.FilterQuery
+}
+
+func newFakeChain(length int) *fakeChain {
+	c := &fakeChain{logs: make(map[uint64][]types.Log)}
+	c.extend(length, "a")
+	return c
+}
+
+func (c *fakeChain) extend(n int, fork string) {
+	for i := 0; i < n; i++ {
+		number := uint64(len(c.headers))
+		header := &types.Header{Number: new(big.Int).SetUint64(number), Extra: []byte(fork)}
+		if number > 0 {
+			header.ParentHash = c.headers[number-1].Hash()
+		}
+		c.headers = append(c.headers, header)
+	}
+}
+
+// reorg drops every block from number on and replaces them with n blocks
+// of fork.
+func (c *fakeChain) reorg(number uint64, n int, fork string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.headers = c.headers[:number]
+	for block := range c.logs {
+		if block >= number {
+			delete(c.logs, block)
+		}
+	}
+	c.extend(n, fork)
+}
+
+func (c *fakeChain) deposit(t *testing.T, number uint64, amount int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	tmABI, err := bindings.TreasureManagerMetaData.GetAbi()
+	require.Nil(t, err)
+	event := tmABI.Events["DepositToken"]
+	data, err := abi.Arguments{event.Inputs[2]}.Pack(big.NewInt(amount))
+	require.Nil(t, err)
+	c.logs[number] = append(c.logs[number], types.Log{
+		Address:     contractAddr,
+		Topics:      []common.Hash{event.ID, common.BytesToHash(tokenAddr.Bytes()), common.BytesToHash(senderAddr.Bytes())},
+		Data:        data,
+		BlockNumber: number,
+		BlockHash:   c.headers[number].Hash(),
+		Index:       uint(len(c.logs[number])),
+	})
+}
+
+func (c *fakeChain) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if number == nil {
+		return c.headers[len(c.headers)-1], nil
+	}
+	if number.Uint64() >= uint64(len(c.headers)) {
+		return nil, ethereum.NotFound
+	}
+	return c.headers[number.Uint64()], nil
+}
+
+func (c *fakeChain) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.queries = append(c.queries, q)
+	var logs []types.Log
+	for number := q.FromBlock.Uint64(); number <= q.ToBlock.Uint64(); number++ {
+		logs = append(logs, c.logs[number]...)
+	}
+	return logs, nil
+}
+
+func newTestIndexer(t *testing.T, chain *fakeChain, store indexer.Store) *indexer.Indexer {
+	tmABI, err := bindings.TreasureManagerMetaData.GetAbi()
+	require.Nil(t, err)
+	ix, err := indexer.New(indexer.Config{
+		Client:     chain,
+		Contract:   contractAddr,
+		ABI:        tmABI,
+		Store:      store,
+		StartBlock: 1,
+		ChunkSize:  10,
+	})
+	require.Nil(t, err)
+	return ix
+}
+
+func TestIndexerBackfillsInChunks(t *testing.T) {
+	chain := newFakeChain(26)
+	chain.deposit(t, 3, 100)
+	chain.deposit(t, 3, 200)
+	chain.deposit(t, 17, 300)
+	store := indexer.NewMemoryStore()
+	ix := newTestIndexer(t, chain, store)
+
+	require.Nil(t, ix.Sync(context.Background()))
+	require.Len(t, chain.queries, 3)
+	require.Equal(t, uint64(1), chain.queries[0].FromBlock.Uint64())
+	require.Equal(t, uint64(10), chain.queries[0].ToBlock.Uint64())
+	require.Equal(t, uint64(25), chain.queries[2].ToBlock.Uint64())
+
+	events, err := store.Events(indexer.EventQuery{Name: "DepositToken"})
+	require.Nil(t, err)
+	require.Len(t, events, 3)
+	require.Equal(t, "200", events[1].Fields["amount"])
+	require.Equal(t, tokenAddr.Hex(), events[1].Fields["tokenAddress"])
+	require.Equal(t, senderAddr.Hex(), events[1].Fields["sender"])
+
+	checkpoint, err := store.Checkpoint()
+	require.Nil(t, err)
+	require.Equal(t, uint64(25), checkpoint.Number)
+
+	// nothing new, nothing read
+	require.Nil(t, ix.Sync(context.Background()))
+	require.Len(t, chain.queries, 3)
+}
+
+func TestIndexerRollsBackReorgs(t *testing.T) {
+	chain := newFakeChain(26)
+	chain.deposit(t, 5, 100)
+	chain.deposit(t, 22, 200)
+	store := indexer.NewMemoryStore()
+	ix := newTestIndexer(t, chain, store)
+	require.Nil(t, ix.Sync(context.Background()))
+
+	// blocks 21 and up are replaced, the deposit moves to block 23
+	chain.reorg(21, 6, "b")
+	chain.deposit(t, 23, 250)
+	require.Nil(t, ix.Sync(context.Background()))
+
+	events, err := store.Events(indexer.EventQuery{})
+	require.Nil(t, err)
+	require.Len(t, events, 2)
+	require.Equal(t, uint64(5), events[0].BlockNumber)
+	require.Equal(t, uint64(23), events[1].BlockNumber)
+	require.Equal(t, "250", events[1].Fields["amount"])
+
+	checkpoint, err := store.Checkpoint()
+	require.Nil(t, err)
+	require.Equal(t, uint64(26), checkpoint.Number)
+	head, _ := chain.HeaderByNumber(context.Background(), nil)
+	require.Equal(t, head.Hash(), checkpoint.Hash)
+}
+
+func TestIndexerResumesFromCheckpoint(t *testing.T) {
+	chain := newFakeChain(16)
+	chain.deposit(t, 2, 100)
+	store := indexer.NewMemoryStore()
+	require.Nil(t, newTestIndexer(t, chain, store).Sync(context.Background()))
+
+	chain.extend(5, "a")
+	chain.deposit(t, 18, 200)
+	chain.queries = nil
+	require.Nil(t, newTestIndexer(t, chain, store).Sync(context.Background()))
+	require.Len(t, chain.queries, 1)
+	require.Equal(t, uint64(16), chain.queries[0].FromBlock.Uint64())
+
+	events, err := store.Events(indexer.EventQuery{FromBlock: 10})
+	require.Nil(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, "200", events[0].Fields["amount"])
+}
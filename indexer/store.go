@@ -0,0 +1,257 @@
+package indexer
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/ethdb/leveldb"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+)
+
+var ErrNoCheckpoint = errors.New("indexer: no checkpoint")
+
+// checkpointHistory is how many past checkpoints are kept to find the common
+// ancestor after a reorg.
+const checkpointHistory = 256
+
+// Event is a decoded contract log. Fields holds the event arguments by name,
+// rendered like the query commands do: numbers as decimal strings, addresses
+// and bytes as hex.
+type Event struct {
+	Name        string                 `json:"name"`
+	Contract    common.Address         `json:"contract"`
+	BlockNumber uint64                 `json:"blockNumber"`
+	BlockHash   common.Hash            `json:"blockHash"`
+	TxHash      common.Hash            `json:"txHash"`
+	TxIndex     uint                   `json:"txIndex"`
+	LogIndex    uint                   `json:"logIndex"`
+	Fields      map[string]interface{} `json:"fields"`
+}
+
+// Checkpoint is the last block indexed.
+type Checkpoint struct {
+	Number uint64      `json:"number"`
+	Hash   common.Hash `json:"hash"`
+}
+
+// EventQuery selects events by name and block range; zero values match
+// everything.
+type EventQuery struct {
+	Name      string
+	FromBlock uint64
+	ToBlock   uint64
+}
+
+// Store persists indexed events together with the checkpoint they lead up
+// to, so a restart resumes where the last run stopped.
+type Store interface {
+	// Commit writes events and moves the checkpoint in one batch.
+	Commit(events []*Event, checkpoint Checkpoint) error
+	// Checkpoint returns the last checkpoint, or ErrNoCheckpoint.
+	Checkpoint() (*Checkpoint, error)
+	// History returns the recent checkpoints, newest first.
+	History() ([]Checkpoint, error)
+	// Rollback drops every event and checkpoint after checkpoint.Number and
+	// makes checkpoint the last one.
+	Rollback(checkpoint Checkpoint) error
+	// Events returns the events matching q, in chain order.
+	Events(q EventQuery) ([]*Event, error)
+	Close() error
+}
+
+var (
+	eventPrefix   = []byte("idx-evt-")
+	historyPrefix = []byte("idx-chk-")
+	checkpointKey = []byte("idx-checkpoint")
+)
+
+// KVStore is a Store backed by any go-ethereum key-value store.
+type KVStore struct {
+	db ethdb.KeyValueStore
+	mu sync.Mutex
+}
+
+func NewKVStore(db ethdb.KeyValueStore) *KVStore {
+	return &KVStore{db: db}
+}
+
+// NewLevelDBStore opens (or creates) an on-disk store at path.
+func NewLevelDBStore(path string) (*KVStore, error) {
+	db, err := leveldb.New(path, 16, 16, "", false)
+	if err != nil {
+		return nil, err
+	}
+	return NewKVStore(db), nil
+}
+
+// NewMemoryStore returns a store that does not survive restarts, useful for
+// tests.
+func NewMemoryStore() *KVStore {
+	return NewKVStore(memorydb.New())
+}
+
+func blockKey(prefix []byte, number uint64) []byte {
+	key := make([]byte, len(prefix)+8)
+	copy(key, prefix)
+	binary.BigEndian.PutUint64(key[len(prefix):], number)
+	return key
+}
+
+func eventKey(number uint64, logIndex uint) []byte {
+	key := blockKey(eventPrefix, number)
+	return binary.BigEndian.AppendUint32(key, uint32(logIndex))
+}
+
+func (s *KVStore) Commit(events []*Event, checkpoint Checkpoint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	batch := s.db.NewBatch()
+	for _, event := range events {
+		value, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+		if err := batch.Put(eventKey(event.BlockNumber, event.LogIndex), value); err != nil {
+			return err
+		}
+	}
+	value, err := json.Marshal(checkpoint)
+	if err != nil {
+		return err
+	}
+	if err := batch.Put(checkpointKey, value); err != nil {
+		return err
+	}
+	if err := batch.Put(blockKey(historyPrefix, checkpoint.Number), value); err != nil {
+		return err
+	}
+
+	history, err := s.history()
+	if err != nil {
+		return err
+	}
+	// history does not see the checkpoint written above yet
+	for i := checkpointHistory - 1; i < len(history); i++ {
+		if err := batch.Delete(blockKey(historyPrefix, history[i].Number)); err != nil {
+			return err
+		}
+	}
+	return batch.Write()
+}
+
+func (s *KVStore) Checkpoint() (*Checkpoint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ok, err := s.db.Has(checkpointKey)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrNoCheckpoint
+	}
+	value, err := s.db.Get(checkpointKey)
+	if err != nil {
+		return nil, err
+	}
+	var checkpoint Checkpoint
+	if err := json.Unmarshal(value, &checkpoint); err != nil {
+		return nil, err
+	}
+	return &checkpoint, nil
+}
+
+func (s *KVStore) History() ([]Checkpoint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.history()
+}
+
+func (s *KVStore) history() ([]Checkpoint, error) {
+	it := s.db.NewIterator(historyPrefix, nil)
+	defer it.Release()
+
+	var history []Checkpoint
+	for it.Next() {
+		var checkpoint Checkpoint
+		if err := json.Unmarshal(it.Value(), &checkpoint); err != nil {
+			return nil, err
+		}
+		history = append(history, checkpoint)
+	}
+	if err := it.Error(); err != nil {
+		return nil, err
+	}
+	for i, j := 0, len(history)-1; i < j; i, j = i+1, j-1 {
+		history[i], history[j] = history[j], history[i]
+	}
+	return history, nil
+}
+
+func (s *KVStore) Rollback(checkpoint Checkpoint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	batch := s.db.NewBatch()
+	for _, prefix := range [][]byte{eventPrefix, historyPrefix} {
+		it := s.db.NewIterator(prefix, blockKey(nil, checkpoint.Number+1))
+		for it.Next() {
+			if err := batch.Delete(common.CopyBytes(it.Key())); err != nil {
+				it.Release()
+				return err
+			}
+		}
+		err := it.Error()
+		it.Release()
+		if err != nil {
+			return err
+		}
+	}
+	value, err := json.Marshal(checkpoint)
+	if err != nil {
+		return err
+	}
+	if err := batch.Put(checkpointKey, value); err != nil {
+		return err
+	}
+	if err := batch.Put(blockKey(historyPrefix, checkpoint.Number), value); err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
+func (s *KVStore) Events(q EventQuery) ([]*Event, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	it := s.db.NewIterator(eventPrefix, blockKey(nil, q.FromBlock))
+	defer it.Release()
+
+	var events []*Event
+	for it.Next() {
+		var event Event
+		if err := json.Unmarshal(it.Value(), &event); err != nil {
+			return nil, err
+		}
+		if q.ToBlock != 0 && event.BlockNumber > q.ToBlock {
+			break
+		}
+		if q.Name == "" || q.Name == event.Name {
+			events = append(events, &event)
+		}
+	}
+	if err := it.Error(); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func (s *KVStore) Close() error {
+	return s.db.Close()
+}
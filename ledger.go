@@ -0,0 +1,113 @@
+package challenger
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	common2 "github.com/the-web3/contracts-caller/common"
+	"github.com/the-web3/contracts-caller/ethereumcli"
+	"github.com/the-web3/contracts-caller/indexer"
+	"github.com/the-web3/contracts-caller/ledger"
+)
+
+// LedgerCommand is `ledger check`, reconciling the events of --indexer-db
+// with the contract state at the last indexed block.
+func LedgerCommand() cli.Command {
+	return cli.Command{
+		Name:  "ledger",
+		Usage: "Reconcile the treasury accounting of the indexed events with the contract",
+		Subcommands: []cli.Command{
+			{
+				Name: "check",
+				Usage: "Print the ledger of every token and reward next to the contract state, " +
+					"failing when they disagree",
+				Action: runLedgerCheck,
+			},
+		},
+	}
+}
+
+// checkLedger replays the events of store and reconciles them with the
+// contract at the block of the store checkpoint.
+func checkLedger(ctx context.Context, store indexer.Store, client ledger.ChainClient, contract common.Address) (*ledger.Report, error) {
+	checkpoint, err := store.Checkpoint()
+	if err != nil {
+		return nil, err
+	}
+	events, err := store.Events(indexer.EventQuery{ToBlock: checkpoint.Number})
+	if err != nil {
+		return nil, err
+	}
+	l, err := ledger.Replay(checkpoint.Number, events)
+	if err != nil {
+		return nil, err
+	}
+	return ledger.Reconcile(ctx, client, contract, l)
+}
+
+func runLedgerCheck(cliCtx *cli.Context) error {
+	cfg, err := NewConfig(cliCtx)
+	if err != nil {
+		return err
+	}
+	if cfg.IndexerDB == "" {
+		return fmt.Errorf("no --indexer-db configured")
+	}
+	contractAddress, err := common2.ParseAddress(cfg.TreasureManagerContractAddress)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	chainClient, err := ethereumcli.EthClientWithTimeout(ctx, cfg.ChainRpcUrl)
+	if err != nil {
+		return err
+	}
+	defer chainClient.Close()
+	store, err := indexer.NewLevelDBStore(cfg.IndexerDB)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	report, err := checkLedger(ctx, store, chainClient, contractAddress)
+	if err != nil {
+		return err
+	}
+	if err := printJSON(report); err != nil {
+		return err
+	}
+	if n := len(report.Discrepancies); n > 0 {
+		return fmt.Errorf("%d ledger discrepancies at block %d", n, report.Block)
+	}
+	return nil
+}
+
+// runLedgerChecks checks the ledger every interval until ctx is done,
+// logging every discrepancy.
+func runLedgerChecks(ctx context.Context, interval time.Duration, store indexer.Store, client ledger.ChainClient, contract common.Address) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+		report, err := checkLedger(ctx, store, client, contract)
+		if err != nil {
+			log.Error("Contract caller ledger check fail", "err", err)
+			continue
+		}
+		for _, d := range report.Discrepancies {
+			log.Warn("Contract caller ledger discrepancy", "block", report.Block, "kind", d.Kind,
+				"token", d.Token, "user", d.User, "expected", d.Expected, "actual", d.Actual)
+		}
+		log.Info("Contract caller ledger checked", "block", report.Block, "discrepancies", len(report.Discrepancies))
+	}
+}
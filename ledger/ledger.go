@@ -0,0 +1,150 @@
+package ledger
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/the-web3/contracts-caller/indexer"
+)
+
+// Ledger is the TreasureManager accounting replayed from its events: what
+// went in and out of the treasury per token, and what was granted per token
+// and user.
+type Ledger struct {
+	// Block is the last block the events were indexed up to.
+	Block   uint64
+	Tokens  map[common.Address]*TokenEntry
+	Rewards map[RewardKey]*RewardEntry
+}
+
+type TokenEntry struct {
+	Deposited *big.Int
+	Withdrawn *big.Int
+	Granted   *big.Int
+}
+
+type RewardKey struct {
+	Token common.Address
+	User  common.Address
+}
+
+type RewardEntry struct {
+	// Grants are the amounts of every GrantRewardTokenAmount, in chain order.
+	Grants  []*big.Int
+	Granted *big.Int
+}
+
+func New(block uint64) *Ledger {
+	return &Ledger{
+		Block:   block,
+		Tokens:  make(map[common.Address]*TokenEntry),
+		Rewards: make(map[RewardKey]*RewardEntry),
+	}
+}
+
+// Replay builds the ledger of events, which must be in chain order as the
+// indexer store returns them.
+func Replay(block uint64, events []*indexer.Event) (*Ledger, error) {
+	l := New(block)
+	for _, e := range events {
+		if err := l.Apply(e); err != nil {
+			return nil, fmt.Errorf("%s in block %d: %w", e.Name, e.BlockNumber, err)
+		}
+	}
+	return l, nil
+}
+
+// Apply books one event; events that do not move funds are ignored.
+func (l *Ledger) Apply(e *indexer.Event) error {
+	switch e.Name {
+	case "DepositToken":
+		token, amount, err := tokenAmount(e)
+		if err != nil {
+			return err
+		}
+		l.token(token).Deposited.Add(l.token(token).Deposited, amount)
+	case "WithdrawToken":
+		token, amount, err := tokenAmount(e)
+		if err != nil {
+			return err
+		}
+		l.token(token).Withdrawn.Add(l.token(token).Withdrawn, amount)
+	case "GrantRewardTokenAmount":
+		token, amount, err := tokenAmount(e)
+		if err != nil {
+			return err
+		}
+		granter, err := addressField(e, "granter")
+		if err != nil {
+			return err
+		}
+		l.token(token).Granted.Add(l.token(token).Granted, amount)
+		key := RewardKey{Token: token, User: granter}
+		entry := l.Rewards[key]
+		if entry == nil {
+			entry = &RewardEntry{Granted: new(big.Int)}
+			l.Rewards[key] = entry
+		}
+		entry.Grants = append(entry.Grants, amount)
+		entry.Granted.Add(entry.Granted, amount)
+	}
+	return nil
+}
+
+// SortedTokens returns the tokens of the ledger in address order.
+func (l *Ledger) SortedTokens() []common.Address {
+	tokens := make([]common.Address, 0, len(l.Tokens))
+	for token := range l.Tokens {
+		tokens = append(tokens, token)
+	}
+	sort.Slice(tokens, func(i, j int) bool { return tokens[i].Cmp(tokens[j]) < 0 })
+	return tokens
+}
+
+// SortedRewards returns the reward keys of the ledger by token, then user.
+func (l *Ledger) SortedRewards() []RewardKey {
+	keys := make([]RewardKey, 0, len(l.Rewards))
+	for key := range l.Rewards {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if c := keys[i].Token.Cmp(keys[j].Token); c != 0 {
+			return c < 0
+		}
+		return keys[i].User.Cmp(keys[j].User) < 0
+	})
+	return keys
+}
+
+func (l *Ledger) token(token common.Address) *TokenEntry {
+	entry := l.Tokens[token]
+	if entry == nil {
+		entry = &TokenEntry{Deposited: new(big.Int), Withdrawn: new(big.Int), Granted: new(big.Int)}
+		l.Tokens[token] = entry
+	}
+	return entry
+}
+
+func tokenAmount(e *indexer.Event) (common.Address, *big.Int, error) {
+	token, err := addressField(e, "tokenAddress")
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+	s, _ := e.Fields["amount"].(string)
+	amount, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return common.Address{}, nil, fmt.Errorf("invalid amount: %v", e.Fields["amount"])
+	}
+	return token, amount, nil
+}
+
+func addressField(e *indexer.Event, name string) (common.Address, error) {
+	s, _ := e.Fields[name].(string)
+	if !common.IsHexAddress(s) {
+		return common.Address{}, fmt.Errorf("invalid %s: %v", name, e.Fields[name])
+	}
+	return common.HexToAddress(s), nil
+}
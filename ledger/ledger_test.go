@@ -0,0 +1,104 @@
+package ledger_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/the-web3/contracts-caller/indexer"
+	"github.com/the-web3/contracts-caller/ledger"
+)
+
+var (
+	ethAddr = common.HexToAddress("0xEeeeeEeeeEeEeeEeEeEeeEEEeeeeEeeeeeeeEEeE")
+	usdt    = common.HexToAddress("0xdAC17F958D2ee523a2206206994597C13D831ec7")
+	alice   = common.HexToAddress("0x70997970C51812dc3A010C7d01b54e8b0b6FA4d6")
+	bob     = common.HexToAddress("0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC")
+)
+
+func deposit(token common.Address, amount string) *indexer.Event {
+	return &indexer.Event{Name: "DepositToken", Fields: map[string]interface{}{
+		"tokenAddress": token.Hex(), "sender": alice.Hex(), "amount": amount,
+	}}
+}
+
+func withdraw(token common.Address, amount string) *indexer.Event {
+	return &indexer.Event{Name: "WithdrawToken", Fields: map[string]interface{}{
+		"tokenAddress": token.Hex(), "sender": alice.Hex(), "withdrawAddress": bob.Hex(), "amount": amount,
+	}}
+}
+
+func grant(token, user common.Address, amount string) *indexer.Event {
+	return &indexer.Event{Name: "GrantRewardTokenAmount", Fields: map[string]interface{}{
+		"tokenAddress": token.Hex(), "granter": user.Hex(), "amount": amount,
+	}}
+}
+
+func testLedger(t *testing.T) *ledger.Ledger {
+	l, err := ledger.Replay(100, []*indexer.Event{
+		deposit(ethAddr, "1000"),
+		deposit(usdt, "500"),
+		{Name: "RoleGranted"},
+		withdraw(ethAddr, "100"),
+		grant(ethAddr, alice, "30"),
+		grant(ethAddr, alice, "20"),
+		grant(ethAddr, bob, "50"),
+	})
+	require.Nil(t, err)
+	return l
+}
+
+// snapshot is the contract after alice claimed her first grant of ETH.
+func snapshot() *ledger.Snapshot {
+	return &ledger.Snapshot{
+		Block:         100,
+		ETHAddress:    ethAddr,
+		TokenBalances: map[common.Address]*big.Int{ethAddr: big.NewInt(870), usdt: big.NewInt(500)},
+		Holdings:      map[common.Address]*big.Int{ethAddr: big.NewInt(870), usdt: big.NewInt(600)},
+		Rewards: map[ledger.RewardKey]*big.Int{
+			{Token: ethAddr, User: alice}: big.NewInt(20),
+			{Token: ethAddr, User: bob}:   big.NewInt(50),
+		},
+	}
+}
+
+func TestReplay(t *testing.T) {
+	l := testLedger(t)
+	require.Equal(t, "1000", l.Tokens[ethAddr].Deposited.String())
+	require.Equal(t, "100", l.Tokens[ethAddr].Withdrawn.String())
+	require.Equal(t, "100", l.Tokens[ethAddr].Granted.String())
+	require.Equal(t, "50", l.Rewards[ledger.RewardKey{Token: ethAddr, User: alice}].Granted.String())
+
+	_, err := ledger.Replay(100, []*indexer.Event{deposit(usdt, "lots")})
+	require.NotNil(t, err)
+}
+
+func TestCompareInSync(t *testing.T) {
+	report := ledger.Compare(testLedger(t), snapshot())
+	require.Empty(t, report.Discrepancies)
+	require.Len(t, report.Tokens, 2)
+	require.Equal(t, "30", report.Tokens[1].Claimed)
+	require.Equal(t, "870", report.Tokens[1].Expected)
+}
+
+func TestCompareDiscrepancies(t *testing.T) {
+	s := snapshot()
+	// 25 is no sum of alice's latest grants
+	s.Rewards[ledger.RewardKey{Token: ethAddr, User: alice}] = big.NewInt(25)
+	s.TokenBalances[usdt] = big.NewInt(700)
+	s.Holdings[usdt] = big.NewInt(700)
+	s.Holdings[ethAddr] = big.NewInt(800)
+
+	report := ledger.Compare(testLedger(t), s)
+	kinds := map[string]int{}
+	for _, d := range report.Discrepancies {
+		kinds[d.Kind]++
+	}
+	require.Equal(t, 1, kinds[ledger.KindRewardMismatch])
+	// ETH is off by the claim inferred from 25, USDT by the 200 not deposited
+	require.Equal(t, 2, kinds[ledger.KindTokenBalanceMismatch])
+	require.Equal(t, 1, kinds[ledger.KindUnderfunded])
+}
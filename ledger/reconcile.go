@@ -0,0 +1,267 @@
+package ledger
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/the-web3/contracts-caller/bindings"
+)
+
+const (
+	// KindRewardMismatch: userRewardAmounts is not what is left of the grants
+	// after a claim, which resets it to zero.
+	KindRewardMismatch = "reward-mismatch"
+	// KindTokenBalanceMismatch: tokenBalances differs from deposits less
+	// withdrawals less claimed rewards.
+	KindTokenBalanceMismatch = "token-balance-mismatch"
+	// KindUnderfunded: the contract holds less of a token than tokenBalances
+	// says it does.
+	KindUnderfunded = "underfunded"
+)
+
+// ChainClient is the part of ethclient.Client reconciling needs.
+type ChainClient interface {
+	bind.ContractCaller
+	BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error)
+}
+
+// Snapshot is the state of the TreasureManager at one block.
+type Snapshot struct {
+	Block      uint64
+	ETHAddress common.Address
+	// TokenBalances is tokenBalances(token) of every token.
+	TokenBalances map[common.Address]*big.Int
+	// Holdings is the ETH or ERC20 balance of the contract itself.
+	Holdings map[common.Address]*big.Int
+	// Rewards is userRewardAmounts(token, user) of every reward of the ledger.
+	Rewards map[RewardKey]*big.Int
+}
+
+type Discrepancy struct {
+	Kind     string         `json:"kind"`
+	Token    common.Address `json:"token"`
+	User     string         `json:"user,omitempty"`
+	Expected string         `json:"expected"`
+	Actual   string         `json:"actual"`
+}
+
+type TokenReport struct {
+	Token     common.Address `json:"token"`
+	Deposited string         `json:"deposited"`
+	Withdrawn string         `json:"withdrawn"`
+	Granted   string         `json:"granted"`
+	Claimed   string         `json:"claimed"`
+	// Expected is deposited - withdrawn - claimed.
+	Expected     string `json:"expected"`
+	TokenBalance string `json:"tokenBalance"`
+	Holding      string `json:"holding"`
+}
+
+type RewardReport struct {
+	Token     common.Address `json:"token"`
+	User      common.Address `json:"user"`
+	Granted   string         `json:"granted"`
+	Unclaimed string         `json:"unclaimed"`
+	Claimed   string         `json:"claimed"`
+}
+
+type Report struct {
+	Block         uint64         `json:"block"`
+	Tokens        []TokenReport  `json:"tokens"`
+	Rewards       []RewardReport `json:"rewards"`
+	Discrepancies []Discrepancy  `json:"discrepancies"`
+}
+
+// Reconcile compares l with the contract at the block l was replayed up to.
+func Reconcile(ctx context.Context, client ChainClient, contract common.Address, l *Ledger) (*Report, error) {
+	snapshot, err := TakeSnapshot(ctx, client, contract, l)
+	if err != nil {
+		return nil, err
+	}
+	return Compare(l, snapshot), nil
+}
+
+// TakeSnapshot reads, at l.Block, every token of l and of the whitelist and
+// every reward of l.
+func TakeSnapshot(ctx context.Context, client ChainClient, contract common.Address, l *Ledger) (*Snapshot, error) {
+	tm, err := bindings.NewTreasureManagerCaller(contract, client)
+	if err != nil {
+		return nil, err
+	}
+	block := new(big.Int).SetUint64(l.Block)
+	opts := &bind.CallOpts{Context: ctx, BlockNumber: block}
+	ethAddress, err := tm.EthAddress(opts)
+	if err != nil {
+		return nil, fmt.Errorf("ethAddress: %w", err)
+	}
+	whitelist, err := tm.GetTokenWhiteList(opts)
+	if err != nil {
+		return nil, fmt.Errorf("getTokenWhiteList: %w", err)
+	}
+
+	s := &Snapshot{
+		Block:         l.Block,
+		ETHAddress:    ethAddress,
+		TokenBalances: make(map[common.Address]*big.Int),
+		Holdings:      make(map[common.Address]*big.Int),
+		Rewards:       make(map[RewardKey]*big.Int),
+	}
+	for _, token := range append(l.SortedTokens(), whitelist...) {
+		if _, ok := s.TokenBalances[token]; ok {
+			continue
+		}
+		if s.TokenBalances[token], err = tm.TokenBalances(opts, token); err != nil {
+			return nil, fmt.Errorf("tokenBalances(%s): %w", token, err)
+		}
+		if token == ethAddress {
+			s.Holdings[token], err = client.BalanceAt(ctx, contract, block)
+		} else {
+			s.Holdings[token], err = erc20BalanceOf(ctx, client, token, contract, block)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("balance of %s: %w", token, err)
+		}
+	}
+	for _, key := range l.SortedRewards() {
+		if s.Rewards[key], err = tm.UserRewardAmounts(opts, key.Token, key.User); err != nil {
+			return nil, fmt.Errorf("userRewardAmounts(%s, %s): %w", key.Token, key.User, err)
+		}
+	}
+	return s, nil
+}
+
+var balanceOfSelector = crypto.Keccak256([]byte("balanceOf(address)"))[:4]
+
+func erc20BalanceOf(ctx context.Context, client bind.ContractCaller, token, account common.Address, block *big.Int) (*big.Int, error) {
+	data := append(common.CopyBytes(balanceOfSelector), common.LeftPadBytes(account.Bytes(), 32)...)
+	output, err := client.CallContract(ctx, ethereum.CallMsg{To: &token, Data: data}, block)
+	if err != nil {
+		return nil, err
+	}
+	if len(output) != 32 {
+		return nil, fmt.Errorf("balanceOf returned %d bytes", len(output))
+	}
+	return new(big.Int).SetBytes(output), nil
+}
+
+// Compare checks the snapshot against the ledger.
+//
+// Claims emit no event, so what a user claimed is inferred: a claim resets
+// userRewardAmounts to zero, so the unclaimed amount must be the sum of the
+// latest grants, and the grants before were claimed and paid out of the
+// treasury.
+func Compare(l *Ledger, s *Snapshot) *Report {
+	r := &Report{Block: s.Block, Tokens: []TokenReport{}, Rewards: []RewardReport{}, Discrepancies: []Discrepancy{}}
+
+	claimed := make(map[common.Address]*big.Int)
+	for _, key := range l.SortedRewards() {
+		entry := l.Rewards[key]
+		unclaimed := s.Rewards[key]
+		if unclaimed == nil {
+			unclaimed = new(big.Int)
+		}
+		paid := new(big.Int).Sub(entry.Granted, unclaimed)
+		if !isGrantSuffix(entry.Grants, unclaimed) {
+			r.Discrepancies = append(r.Discrepancies, Discrepancy{
+				Kind:     KindRewardMismatch,
+				Token:    key.Token,
+				User:     key.User.Hex(),
+				Expected: fmt.Sprintf("sum of the latest grants, at most %s", entry.Granted),
+				Actual:   unclaimed.String(),
+			})
+		}
+		if claimed[key.Token] == nil {
+			claimed[key.Token] = new(big.Int)
+		}
+		if paid.Sign() > 0 {
+			claimed[key.Token].Add(claimed[key.Token], paid)
+		}
+		r.Rewards = append(r.Rewards, RewardReport{
+			Token:     key.Token,
+			User:      key.User,
+			Granted:   entry.Granted.String(),
+			Unclaimed: unclaimed.String(),
+			Claimed:   paid.String(),
+		})
+	}
+
+	tokens := l.SortedTokens()
+	for token := range s.TokenBalances {
+		if _, ok := l.Tokens[token]; !ok {
+			tokens = append(tokens, token)
+		}
+	}
+	sort.Slice(tokens, func(i, j int) bool { return tokens[i].Cmp(tokens[j]) < 0 })
+	for _, token := range tokens {
+		entry := l.Tokens[token]
+		if entry == nil {
+			entry = &TokenEntry{Deposited: new(big.Int), Withdrawn: new(big.Int), Granted: new(big.Int)}
+		}
+		paid := claimed[token]
+		if paid == nil {
+			paid = new(big.Int)
+		}
+		expected := new(big.Int).Sub(entry.Deposited, entry.Withdrawn)
+		expected.Sub(expected, paid)
+		balance, holding := valueOrZero(s.TokenBalances[token]), valueOrZero(s.Holdings[token])
+
+		if expected.Cmp(balance) != 0 {
+			r.Discrepancies = append(r.Discrepancies, Discrepancy{
+				Kind:     KindTokenBalanceMismatch,
+				Token:    token,
+				Expected: expected.String(),
+				Actual:   balance.String(),
+			})
+		}
+		// more than tokenBalances is fine, tokens can be sent without a deposit
+		if holding.Cmp(balance) < 0 {
+			r.Discrepancies = append(r.Discrepancies, Discrepancy{
+				Kind:     KindUnderfunded,
+				Token:    token,
+				Expected: balance.String(),
+				Actual:   holding.String(),
+			})
+		}
+		r.Tokens = append(r.Tokens, TokenReport{
+			Token:        token,
+			Deposited:    entry.Deposited.String(),
+			Withdrawn:    entry.Withdrawn.String(),
+			Granted:      entry.Granted.String(),
+			Claimed:      paid.String(),
+			Expected:     expected.String(),
+			TokenBalance: balance.String(),
+			Holding:      holding.String(),
+		})
+	}
+	return r
+}
+
+// isGrantSuffix tells whether unclaimed is the sum of the last n grants for
+// some n, zero included.
+func isGrantSuffix(grants []*big.Int, unclaimed *big.Int) bool {
+	sum := new(big.Int)
+	if sum.Cmp(unclaimed) == 0 {
+		return true
+	}
+	for i := len(grants) - 1; i >= 0; i-- {
+		sum.Add(sum, grants[i])
+		if sum.Cmp(unclaimed) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func valueOrZero(v *big.Int) *big.Int {
+	if v == nil {
+		return new(big.Int)
+	}
+	return v
+}
@@ -0,0 +1,278 @@
+package challenger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/urfave/cli"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+
+	"github.com/the-web3/contracts-caller/caller"
+)
+
+type proposalVerb struct {
+	name      string
+	method    string
+	usage     string
+	argsUsage string
+	parse     func(a *cliArgs) []interface{}
+}
+
+var proposalVerbs = []proposalVerb{
+	{"withdraw-eth", "withdrawETH", "Propose withdrawing ETH from the treasure", "<to> <amount-wei>",
+		func(a *cliArgs) []interface{} {
+			return []interface{}{a.address(0), a.amount(1)}
+		}},
+	{"withdraw-erc20", "withdrawERC20", "Propose withdrawing an ERC20 from the treasure", "<token> <to> <amount>",
+		func(a *cliArgs) []interface{} {
+			return []interface{}{a.address(0), a.address(1), a.amount(2)}
+		}},
+	{"transfer-ownership", "transferOwnership", "Propose transferring ownership of the contract", "<new-owner>",
+		func(a *cliArgs) []interface{} {
+			return []interface{}{a.address(0)}
+		}},
+	{"grant-role", "grantRole", "Propose granting a role, given by name or 32-byte hex", "<role> <account>",
+		func(a *cliArgs) []interface{} {
+			return []interface{}{a.role(0), a.address(1)}
+		}},
+	{"renounce-ownership", "renounceOwnership", "Propose leaving the contract without an owner", "",
+		func(a *cliArgs) []interface{} {
+			return nil
+		}},
+}
+
+// ProposalsCommand is `proposals create|show|sign|approve|list|execute|cancel`,
+// the M-of-N approval of the calls in caller.ProposalMethods.
+func ProposalsCommand() cli.Command {
+	create := cli.Command{
+		Name:  "create",
+		Usage: "Simulate a call with the caller wallet and record it as a proposal",
+	}
+	for _, verb := range proposalVerbs {
+		verb := verb
+		create.Subcommands = append(create.Subcommands, cli.Command{
+			Name:      verb.name,
+			Usage:     verb.usage,
+			ArgsUsage: verb.argsUsage,
+			Action: func(cliCtx *cli.Context) error {
+				return runProposalCreate(cliCtx, verb)
+			},
+		})
+	}
+	return cli.Command{
+		Name:  "proposals",
+		Usage: "Approve withdrawals, ownership and role changes by M-of-N EIP-712 signatures",
+		Subcommands: []cli.Command{
+			create,
+			{
+				Name:      "show",
+				Usage:     "Print a proposal and the EIP-712 typed data approvers sign",
+				ArgsUsage: "<id>",
+				Action:    runProposalShow,
+			},
+			{
+				Name:      "sign",
+				Usage:     "Sign a proposal with the configured wallet and record the approval",
+				ArgsUsage: "<id>",
+				Action:    runProposalSign,
+			},
+			{
+				Name:      "approve",
+				Usage:     "Record an approval signed elsewhere, e.g. with eth_signTypedData_v4",
+				ArgsUsage: "<id> <signature>",
+				Action:    runProposalApprove,
+			},
+			{
+				Name:   "list",
+				Usage:  "Print every proposal, oldest first",
+				Action: runProposalList,
+			},
+			{
+				Name:      "execute",
+				Usage:     "Send a proposal with the caller wallet once it holds --approval-threshold approvals",
+				ArgsUsage: "<id>",
+				Action:    runProposalExecute,
+			},
+			{
+				Name:      "cancel",
+				Usage:     "Cancel an open proposal",
+				ArgsUsage: "<id>",
+				Action:    runProposalCancel,
+			},
+		},
+	}
+}
+
+func openProposalStore(cfg Config) (caller.ProposalStore, error) {
+	if cfg.ProposalsDB == "" {
+		return nil, errors.New("no --proposals-db configured")
+	}
+	return caller.NewLevelDBProposalStore(cfg.ProposalsDB)
+}
+
+// withProposalStore reads the arguments of a subcommand before its config
+// and store are opened.
+func withProposalStore(cliCtx *cli.Context, argsUsage string, parse func(a *cliArgs), run func(cfg Config, store caller.ProposalStore) error) error {
+	args := &cliArgs{args: cliCtx.Args()}
+	parse(args)
+	if err := args.done(); err != nil {
+		return fmt.Errorf("%s: %w, usage: %s %s", cliCtx.Command.Name, err, cliCtx.Command.Name, argsUsage)
+	}
+	cfg, err := NewConfig(cliCtx)
+	if err != nil {
+		return err
+	}
+	store, err := openProposalStore(cfg)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+	return run(cfg, store)
+}
+
+func runProposalCreate(cliCtx *cli.Context, verb proposalVerb) error {
+	var callArgs []interface{}
+	return withProposalStore(cliCtx, verb.argsUsage, func(a *cliArgs) {
+		callArgs = verb.parse(a)
+	}, func(cfg Config, store caller.ProposalStore) error {
+		if cfg.WithdrawalPolicy != "" && (verb.method == "withdrawETH" || verb.method == "withdrawERC20") {
+			return errors.New("withdrawals within --withdrawal-policy are proposed with `withdraw propose`")
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		cCaller, closeCaller, err := newContractCaller(ctx, cfg)
+		if err != nil {
+			return err
+		}
+		defer closeCaller()
+
+		p, err := cCaller.Propose(ctx, store, verb.method, nil, callArgs...)
+		if err != nil {
+			return err
+		}
+		return printJSON(p)
+	})
+}
+
+func runProposalShow(cliCtx *cli.Context) error {
+	var id string
+	return withProposalStore(cliCtx, "<id>", func(a *cliArgs) {
+		id = a.get(0)
+	}, func(cfg Config, store caller.ProposalStore) error {
+		p, err := store.Get(id)
+		if err != nil {
+			return err
+		}
+		return printJSON(struct {
+			Proposal  *caller.Proposal   `json:"proposal"`
+			TypedData apitypes.TypedData `json:"typedData"`
+		}{p, caller.ProposalTypedData(p)})
+	})
+}
+
+func runProposalSign(cliCtx *cli.Context) error {
+	var id string
+	return withProposalStore(cliCtx, "<id>", func(a *cliArgs) {
+		id = a.get(0)
+	}, func(cfg Config, store caller.ProposalStore) error {
+		approvers, err := parseApprovers(cfg)
+		if err != nil {
+			return err
+		}
+		p, err := store.Get(id)
+		if err != nil {
+			return err
+		}
+		ctx := context.Background()
+		approverSigner, err := newSigner(ctx, cfg)
+		if err != nil {
+			return err
+		}
+		if closer, ok := approverSigner.(io.Closer); ok {
+			defer closer.Close()
+		}
+		signature, err := approverSigner.SignTypedData(ctx, caller.ProposalTypedData(p))
+		if err != nil {
+			return err
+		}
+		p, err = caller.ApproveProposal(store, id, signature, approvers)
+		if err != nil {
+			return err
+		}
+		return printJSON(p)
+	})
+}
+
+func runProposalApprove(cliCtx *cli.Context) error {
+	var id, signature string
+	return withProposalStore(cliCtx, "<id> <signature>", func(a *cliArgs) {
+		id, signature = a.get(0), a.get(1)
+	}, func(cfg Config, store caller.ProposalStore) error {
+		approvers, err := parseApprovers(cfg)
+		if err != nil {
+			return err
+		}
+		sig, err := hexutil.Decode(signature)
+		if err != nil {
+			return fmt.Errorf("invalid signature: %w", err)
+		}
+		p, err := caller.ApproveProposal(store, id, sig, approvers)
+		if err != nil {
+			return err
+		}
+		return printJSON(p)
+	})
+}
+
+func runProposalList(cliCtx *cli.Context) error {
+	return withProposalStore(cliCtx, "", func(a *cliArgs) {}, func(cfg Config, store caller.ProposalStore) error {
+		proposals, err := store.List()
+		if err != nil {
+			return err
+		}
+		if proposals == nil {
+			proposals = []*caller.Proposal{}
+		}
+		return printJSON(proposals)
+	})
+}
+
+func runProposalExecute(cliCtx *cli.Context) error {
+	var id string
+	return withProposalStore(cliCtx, "<id>", func(a *cliArgs) {
+		id = a.get(0)
+	}, func(cfg Config, store caller.ProposalStore) error {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		cCaller, closeCaller, err := newContractCaller(ctx, cfg)
+		if err != nil {
+			return err
+		}
+		defer closeCaller()
+
+		p, _, execErr := cCaller.ExecuteProposal(ctx, store, id)
+		if p != nil {
+			if err := printJSON(p); err != nil {
+				return err
+			}
+		}
+		return execErr
+	})
+}
+
+func runProposalCancel(cliCtx *cli.Context) error {
+	var id string
+	return withProposalStore(cliCtx, "<id>", func(a *cliArgs) {
+		id = a.get(0)
+	}, func(cfg Config, store caller.ProposalStore) error {
+		p, err := caller.CancelProposal(store, id)
+		if err != nil {
+			return err
+		}
+		return printJSON(p)
+	})
+}
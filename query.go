@@ -0,0 +1,293 @@
+package challenger
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/urfave/cli"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	ethc "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/the-web3/contracts-caller/bindings"
+	common2 "github.com/the-web3/contracts-caller/common"
+	"github.com/the-web3/contracts-caller/ethereumcli"
+	"github.com/the-web3/contracts-caller/flags"
+)
+
+// QueryResult is what a query subcommand read, as a table of cells every
+// output format can render.
+type QueryResult struct {
+	Query   string
+	Block   uint64
+	Columns []string
+	Rows    [][]string
+}
+
+type queryFunc func(tm *bindings.TreasureManagerCaller, opts *bind.CallOpts) ([][]string, error)
+
+type queryVerb struct {
+	name      string
+	usage     string
+	argsUsage string
+	columns   []string
+	parse     func(a *cliArgs) queryFunc
+}
+
+var queryVerbs = []queryVerb{
+	{"owner", "Owner of the contract", "", []string{"owner"},
+		func(a *cliArgs) queryFunc {
+			return func(tm *bindings.TreasureManagerCaller, opts *bind.CallOpts) ([][]string, error) {
+				return addressRow(tm.Owner(opts))
+			}
+		}},
+	{"withdraw-manager", "Withdraw manager address", "", []string{"withdrawManager"},
+		func(a *cliArgs) queryFunc {
+			return func(tm *bindings.TreasureManagerCaller, opts *bind.CallOpts) ([][]string, error) {
+				return addressRow(tm.WithdrawManager(opts))
+			}
+		}},
+	{"treasure-manager", "Treasure manager address", "", []string{"treasureManager"},
+		func(a *cliArgs) queryFunc {
+			return func(tm *bindings.TreasureManagerCaller, opts *bind.CallOpts) ([][]string, error) {
+				return addressRow(tm.TreasureManager(opts))
+			}
+		}},
+	{"token-whitelist", "Whitelisted tokens", "", []string{"index", "token"},
+		func(a *cliArgs) queryFunc {
+			return func(tm *bindings.TreasureManagerCaller, opts *bind.CallOpts) ([][]string, error) {
+				tokens, err := tm.GetTokenWhiteList(opts)
+				if err != nil {
+					return nil, err
+				}
+				rows := make([][]string, 0, len(tokens))
+				for i, token := range tokens {
+					rows = append(rows, []string{strconv.Itoa(i), token.Hex()})
+				}
+				return rows, nil
+			}
+		}},
+	{"token-balances", "Balance the treasure holds of a token", "<token>", []string{"token", "balance"},
+		func(a *cliArgs) queryFunc {
+			token := a.address(0)
+			return func(tm *bindings.TreasureManagerCaller, opts *bind.CallOpts) ([][]string, error) {
+				balance, err := tm.TokenBalances(opts, token)
+				if err != nil {
+					return nil, err
+				}
+				return [][]string{{token.Hex(), balance.String()}}, nil
+			}
+		}},
+	{"query-reward", "Reward of a token claimable by --from", "<token>", []string{"token", "account", "reward"},
+		func(a *cliArgs) queryFunc {
+			token := a.address(0)
+			return func(tm *bindings.TreasureManagerCaller, opts *bind.CallOpts) ([][]string, error) {
+				reward, err := tm.QueryReward(opts, token)
+				if err != nil {
+					return nil, err
+				}
+				return [][]string{{token.Hex(), opts.From.Hex(), reward.String()}}, nil
+			}
+		}},
+	{"user-reward", "Reward amount of a token granted to a user", "<token> <user>", []string{"token", "user", "reward"},
+		func(a *cliArgs) queryFunc {
+			token, user := a.address(0), a.address(1)
+			return func(tm *bindings.TreasureManagerCaller, opts *bind.CallOpts) ([][]string, error) {
+				reward, err := tm.UserRewardAmounts(opts, token, user)
+				if err != nil {
+					return nil, err
+				}
+				return [][]string{{token.Hex(), user.Hex(), reward.String()}}, nil
+			}
+		}},
+	{"has-role", "Whether an account has a role, given by name or 32-byte hex", "<role> <account>", []string{"role", "account", "hasRole"},
+		func(a *cliArgs) queryFunc {
+			role, account := a.role(0), a.address(1)
+			return func(tm *bindings.TreasureManagerCaller, opts *bind.CallOpts) ([][]string, error) {
+				has, err := tm.HasRole(opts, role, account)
+				if err != nil {
+					return nil, err
+				}
+				return [][]string{{ethc.Hash(role).Hex(), account.Hex(), strconv.FormatBool(has)}}, nil
+			}
+		}},
+	{"role-admin", "Admin role of a role, given by name or 32-byte hex", "<role>", []string{"role", "adminRole"},
+		func(a *cliArgs) queryFunc {
+			role := a.role(0)
+			return func(tm *bindings.TreasureManagerCaller, opts *bind.CallOpts) ([][]string, error) {
+				admin, err := tm.GetRoleAdmin(opts, role)
+				if err != nil {
+					return nil, err
+				}
+				return [][]string{{ethc.Hash(role).Hex(), ethc.Hash(admin).Hex()}}, nil
+			}
+		}},
+}
+
+func addressRow(addr ethc.Address, err error) ([][]string, error) {
+	if err != nil {
+		return nil, err
+	}
+	return [][]string{{addr.Hex()}}, nil
+}
+
+// QueryCommand is `query <view>`, reading the TreasureManager at a given
+// block without a wallet.
+func QueryCommand() cli.Command {
+	cmd := cli.Command{
+		Name:  "query",
+		Usage: "Read TreasureManager state",
+	}
+	for _, verb := range queryVerbs {
+		verb := verb
+		cmd.Subcommands = append(cmd.Subcommands, cli.Command{
+			Name:      verb.name,
+			Usage:     verb.usage,
+			ArgsUsage: verb.argsUsage,
+			Flags:     []cli.Flag{flags.QueryBlockFlag, flags.QueryOutputFlag, flags.QueryFromFlag},
+			Action: func(cliCtx *cli.Context) error {
+				return runQueryVerb(cliCtx, verb)
+			},
+		})
+	}
+	return cmd
+}
+
+func runQueryVerb(cliCtx *cli.Context, verb queryVerb) error {
+	args := &cliArgs{args: cliCtx.Args()}
+	query := verb.parse(args)
+	if err := args.done(); err != nil {
+		return fmt.Errorf("%s: %w, usage: %s %s", verb.name, err, verb.name, verb.argsUsage)
+	}
+	output := cliCtx.String(flags.QueryOutputFlag.Name)
+	if output != "json" && output != "table" && output != "csv" {
+		return fmt.Errorf("unknown output format: %v", output)
+	}
+	var from ethc.Address
+	if s := cliCtx.String(flags.QueryFromFlag.Name); s != "" {
+		addr, err := common2.ParseAddress(s)
+		if err != nil {
+			return err
+		}
+		from = addr
+	}
+
+	cfg, err := NewConfig(cliCtx)
+	if err != nil {
+		return err
+	}
+	contractAddress, err := common2.ParseAddress(cfg.TreasureManagerContractAddress)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	chainClient, err := ethereumcli.EthClientWithTimeout(ctx, cfg.ChainRpcUrl)
+	if err != nil {
+		return err
+	}
+	defer chainClient.Close()
+	tm, err := bindings.NewTreasureManagerCaller(contractAddress, chainClient)
+	if err != nil {
+		return err
+	}
+
+	block, err := ResolveBlock(ctx, chainClient, cliCtx.String(flags.QueryBlockFlag.Name))
+	if err != nil {
+		return err
+	}
+	rows, err := query(tm, &bind.CallOpts{Context: ctx, From: from, BlockNumber: block})
+	if err != nil {
+		return err
+	}
+	return WriteQueryResult(os.Stdout, output, &QueryResult{
+		Query:   verb.name,
+		Block:   block.Uint64(),
+		Columns: verb.columns,
+		Rows:    rows,
+	})
+}
+
+// ResolveBlock turns a block tag or number into a block number, so every
+// call of a query reads the same state and the output can say which.
+func ResolveBlock(ctx context.Context, client *ethclient.Client, block string) (*big.Int, error) {
+	var tag rpc.BlockNumber
+	switch block {
+	case "", "latest":
+		tag = rpc.LatestBlockNumber
+	case "safe":
+		tag = rpc.SafeBlockNumber
+	case "finalized":
+		tag = rpc.FinalizedBlockNumber
+	default:
+		number, ok := new(big.Int).SetString(block, 0)
+		if !ok || number.Sign() < 0 {
+			return nil, fmt.Errorf("invalid block: %v", block)
+		}
+		return number, nil
+	}
+	header, err := client.HeaderByNumber(ctx, big.NewInt(tag.Int64()))
+	if err != nil {
+		return nil, fmt.Errorf("resolve %s block: %w", block, err)
+	}
+	return header.Number, nil
+}
+
+// WriteQueryResult renders r as json, table or csv. Table and csv carry the
+// block in a leading column so rows of several queries can be combined.
+func WriteQueryResult(w io.Writer, output string, r *QueryResult) error {
+	switch output {
+	case "json":
+		rows := make([]map[string]string, 0, len(r.Rows))
+		for _, row := range r.Rows {
+			obj := make(map[string]string, len(row))
+			for i, cell := range row {
+				obj[r.Columns[i]] = cell
+			}
+			rows = append(rows, obj)
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(struct {
+			Query  string              `json:"query"`
+			Block  uint64              `json:"block"`
+			Result []map[string]string `json:"result"`
+		}{r.Query, r.Block, rows})
+
+	case "table":
+		tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		header := append([]string{"block"}, r.Columns...)
+		fmt.Fprintln(tw, strings.ToUpper(strings.Join(header, "\t")))
+		block := strconv.FormatUint(r.Block, 10)
+		for _, row := range r.Rows {
+			fmt.Fprintln(tw, strings.Join(append([]string{block}, row...), "\t"))
+		}
+		return tw.Flush()
+
+	case "csv":
+		cw := csv.NewWriter(w)
+		if err := cw.Write(append([]string{"block"}, r.Columns...)); err != nil {
+			return err
+		}
+		block := strconv.FormatUint(r.Block, 10)
+		for _, row := range r.Rows {
+			if err := cw.Write(append([]string{block}, row...)); err != nil {
+				return err
+			}
+		}
+		cw.Flush()
+		return cw.Error()
+
+	default:
+		return fmt.Errorf("unknown output format: %v", output)
+	}
+}
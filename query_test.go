@@ -0,0 +1,146 @@
+package challenger
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// headerService answers eth_getBlockByNumber with a header numbered after
+// the tag asked for.
+type headerService struct {
+	heads map[rpc.BlockNumber]int64
+}
+
+func (s *headerService) GetBlockByNumber(number rpc.BlockNumber, fullTx bool) (*types.Header, error) {
+	head, ok := s.heads[number]
+	if !ok {
+		return nil, errors.New("block not found")
+	}
+	return &types.Header{Number: big.NewInt(head), Difficulty: new(big.Int)}, nil
+}
+
+func headerClient(t *testing.T, heads map[rpc.BlockNumber]int64) *ethclient.Client {
+	server := rpc.NewServer()
+	require.Nil(t, server.RegisterName("eth", &headerService{heads: heads}))
+	client := ethclient.NewClient(rpc.DialInProc(server))
+	t.Cleanup(func() {
+		client.Close()
+		server.Stop()
+	})
+	return client
+}
+
+func TestResolveBlock(t *testing.T) {
+	client := headerClient(t, map[rpc.BlockNumber]int64{
+		rpc.LatestBlockNumber: 100,
+		rpc.SafeBlockNumber:   90,
+	})
+	tests := []struct {
+		block  string
+		number int64
+		err    string
+	}{
+		{block: "", number: 100},
+		{block: "latest", number: 100},
+		{block: "safe", number: 90},
+		{block: "finalized", err: "resolve finalized block: block not found"},
+		{block: "42", number: 42},
+		{block: "0x2a", number: 42},
+		{block: "pending", err: "invalid block: pending"},
+		{block: "-1", err: "invalid block: -1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.block, func(t *testing.T) {
+			number, err := ResolveBlock(context.Background(), client, tt.block)
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, big.NewInt(tt.number), number)
+		})
+	}
+
+	client = headerClient(t, map[rpc.BlockNumber]int64{rpc.FinalizedBlockNumber: 80})
+	number, err := ResolveBlock(context.Background(), client, "finalized")
+	require.Nil(t, err)
+	require.Equal(t, big.NewInt(80), number)
+}
+
+func TestWriteQueryResult(t *testing.T) {
+	result := &QueryResult{
+		Query:   "token-whitelist",
+		Block:   42,
+		Columns: []string{"index", "token"},
+		Rows: [][]string{
+			{"0", "0x1111111111111111111111111111111111111111"},
+			{"1", "0x2222222222222222222222222222222222222222"},
+		},
+	}
+	tests := []struct {
+		output string
+		want   string
+		err    string
+	}{
+		{
+			output: "json",
+			want: `{
+  "query": "token-whitelist",
+  "block": 42,
+  "result": [
+    {
+      "index": "0",
+      "token": "0x1111111111111111111111111111111111111111"
+    },
+    {
+      "index": "1",
+      "token": "0x2222222222222222222222222222222222222222"
+    }
+  ]
+}
+`,
+		},
+		{
+			output: "table",
+			want: "BLOCK  INDEX  TOKEN\n" +
+				"42     0      0x1111111111111111111111111111111111111111\n" +
+				"42     1      0x2222222222222222222222222222222222222222\n",
+		},
+		{
+			output: "csv",
+			want: "block,index,token\n" +
+				"42,0,0x1111111111111111111111111111111111111111\n" +
+				"42,1,0x2222222222222222222222222222222222222222\n",
+		},
+		{
+			output: "yaml",
+			err:    "unknown output format: yaml",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.output, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := WriteQueryResult(&buf, tt.output, result)
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, tt.want, buf.String())
+		})
+	}
+
+	// a query with no rows still says which block it read
+	var buf bytes.Buffer
+	require.Nil(t, WriteQueryResult(&buf, "json", &QueryResult{Query: "token-whitelist", Block: 7, Columns: result.Columns}))
+	require.JSONEq(t, `{"query":"token-whitelist","block":7,"result":[]}`, buf.String())
+}
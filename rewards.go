@@ -0,0 +1,131 @@
+package challenger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/the-web3/contracts-caller/bindings"
+	"github.com/the-web3/contracts-caller/caller"
+	common2 "github.com/the-web3/contracts-caller/common"
+	"github.com/the-web3/contracts-caller/ethereumcli"
+)
+
+const rewardsArgsUsage = "<schedule.csv|schedule.json>"
+
+// RewardsCommand is `rewards plan|grant`, granting a reward schedule
+// exactly once per entry, as recorded in --rewards-db.
+func RewardsCommand() cli.Command {
+	return cli.Command{
+		Name:  "rewards",
+		Usage: "Grant a reward schedule of token,granter,amount,epoch entries",
+		Subcommands: []cli.Command{
+			{
+				Name:      "plan",
+				Usage:     "Validate the schedule and print what grant would do, without a wallet",
+				ArgsUsage: rewardsArgsUsage,
+				Action:    runRewardsPlan,
+			},
+			{
+				Name:      "grant",
+				Usage:     "Grant every entry not granted yet with the caller wallet, recording each in --rewards-db",
+				ArgsUsage: rewardsArgsUsage,
+				Action:    runRewardsGrant,
+			},
+		},
+	}
+}
+
+func loadRewardScheduleArg(cliCtx *cli.Context) ([]*caller.RewardEntry, error) {
+	args := &cliArgs{args: cliCtx.Args()}
+	path := args.get(0)
+	if err := args.done(); err != nil {
+		return nil, fmt.Errorf("%s: %w, usage: %s %s", cliCtx.Command.Name, err, cliCtx.Command.Name, rewardsArgsUsage)
+	}
+	return caller.LoadRewardSchedule(path)
+}
+
+// openRewardStore opens --rewards-db, or an empty store when it is not set
+// and required is false.
+func openRewardStore(cfg Config, required bool) (caller.RewardStore, error) {
+	if cfg.RewardsDB == "" {
+		if required {
+			return nil, errors.New("no --rewards-db configured, it is what keeps grants from being repeated")
+		}
+		return caller.NewMemoryRewardStore(), nil
+	}
+	return caller.NewLevelDBRewardStore(cfg.RewardsDB)
+}
+
+func runRewardsPlan(cliCtx *cli.Context) error {
+	entries, err := loadRewardScheduleArg(cliCtx)
+	if err != nil {
+		return err
+	}
+	cfg, err := NewConfig(cliCtx)
+	if err != nil {
+		return err
+	}
+	contractAddress, err := common2.ParseAddress(cfg.TreasureManagerContractAddress)
+	if err != nil {
+		return err
+	}
+	store, err := openRewardStore(cfg, false)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+	ctx := context.Background()
+	chainClient, err := ethereumcli.EthClientWithTimeout(ctx, cfg.ChainRpcUrl)
+	if err != nil {
+		return err
+	}
+	defer chainClient.Close()
+	tm, err := bindings.NewTreasureManagerCaller(contractAddress, chainClient)
+	if err != nil {
+		return err
+	}
+
+	plan, err := caller.PlanRewards(ctx, tm, store, entries)
+	if err != nil {
+		return err
+	}
+	return printJSON(plan)
+}
+
+func runRewardsGrant(cliCtx *cli.Context) error {
+	entries, err := loadRewardScheduleArg(cliCtx)
+	if err != nil {
+		return err
+	}
+	cfg, err := NewConfig(cliCtx)
+	if err != nil {
+		return err
+	}
+	store, err := openRewardStore(cfg, true)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cCaller, closeCaller, err := newContractCaller(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer closeCaller()
+
+	plan, records, grantErr := cCaller.GrantRewardSchedule(ctx, store, entries)
+	if records == nil {
+		records = []*caller.RewardRecord{}
+	}
+	if err := printJSON(struct {
+		Plan    *caller.RewardPlan     `json:"plan"`
+		Records []*caller.RewardRecord `json:"records"`
+	}{plan, records}); err != nil {
+		return err
+	}
+	return grantErr
+}
@@ -0,0 +1,108 @@
+package challenger
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/the-web3/contracts-caller/caller"
+	common2 "github.com/the-web3/contracts-caller/common"
+	"github.com/the-web3/contracts-caller/ethereumcli"
+)
+
+const rolesArgsUsage = "<manifest.yaml>"
+
+// RolesCommand is `roles plan|apply`, bringing the AccessControl roles and
+// the owner of the TreasureManager to a manifest.
+func RolesCommand() cli.Command {
+	return cli.Command{
+		Name:  "roles",
+		Usage: "Reconcile TreasureManager roles and ownership with a manifest",
+		Subcommands: []cli.Command{
+			{
+				Name:      "plan",
+				Usage:     "Print the current members and the grants, revocations and ownership transfer needed, without a wallet",
+				ArgsUsage: rolesArgsUsage,
+				Action:    runRolesPlan,
+			},
+			{
+				Name:      "apply",
+				Usage:     "Send the changes of the plan with the caller wallet, in order",
+				ArgsUsage: rolesArgsUsage,
+				Action:    runRolesApply,
+			},
+		},
+	}
+}
+
+func loadRolesManifestArg(cliCtx *cli.Context) (*caller.RolesManifest, error) {
+	args := &cliArgs{args: cliCtx.Args()}
+	path := args.get(0)
+	if err := args.done(); err != nil {
+		return nil, fmt.Errorf("%s: %w, usage: %s %s", cliCtx.Command.Name, err, cliCtx.Command.Name, rolesArgsUsage)
+	}
+	return caller.LoadRolesManifest(path)
+}
+
+func runRolesPlan(cliCtx *cli.Context) error {
+	manifest, err := loadRolesManifestArg(cliCtx)
+	if err != nil {
+		return err
+	}
+	cfg, err := NewConfig(cliCtx)
+	if err != nil {
+		return err
+	}
+	contractAddress, err := common2.ParseAddress(cfg.TreasureManagerContractAddress)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	chainClient, err := ethereumcli.EthClientWithTimeout(ctx, cfg.ChainRpcUrl)
+	if err != nil {
+		return err
+	}
+	defer chainClient.Close()
+
+	plan, err := caller.PlanRoles(ctx, chainClient, contractAddress, manifest)
+	if err != nil {
+		return err
+	}
+	return printJSON(plan)
+}
+
+func runRolesApply(cliCtx *cli.Context) error {
+	manifest, err := loadRolesManifestArg(cliCtx)
+	if err != nil {
+		return err
+	}
+	cfg, err := NewConfig(cliCtx)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cCaller, closeCaller, err := newContractCaller(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer closeCaller()
+
+	plan, err := caller.PlanRoles(ctx, cCaller.Cfg.ChainClient, cCaller.Cfg.TreasureManagerAddr, manifest)
+	if err != nil {
+		return err
+	}
+	receipts, applyErr := cCaller.ApplyRolesPlan(ctx, plan)
+	methods := make([]string, len(plan.Changes))
+	for i, change := range plan.Changes {
+		methods[i] = change.Method()
+	}
+	if err := printJSON(struct {
+		Plan    *caller.RolesPlan `json:"plan"`
+		Results []TxResult        `json:"results"`
+	}{plan, batchTxResults(methods, cCaller.WalletAddr, cCaller.Cfg.TreasureManagerAddr, receipts, applyErr)}); err != nil {
+		return err
+	}
+	return applyErr
+}
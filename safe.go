@@ -0,0 +1,217 @@
+package challenger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/urfave/cli"
+
+	ethc "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+
+	"github.com/the-web3/contracts-caller/bindings"
+	"github.com/the-web3/contracts-caller/caller"
+	"github.com/the-web3/contracts-caller/ethereumcli"
+)
+
+// SafeCommand is `safe list|show|sign|approve|execute`, collecting owner
+// signatures of the Safe transactions queued in --safe-address mode.
+func SafeCommand() cli.Command {
+	return cli.Command{
+		Name:  "safe",
+		Usage: "Sign and execute the TreasureManager calls queued for the Safe owning it",
+		Subcommands: []cli.Command{
+			{
+				Name:   "list",
+				Usage:  "Print every queued Safe transaction, by nonce",
+				Action: runSafeList,
+			},
+			{
+				Name:      "show",
+				Usage:     "Print a Safe transaction and the EIP-712 typed data owners sign",
+				ArgsUsage: "<safe-tx-hash>",
+				Action:    runSafeShow,
+			},
+			{
+				Name:      "sign",
+				Usage:     "Sign a Safe transaction with the configured wallet, one of the Safe owners",
+				ArgsUsage: "<safe-tx-hash>",
+				Action:    runSafeSign,
+			},
+			{
+				Name:      "approve",
+				Usage:     "Record an owner signature made elsewhere, e.g. with eth_signTypedData_v4",
+				ArgsUsage: "<safe-tx-hash> <signature>",
+				Action:    runSafeApprove,
+			},
+			{
+				Name:      "execute",
+				Usage:     "Send execTransaction for a Safe transaction holding enough owner signatures",
+				ArgsUsage: "<safe-tx-hash>",
+				Action:    runSafeExecute,
+			},
+		},
+	}
+}
+
+func (a *cliArgs) hash(i int) ethc.Hash {
+	s := a.get(i)
+	b, err := hexutil.Decode(s)
+	if err != nil || len(b) != ethc.HashLength {
+		a.fail(fmt.Errorf("invalid hash: %v", s))
+		return ethc.Hash{}
+	}
+	return ethc.BytesToHash(b)
+}
+
+func openSafeTxStore(cfg Config) (caller.SafeTxStore, error) {
+	if cfg.SafeDB == "" {
+		return nil, errors.New("no --safe-db configured")
+	}
+	return caller.NewLevelDBSafeTxStore(cfg.SafeDB)
+}
+
+// withSafeTxStore reads the arguments of a subcommand before its config
+// and store are opened.
+func withSafeTxStore(cliCtx *cli.Context, argsUsage string, parse func(a *cliArgs), run func(cfg Config, store caller.SafeTxStore) error) error {
+	args := &cliArgs{args: cliCtx.Args()}
+	parse(args)
+	if err := args.done(); err != nil {
+		return fmt.Errorf("%s: %w, usage: %s %s", cliCtx.Command.Name, err, cliCtx.Command.Name, argsUsage)
+	}
+	cfg, err := NewConfig(cliCtx)
+	if err != nil {
+		return err
+	}
+	store, err := openSafeTxStore(cfg)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+	return run(cfg, store)
+}
+
+// approveSafeTx records signature once the chain confirms its signer owns
+// the Safe of the transaction.
+func approveSafeTx(ctx context.Context, cfg Config, store caller.SafeTxStore, safeTxHash ethc.Hash, signature []byte) error {
+	tx, err := store.Get(safeTxHash)
+	if err != nil {
+		return err
+	}
+	chainClient, err := ethereumcli.EthClientWithTimeout(ctx, cfg.ChainRpcUrl)
+	if err != nil {
+		return err
+	}
+	defer chainClient.Close()
+	safe, err := bindings.NewSafeCaller(tx.Safe, chainClient)
+	if err != nil {
+		return err
+	}
+	tx, err = caller.ApproveSafeTx(ctx, safe, store, safeTxHash, signature)
+	if err != nil {
+		return err
+	}
+	return printJSON(tx)
+}
+
+func runSafeList(cliCtx *cli.Context) error {
+	return withSafeTxStore(cliCtx, "", func(a *cliArgs) {}, func(cfg Config, store caller.SafeTxStore) error {
+		txs, err := store.List()
+		if err != nil {
+			return err
+		}
+		if txs == nil {
+			txs = []*caller.SafeTx{}
+		}
+		return printJSON(txs)
+	})
+}
+
+func runSafeShow(cliCtx *cli.Context) error {
+	var safeTxHash ethc.Hash
+	return withSafeTxStore(cliCtx, "<safe-tx-hash>", func(a *cliArgs) {
+		safeTxHash = a.hash(0)
+	}, func(cfg Config, store caller.SafeTxStore) error {
+		tx, err := store.Get(safeTxHash)
+		if err != nil {
+			return err
+		}
+		return printJSON(struct {
+			SafeTx    *caller.SafeTx     `json:"safeTx"`
+			TypedData apitypes.TypedData `json:"typedData"`
+		}{tx, caller.SafeTxTypedData(tx)})
+	})
+}
+
+func runSafeSign(cliCtx *cli.Context) error {
+	var safeTxHash ethc.Hash
+	return withSafeTxStore(cliCtx, "<safe-tx-hash>", func(a *cliArgs) {
+		safeTxHash = a.hash(0)
+	}, func(cfg Config, store caller.SafeTxStore) error {
+		tx, err := store.Get(safeTxHash)
+		if err != nil {
+			return err
+		}
+		ctx := context.Background()
+		ownerSigner, err := newSigner(ctx, cfg)
+		if err != nil {
+			return err
+		}
+		if closer, ok := ownerSigner.(io.Closer); ok {
+			defer closer.Close()
+		}
+		signature, err := ownerSigner.SignTypedData(ctx, caller.SafeTxTypedData(tx))
+		if err != nil {
+			return err
+		}
+		return approveSafeTx(ctx, cfg, store, safeTxHash, signature)
+	})
+}
+
+func runSafeApprove(cliCtx *cli.Context) error {
+	var safeTxHash ethc.Hash
+	var signature string
+	return withSafeTxStore(cliCtx, "<safe-tx-hash> <signature>", func(a *cliArgs) {
+		safeTxHash, signature = a.hash(0), a.get(1)
+	}, func(cfg Config, store caller.SafeTxStore) error {
+		sig, err := hexutil.Decode(signature)
+		if err != nil {
+			return fmt.Errorf("invalid signature: %w", err)
+		}
+		return approveSafeTx(context.Background(), cfg, store, safeTxHash, sig)
+	})
+}
+
+func runSafeExecute(cliCtx *cli.Context) error {
+	args := &cliArgs{args: cliCtx.Args()}
+	safeTxHash := args.hash(0)
+	if err := args.done(); err != nil {
+		return fmt.Errorf("execute: %w, usage: execute <safe-tx-hash>", err)
+	}
+	cfg, err := NewConfig(cliCtx)
+	if err != nil {
+		return err
+	}
+	if cfg.SafeAddress == "" || cfg.SafeDB == "" {
+		return errors.New("execute needs --safe-address and --safe-db")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	// the caller opens --safe-db itself
+	cCaller, closeCaller, err := newContractCaller(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer closeCaller()
+
+	tx, _, execErr := cCaller.ExecuteSafeTx(ctx, safeTxHash)
+	if tx != nil {
+		if err := printJSON(tx); err != nil {
+			return err
+		}
+	}
+	return execErr
+}
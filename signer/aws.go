@@ -0,0 +1,51 @@
+package signer
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+
+	"github.com/the-web3/contracts-caller/hsm"
+)
+
+// AWSKMSSigner signs with an ECC_SECG_P256K1 key held in AWS KMS.
+type AWSKMSSigner struct {
+	mk *hsm.AWSManagedKey
+}
+
+func NewAWSKMSSigner(mk *hsm.AWSManagedKey) *AWSKMSSigner {
+	return &AWSKMSSigner{mk: mk}
+}
+
+// NewAWSKMSSignerFromKeyID uses the default AWS credential chain. endpoint
+// may point at local-kms or LocalStack for testing.
+func NewAWSKMSSignerFromKeyID(ctx context.Context, keyID, region, endpoint string) (*AWSKMSSigner, error) {
+	client, err := hsm.NewAWSKMSClient(ctx, region, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	mk, err := hsm.NewAWSManagedKey(ctx, client, keyID)
+	if err != nil {
+		return nil, err
+	}
+	return NewAWSKMSSigner(mk), nil
+}
+
+func (s *AWSKMSSigner) Address() common.Address {
+	return s.mk.EthereumAddr
+}
+
+func (s *AWSKMSSigner) SignHash(ctx context.Context, hash common.Hash) ([]byte, error) {
+	return s.mk.SignHash(ctx, hash)
+}
+
+func (s *AWSKMSSigner) SignTx(ctx context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return signTx(ctx, s, tx, chainID)
+}
+
+func (s *AWSKMSSigner) SignTypedData(ctx context.Context, typedData apitypes.TypedData) ([]byte, error) {
+	return signTypedData(ctx, s, typedData)
+}
@@ -0,0 +1,117 @@
+package signer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	common2 "github.com/the-web3/contracts-caller/common"
+	"github.com/the-web3/contracts-caller/hsm"
+)
+
+type Config struct {
+	Keystore             string
+	KeystorePasswordFile string
+
+	PrivateKey string
+	Mnemonic   string
+	HDPath     string
+	Passphrase string
+	// AllowPlaintextKey opts in to PrivateKey and Mnemonic, which are only
+	// meant for dev chains.
+	AllowPlaintextKey bool
+
+	EnableHsm  bool
+	HsmAPIName string
+	// HsmAddress is the expected address of whichever remote key is used.
+	HsmAddress string
+	HsmCreden  string
+
+	AWSKMSKeyID    string
+	AWSKMSRegion   string
+	AWSKMSEndpoint string
+
+	VaultAddr    string
+	VaultToken   string
+	VaultMount   string
+	VaultKeyName string
+
+	PKCS11Module     string
+	PKCS11TokenLabel string
+	PKCS11Pin        string
+	PKCS11KeyLabel   string
+}
+
+// New picks the signer backend from cfg; it is meant to be called once at
+// startup and the result shared by everything that signs.
+func New(ctx context.Context, cfg Config) (Signer, error) {
+	useMnemonic := cfg.Mnemonic != "" && cfg.HDPath != ""
+	usePrivKeyStr := cfg.PrivateKey != ""
+
+	useAWS := cfg.AWSKMSKeyID != ""
+	useVault := cfg.VaultKeyName != ""
+	usePKCS11 := cfg.PKCS11Module != ""
+	remotes := 0
+	for _, use := range []bool{cfg.EnableHsm, useAWS, useVault, usePKCS11} {
+		if use {
+			remotes++
+		}
+	}
+
+	switch {
+	case remotes > 1:
+		return nil, ErrConflictingSigners
+
+	case remotes == 1:
+		s, err := newRemote(ctx, cfg)
+		if err != nil {
+			return nil, err
+		}
+		if cfg.HsmAddress != "" && s.Address() != common.HexToAddress(cfg.HsmAddress) {
+			return nil, fmt.Errorf("signer: remote key is address %s, configured %s", s.Address(), cfg.HsmAddress)
+		}
+		return s, nil
+
+	case (useMnemonic || usePrivKeyStr) && !cfg.AllowPlaintextKey:
+		return nil, ErrPlaintextKey
+
+	case useMnemonic || usePrivKeyStr || cfg.Keystore != "":
+		key, err := common2.GetConfiguredPrivateKey(cfg.Mnemonic, cfg.HDPath, cfg.PrivateKey, cfg.Passphrase,
+			cfg.Keystore, cfg.KeystorePasswordFile)
+		if err != nil {
+			return nil, err
+		}
+		return NewPrivateKeySigner(key), nil
+
+	default:
+		return nil, ErrNoSignerConfigured
+	}
+}
+
+func newRemote(ctx context.Context, cfg Config) (Signer, error) {
+	switch {
+	case cfg.AWSKMSKeyID != "":
+		return NewAWSKMSSignerFromKeyID(ctx, cfg.AWSKMSKeyID, cfg.AWSKMSRegion, cfg.AWSKMSEndpoint)
+
+	case cfg.VaultKeyName != "":
+		if cfg.HsmAddress == "" {
+			return nil, fmt.Errorf("signer: the Vault transit key needs its hsm address configured")
+		}
+		vk, err := hsm.NewVaultTransitKey(ctx, cfg.VaultAddr, cfg.VaultMount, cfg.VaultKeyName, cfg.VaultToken, cfg.HsmAddress)
+		if err != nil {
+			return nil, err
+		}
+		return NewVaultSigner(vk), nil
+
+	case cfg.PKCS11Module != "":
+		k, err := hsm.NewPKCS11Key(cfg.PKCS11Module, cfg.PKCS11TokenLabel, cfg.PKCS11Pin, cfg.PKCS11KeyLabel, cfg.HsmAddress)
+		if err != nil {
+			return nil, err
+		}
+		return NewPKCS11Signer(k), nil
+
+	default:
+		return NewGoogleKMSSigner(ctx, cfg.HsmAPIName, cfg.HsmAddress, cfg.HsmCreden)
+	}
+}
@@ -0,0 +1,72 @@
+package signer
+
+import (
+	"context"
+	"encoding/hex"
+	"io"
+	"math/big"
+
+	kms "cloud.google.com/go/kms/apiv1"
+	"google.golang.org/api/option"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+
+	"github.com/the-web3/contracts-caller/hsm"
+)
+
+// KMSSigner signs with a key held in Google Cloud KMS.
+type KMSSigner struct {
+	mk *hsm.ManagedKey
+}
+
+var _ io.Closer = (*KMSSigner)(nil)
+
+func NewKMSSigner(mk *hsm.ManagedKey) *KMSSigner {
+	return &KMSSigner{mk: mk}
+}
+
+// NewGoogleKMSSigner creates the KMS client once for the lifetime of the
+// signer. hsmCreden is the hex encoded service account JSON. The address is
+// derived from the key; a non-empty address must agree with it.
+func NewGoogleKMSSigner(ctx context.Context, keyName, address, hsmCreden string) (*KMSSigner, error) {
+	credentials, err := hex.DecodeString(hsmCreden)
+	if err != nil {
+		return nil, err
+	}
+	client, err := kms.NewKeyManagementClient(ctx, option.WithCredentialsJSON(credentials))
+	if err != nil {
+		return nil, err
+	}
+	mk, err := hsm.NewManagedKey(ctx, client, address, keyName)
+	if err != nil {
+		client.Close()
+		return nil, err
+	}
+	return NewKMSSigner(mk), nil
+}
+
+func (s *KMSSigner) Address() common.Address {
+	return s.mk.EthereumAddr
+}
+
+func (s *KMSSigner) SignHash(ctx context.Context, hash common.Hash) ([]byte, error) {
+	return s.mk.SignHash(ctx, hash)
+}
+
+func (s *KMSSigner) SignTx(ctx context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return signTx(ctx, s, tx, chainID)
+}
+
+func (s *KMSSigner) SignTypedData(ctx context.Context, typedData apitypes.TypedData) ([]byte, error) {
+	return signTypedData(ctx, s, typedData)
+}
+
+// Close closes the KMS client of the key.
+func (s *KMSSigner) Close() error {
+	if s.mk.Gclient == nil {
+		return nil
+	}
+	return s.mk.Gclient.Close()
+}
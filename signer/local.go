@@ -0,0 +1,52 @@
+package signer
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+
+	common2 "github.com/the-web3/contracts-caller/common"
+)
+
+// PrivateKeySigner signs with an in-memory secp256k1 key.
+type PrivateKeySigner struct {
+	key  *ecdsa.PrivateKey
+	addr common.Address
+}
+
+func NewPrivateKeySigner(key *ecdsa.PrivateKey) *PrivateKeySigner {
+	return &PrivateKeySigner{
+		key:  key,
+		addr: crypto.PubkeyToAddress(key.PublicKey),
+	}
+}
+
+// NewMnemonicSigner derives the key at hdPath from a BIP-39 mnemonic.
+func NewMnemonicSigner(mnemonic, hdPath, password string) (*PrivateKeySigner, error) {
+	key, err := common2.DerivePrivateKey(mnemonic, hdPath, password)
+	if err != nil {
+		return nil, err
+	}
+	return NewPrivateKeySigner(key), nil
+}
+
+func (s *PrivateKeySigner) Address() common.Address {
+	return s.addr
+}
+
+func (s *PrivateKeySigner) SignHash(ctx context.Context, hash common.Hash) ([]byte, error) {
+	return crypto.Sign(hash[:], s.key)
+}
+
+func (s *PrivateKeySigner) SignTx(ctx context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return signTx(ctx, s, tx, chainID)
+}
+
+func (s *PrivateKeySigner) SignTypedData(ctx context.Context, typedData apitypes.TypedData) ([]byte, error) {
+	return signTypedData(ctx, s, typedData)
+}
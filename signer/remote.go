@@ -0,0 +1,75 @@
+package signer
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+
+	"github.com/the-web3/contracts-caller/hsm"
+)
+
+// VaultSigner signs with a key in a HashiCorp Vault transit engine.
+type VaultSigner struct {
+	vk *hsm.VaultTransitKey
+}
+
+func NewVaultSigner(vk *hsm.VaultTransitKey) *VaultSigner {
+	return &VaultSigner{vk: vk}
+}
+
+func (s *VaultSigner) Address() common.Address {
+	return s.vk.EthereumAddr
+}
+
+func (s *VaultSigner) HealthCheck(ctx context.Context) error {
+	return s.vk.HealthCheck(ctx)
+}
+
+func (s *VaultSigner) SignHash(ctx context.Context, hash common.Hash) ([]byte, error) {
+	return s.vk.SignHash(ctx, hash)
+}
+
+func (s *VaultSigner) SignTx(ctx context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return signTx(ctx, s, tx, chainID)
+}
+
+func (s *VaultSigner) SignTypedData(ctx context.Context, typedData apitypes.TypedData) ([]byte, error) {
+	return signTypedData(ctx, s, typedData)
+}
+
+// PKCS11Signer signs with a key on a PKCS#11 token. It holds a session
+// open and must be closed.
+type PKCS11Signer struct {
+	k *hsm.PKCS11Key
+}
+
+func NewPKCS11Signer(k *hsm.PKCS11Key) *PKCS11Signer {
+	return &PKCS11Signer{k: k}
+}
+
+func (s *PKCS11Signer) Address() common.Address {
+	return s.k.EthereumAddr
+}
+
+func (s *PKCS11Signer) HealthCheck(ctx context.Context) error {
+	return s.k.HealthCheck(ctx)
+}
+
+func (s *PKCS11Signer) SignHash(ctx context.Context, hash common.Hash) ([]byte, error) {
+	return s.k.SignHash(ctx, hash)
+}
+
+func (s *PKCS11Signer) SignTx(ctx context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return signTx(ctx, s, tx, chainID)
+}
+
+func (s *PKCS11Signer) SignTypedData(ctx context.Context, typedData apitypes.TypedData) ([]byte, error) {
+	return signTypedData(ctx, s, typedData)
+}
+
+func (s *PKCS11Signer) Close() error {
+	return s.k.Close()
+}
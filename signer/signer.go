@@ -0,0 +1,115 @@
+// Package signer hides where the caller wallet key lives behind one
+// interface, so backends can be added without touching the caller.
+package signer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+var (
+	ErrNoSignerConfigured = errors.New("signer: no key configured, set a keystore, a remote key, or for dev chains a private key or mnemonic")
+	ErrPlaintextKey       = errors.New("signer: plaintext private keys and mnemonics are for dev chains only, opt in with allow-plaintext-key")
+	ErrConflictingSigners = errors.New("signer: more than one of Google Cloud hsm, AWS KMS, Vault and PKCS#11 is configured")
+)
+
+// Signer signs on behalf of a single Ethereum account.
+type Signer interface {
+	// Address is the account the signer signs for.
+	Address() common.Address
+	// SignTx signs tx with the latest signer for chainID.
+	SignTx(ctx context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error)
+	// SignHash returns a 65-byte [R || S || V] signature of hash, V being 0
+	// or 1 as in crypto.Sign.
+	SignHash(ctx context.Context, hash common.Hash) ([]byte, error)
+	// SignTypedData returns the EIP-712 signature of typedData, V being 27
+	// or 28.
+	SignTypedData(ctx context.Context, typedData apitypes.TypedData) ([]byte, error)
+}
+
+// HealthChecker is implemented by backends whose key lives in a remote
+// service or device.
+type HealthChecker interface {
+	HealthCheck(ctx context.Context) error
+}
+
+// Check makes sure s is usable before anything is sent with it: the backend
+// must be reachable and a probe signature must recover to s.Address().
+func Check(ctx context.Context, s Signer) error {
+	if hc, ok := s.(HealthChecker); ok {
+		if err := hc.HealthCheck(ctx); err != nil {
+			return fmt.Errorf("signer health check: %w", err)
+		}
+	}
+	probe := crypto.Keccak256Hash([]byte("contracts-caller signer probe"), s.Address().Bytes())
+	sig, err := s.SignHash(ctx, probe)
+	if err != nil {
+		return fmt.Errorf("signer probe: %w", err)
+	}
+	pub, err := crypto.SigToPub(probe[:], sig)
+	if err != nil {
+		return fmt.Errorf("signer probe recovery: %w", err)
+	}
+	if recovered := crypto.PubkeyToAddress(*pub); recovered != s.Address() {
+		return fmt.Errorf("signer probe recovered %s, want %s", recovered, s.Address())
+	}
+	return nil
+}
+
+// TransactOpts adapts a Signer to the abigen bindings. Ctx applies to the
+// entire lifespan of the bind.TransactOpts.
+func TransactOpts(ctx context.Context, s Signer, chainID *big.Int) (*bind.TransactOpts, error) {
+	if chainID == nil {
+		return nil, bind.ErrNoChainID
+	}
+	return &bind.TransactOpts{
+		Context: ctx,
+		From:    s.Address(),
+		Signer: func(addr common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			if addr != s.Address() {
+				return nil, bind.ErrNotAuthorized
+			}
+			return s.SignTx(ctx, tx, chainID)
+		},
+	}, nil
+}
+
+// hashSigner is the primitive every backend implements; the rest of the
+// Signer interface is derived from it.
+type hashSigner interface {
+	Address() common.Address
+	SignHash(ctx context.Context, hash common.Hash) ([]byte, error)
+}
+
+func signTx(ctx context.Context, s hashSigner, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	if chainID == nil {
+		return nil, bind.ErrNoChainID
+	}
+	txSigner := types.LatestSignerForChainID(chainID)
+	sig, err := s.SignHash(ctx, txSigner.Hash(tx))
+	if err != nil {
+		return nil, err
+	}
+	return tx.WithSignature(txSigner, sig)
+}
+
+func signTypedData(ctx context.Context, s hashSigner, typedData apitypes.TypedData) ([]byte, error) {
+	hash, _, err := apitypes.TypedDataAndHash(typedData)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := s.SignHash(ctx, common.BytesToHash(hash))
+	if err != nil {
+		return nil, err
+	}
+	sig[64] += 27
+	return sig, nil
+}
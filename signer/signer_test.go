@@ -0,0 +1,151 @@
+package signer_test
+
+import (
+	"context"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+
+	common2 "github.com/the-web3/contracts-caller/common"
+	"github.com/the-web3/contracts-caller/signer"
+)
+
+const (
+	testMnemonic = "test test test test test test test test test test test junk"
+	testHDPath   = "m/44'/60'/0'/0/0"
+	testKey      = "0xac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
+)
+
+var testAddr = common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")
+
+func TestNewPicksBackend(t *testing.T) {
+	ctx := context.Background()
+
+	s, err := signer.New(ctx, signer.Config{PrivateKey: testKey, AllowPlaintextKey: true})
+	require.Nil(t, err)
+	require.Equal(t, testAddr, s.Address())
+
+	s, err = signer.New(ctx, signer.Config{Mnemonic: testMnemonic, HDPath: testHDPath, AllowPlaintextKey: true})
+	require.Nil(t, err)
+	require.Equal(t, testAddr, s.Address())
+
+	_, err = signer.New(ctx, signer.Config{})
+	require.Equal(t, signer.ErrNoSignerConfigured, err)
+
+	_, err = signer.New(ctx, signer.Config{PrivateKey: testKey})
+	require.Equal(t, signer.ErrPlaintextKey, err)
+
+	_, err = signer.New(ctx, signer.Config{PrivateKey: testKey, Mnemonic: testMnemonic, HDPath: testHDPath, AllowPlaintextKey: true})
+	require.NotNil(t, err)
+}
+
+func TestNewFromKeystore(t *testing.T) {
+	key, err := common2.ParsePrivateKeyStr(testKey)
+	require.Nil(t, err)
+	keyJSON, err := keystore.EncryptKey(&keystore.Key{
+		Id:         uuid.New(),
+		Address:    testAddr,
+		PrivateKey: key,
+	}, "secret", keystore.LightScryptN, keystore.LightScryptP)
+	require.Nil(t, err)
+
+	dir := t.TempDir()
+	keystorePath := filepath.Join(dir, "keystore.json")
+	passwordPath := filepath.Join(dir, "password")
+	require.Nil(t, os.WriteFile(keystorePath, keyJSON, 0600))
+	require.Nil(t, os.WriteFile(passwordPath, []byte("secret\n"), 0600))
+
+	s, err := signer.New(context.Background(), signer.Config{Keystore: keystorePath, KeystorePasswordFile: passwordPath})
+	require.Nil(t, err)
+	require.Equal(t, testAddr, s.Address())
+
+	require.Nil(t, os.WriteFile(passwordPath, []byte("wrong\n"), 0600))
+	_, err = signer.New(context.Background(), signer.Config{Keystore: keystorePath, KeystorePasswordFile: passwordPath})
+	require.NotNil(t, err)
+}
+
+func TestPrivateKeySignerSignTx(t *testing.T) {
+	s, err := signer.New(context.Background(), signer.Config{PrivateKey: testKey, AllowPlaintextKey: true})
+	require.Nil(t, err)
+
+	chainID := big.NewInt(31337)
+	to := common.HexToAddress("0x0B306BF915C4d645ff596e518fAf3F9669b97016")
+	tx := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     1,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(2),
+		Gas:       21000,
+		To:        &to,
+	})
+
+	signed, err := s.SignTx(context.Background(), tx, chainID)
+	require.Nil(t, err)
+	from, err := types.Sender(types.LatestSignerForChainID(chainID), signed)
+	require.Nil(t, err)
+	require.Equal(t, testAddr, from)
+
+	opts, err := signer.TransactOpts(context.Background(), s, chainID)
+	require.Nil(t, err)
+	_, err = opts.Signer(to, tx)
+	require.NotNil(t, err)
+}
+
+func TestPrivateKeySignerSignTypedData(t *testing.T) {
+	s, err := signer.New(context.Background(), signer.Config{PrivateKey: testKey, AllowPlaintextKey: true})
+	require.Nil(t, err)
+
+	typedData := apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": {
+				{Name: "name", Type: "string"},
+				{Name: "chainId", Type: "uint256"},
+			},
+			"Approval": {
+				{Name: "operation", Type: "bytes32"},
+			},
+		},
+		PrimaryType: "Approval",
+		Domain: apitypes.TypedDataDomain{
+			Name:    "contracts-caller",
+			ChainId: math.NewHexOrDecimal256(31337),
+		},
+		Message: apitypes.TypedDataMessage{
+			"operation": crypto.Keccak256Hash([]byte("op")).Hex(),
+		},
+	}
+
+	sig, err := s.SignTypedData(context.Background(), typedData)
+	require.Nil(t, err)
+	require.Len(t, sig, 65)
+	require.Contains(t, []byte{27, 28}, sig[64])
+
+	hash, _, err := apitypes.TypedDataAndHash(typedData)
+	require.Nil(t, err)
+	recoverable := append([]byte{}, sig...)
+	recoverable[64] -= 27
+	pub, err := crypto.SigToPub(hash, recoverable)
+	require.Nil(t, err)
+	require.Equal(t, testAddr, crypto.PubkeyToAddress(*pub))
+}
+
+func TestCheckRecoversAddress(t *testing.T) {
+	s, err := signer.New(context.Background(), signer.Config{PrivateKey: testKey, AllowPlaintextKey: true})
+	require.Nil(t, err)
+	require.Nil(t, signer.Check(context.Background(), s))
+
+	_, err = signer.New(context.Background(), signer.Config{EnableHsm: true, AWSKMSKeyID: "alias/caller"})
+	require.Equal(t, signer.ErrConflictingSigners, err)
+}
@@ -0,0 +1,298 @@
+package challenger
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+
+	ethc "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/the-web3/contracts-caller/caller"
+	common2 "github.com/the-web3/contracts-caller/common"
+	"github.com/the-web3/contracts-caller/txmgr"
+)
+
+// TxResult is printed as JSON on stdout by every tm subcommand.
+type TxResult struct {
+	Method      string `json:"method"`
+	From        string `json:"from"`
+	Contract    string `json:"contract"`
+	Status      string `json:"status"`
+	TxHash      string `json:"txHash,omitempty"`
+	BlockNumber uint64 `json:"blockNumber,omitempty"`
+	GasUsed     uint64 `json:"gasUsed,omitempty"`
+	Error       string `json:"error,omitempty"`
+}
+
+const (
+	TxStatusSuccess  = "success"
+	TxStatusReverted = "reverted"
+	TxStatusFailed   = "failed"
+)
+
+type tmVerb struct {
+	name      string
+	method    string
+	usage     string
+	argsUsage string
+	// parse reads the arguments before anything is dialed or unlocked and
+	// returns the call to make.
+	parse func(a *cliArgs) txFunc
+}
+
+type txFunc func(ctx context.Context, c *caller.ContractCaller) (*types.Receipt, error)
+
+var tmVerbs = []tmVerb{
+	{"deposit-eth", "depositETH", "Deposit ETH into the treasure", "<amount-wei>",
+		func(a *cliArgs) txFunc {
+			amount := a.amount(0)
+			return func(ctx context.Context, c *caller.ContractCaller) (*types.Receipt, error) {
+				return c.DepositETH(ctx, amount)
+			}
+		}},
+	{"deposit-erc20", "depositERC20", "Deposit an ERC20 already approved to the treasure", "<token> <amount>",
+		func(a *cliArgs) txFunc {
+			token, amount := a.address(0), a.amount(1)
+			return func(ctx context.Context, c *caller.ContractCaller) (*types.Receipt, error) {
+				return c.DepositERC20(ctx, token, amount)
+			}
+		}},
+	{"grant-rewards", "grantRewards", "Grant a reward amount of token to granter", "<token> <granter> <amount>",
+		func(a *cliArgs) txFunc {
+			token, granter, amount := a.address(0), a.address(1), a.amount(2)
+			return func(ctx context.Context, c *caller.ContractCaller) (*types.Receipt, error) {
+				return c.GrantRewards(ctx, token, granter, amount)
+			}
+		}},
+	{"withdraw-eth", "withdrawETH", "Withdraw ETH from the treasure", "<to> <amount-wei>",
+		func(a *cliArgs) txFunc {
+			to, amount := a.address(0), a.amount(1)
+			return func(ctx context.Context, c *caller.ContractCaller) (*types.Receipt, error) {
+				return c.WithdrawETH(ctx, to, amount)
+			}
+		}},
+	{"withdraw-erc20", "withdrawERC20", "Withdraw an ERC20 from the treasure", "<token> <to> <amount>",
+		func(a *cliArgs) txFunc {
+			token, to, amount := a.address(0), a.address(1), a.amount(2)
+			return func(ctx context.Context, c *caller.ContractCaller) (*types.Receipt, error) {
+				return c.WithdrawERC20(ctx, token, to, amount)
+			}
+		}},
+	{"claim-token", "claimToken", "Claim the rewards of one token", "<token>",
+		func(a *cliArgs) txFunc {
+			token := a.address(0)
+			return func(ctx context.Context, c *caller.ContractCaller) (*types.Receipt, error) {
+				return c.ClaimToken(ctx, token)
+			}
+		}},
+	{"claim-all", "claimAllTokens", "Claim the rewards of every whitelisted token", "",
+		func(a *cliArgs) txFunc {
+			return func(ctx context.Context, c *caller.ContractCaller) (*types.Receipt, error) {
+				return c.ClaimAllTokens(ctx)
+			}
+		}},
+	{"set-token-whitelist", "setTokenWhiteList", "Add a token to the whitelist", "<token>",
+		func(a *cliArgs) txFunc {
+			token := a.address(0)
+			return func(ctx context.Context, c *caller.ContractCaller) (*types.Receipt, error) {
+				return c.SetTokenWhiteList(ctx, token)
+			}
+		}},
+	{"set-withdraw-manager", "setWithdrawManager", "Set the withdraw manager", "<address>",
+		func(a *cliArgs) txFunc {
+			withdrawManager := a.address(0)
+			return func(ctx context.Context, c *caller.ContractCaller) (*types.Receipt, error) {
+				return c.SetWithdrawManager(ctx, withdrawManager)
+			}
+		}},
+	{"grant-role", "grantRole", "Grant a role, given by name or 32-byte hex, to an account", "<role> <account>",
+		func(a *cliArgs) txFunc {
+			role, account := a.role(0), a.address(1)
+			return func(ctx context.Context, c *caller.ContractCaller) (*types.Receipt, error) {
+				return c.GrantRole(ctx, role, account)
+			}
+		}},
+	{"revoke-role", "revokeRole", "Revoke a role, given by name or 32-byte hex, from an account", "<role> <account>",
+		func(a *cliArgs) txFunc {
+			role, account := a.role(0), a.address(1)
+			return func(ctx context.Context, c *caller.ContractCaller) (*types.Receipt, error) {
+				return c.RevokeRole(ctx, role, account)
+			}
+		}},
+	{"renounce-role", "renounceRole", "Renounce a role of the caller wallet", "<role>",
+		func(a *cliArgs) txFunc {
+			role := a.role(0)
+			return func(ctx context.Context, c *caller.ContractCaller) (*types.Receipt, error) {
+				return c.RenounceRole(ctx, role)
+			}
+		}},
+	{"transfer-ownership", "transferOwnership", "Transfer the contract ownership", "<new-owner>",
+		func(a *cliArgs) txFunc {
+			newOwner := a.address(0)
+			return func(ctx context.Context, c *caller.ContractCaller) (*types.Receipt, error) {
+				return c.TransferOwnership(ctx, newOwner)
+			}
+		}},
+}
+
+// TreasureManagerCommand is `tm <verb>`, one subcommand per TreasureManager
+// write. Each waits for the configured confirmations and prints a TxResult.
+func TreasureManagerCommand() cli.Command {
+	cmd := cli.Command{
+		Name:  "tm",
+		Usage: "Send a TreasureManager transaction and wait for its confirmation",
+	}
+	for _, verb := range tmVerbs {
+		verb := verb
+		cmd.Subcommands = append(cmd.Subcommands, cli.Command{
+			Name:      verb.name,
+			Usage:     verb.usage,
+			ArgsUsage: verb.argsUsage,
+			Action: func(cliCtx *cli.Context) error {
+				return runTreasureManagerVerb(cliCtx, verb)
+			},
+		})
+	}
+	return cmd
+}
+
+func runTreasureManagerVerb(cliCtx *cli.Context, verb tmVerb) error {
+	args := &cliArgs{args: cliCtx.Args()}
+	call := verb.parse(args)
+	if err := args.done(); err != nil {
+		return fmt.Errorf("%s: %w, usage: %s %s", verb.name, err, verb.name, verb.argsUsage)
+	}
+
+	cfg, err := NewConfig(cliCtx)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cCaller, closeCaller, err := newContractCaller(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer closeCaller()
+
+	receipt, err := call(ctx, cCaller)
+	return printTxResult(verb.method, cCaller.WalletAddr, cCaller.Cfg.TreasureManagerAddr, receipt, err)
+}
+
+// printTxResult prints the outcome of a transaction and passes err through.
+func printTxResult(method string, from, contract ethc.Address, receipt *types.Receipt, err error) error {
+	if encErr := printJSON(newTxResult(method, from, contract, receipt, err)); encErr != nil {
+		return encErr
+	}
+	return err
+}
+
+func newTxResult(method string, from, contract ethc.Address, receipt *types.Receipt, err error) TxResult {
+	result := TxResult{
+		Method:   method,
+		From:     from.Hex(),
+		Contract: contract.Hex(),
+		Status:   TxStatusSuccess,
+	}
+	if receipt != nil {
+		result.TxHash = receipt.TxHash.Hex()
+		result.BlockNumber = receipt.BlockNumber.Uint64()
+		result.GasUsed = receipt.GasUsed
+	}
+	if err != nil {
+		result.Status = TxStatusFailed
+		if errors.Is(err, txmgr.ErrTxReverted) || errors.Is(err, caller.ErrSimulationReverted) {
+			result.Status = TxStatusReverted
+		}
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// batchTxResults lines up the receipts of a batch sent in order and
+// stopped by err with the methods of the batch; the ones never sent are left
+// out.
+func batchTxResults(methods []string, from, contract ethc.Address, receipts []*types.Receipt, err error) []TxResult {
+	results := make([]TxResult, 0, len(methods))
+	for i, method := range methods {
+		switch {
+		case i < len(receipts):
+			results = append(results, newTxResult(method, from, contract, receipts[i], nil))
+		case i == len(receipts) && err != nil:
+			results = append(results, newTxResult(method, from, contract, nil, err))
+		}
+	}
+	return results
+}
+
+func printJSON(v interface{}) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// cliArgs parses positional arguments, remembering the first error so the
+// verb table stays free of error plumbing.
+type cliArgs struct {
+	args cli.Args
+	used int
+	err  error
+}
+
+func (a *cliArgs) get(i int) string {
+	if i+1 > a.used {
+		a.used = i + 1
+	}
+	if i >= len(a.args) {
+		a.fail(fmt.Errorf("missing argument %d", i+1))
+		return ""
+	}
+	return a.args[i]
+}
+
+// done returns the first parse error, or complains about arguments nothing
+// asked for.
+func (a *cliArgs) done() error {
+	if a.err == nil && len(a.args) > a.used {
+		a.err = fmt.Errorf("unexpected argument %s", a.args[a.used])
+	}
+	return a.err
+}
+
+func (a *cliArgs) fail(err error) {
+	if a.err == nil {
+		a.err = err
+	}
+}
+
+func (a *cliArgs) address(i int) ethc.Address {
+	addr, err := common2.ParseAddress(a.get(i))
+	if err != nil {
+		a.fail(err)
+	}
+	return addr
+}
+
+func (a *cliArgs) amount(i int) *big.Int {
+	s := a.get(i)
+	amount, ok := new(big.Int).SetString(s, 0)
+	if !ok || amount.Sign() < 0 {
+		a.fail(fmt.Errorf("invalid amount: %v", s))
+		return new(big.Int)
+	}
+	return amount
+}
+
+func (a *cliArgs) role(i int) [32]byte {
+	role, err := common2.ParseRole(a.get(i))
+	if err != nil {
+		a.fail(err)
+	}
+	return role
+}
@@ -0,0 +1,179 @@
+package challenger
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli"
+
+	ethc "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/the-web3/contracts-caller/caller"
+	"github.com/the-web3/contracts-caller/txmgr"
+)
+
+const (
+	testToken   = "0x1111111111111111111111111111111111111111"
+	testAccount = "0x2222222222222222222222222222222222222222"
+)
+
+var testRoleHash = crypto.Keccak256Hash([]byte("WITHDRAW_ROLE")).Hex()
+
+func findTmVerb(t *testing.T, name string) tmVerb {
+	for _, verb := range tmVerbs {
+		if verb.name == name {
+			return verb
+		}
+	}
+	t.Fatalf("no tm verb %s", name)
+	return tmVerb{}
+}
+
+func TestTmVerbArgs(t *testing.T) {
+	tests := []struct {
+		verb string
+		args []string
+		err  string
+	}{
+		{verb: "deposit-eth", args: []string{"1000000000000000000"}},
+		{verb: "deposit-eth", args: []string{"0x10"}},
+		{verb: "deposit-eth", args: []string{"1e18"}, err: "invalid amount: 1e18"},
+		{verb: "deposit-eth", args: []string{"-1"}, err: "invalid amount: -1"},
+		{verb: "deposit-eth", args: nil, err: "missing argument 1"},
+		{verb: "deposit-eth", args: []string{"1", "2"}, err: "unexpected argument 2"},
+		{verb: "deposit-erc20", args: []string{testToken, "5"}},
+		{verb: "deposit-erc20", args: []string{"token", "5"}, err: "invalid address: token"},
+		{verb: "grant-rewards", args: []string{testToken, testAccount, "5"}},
+		{verb: "grant-rewards", args: []string{testToken, testAccount}, err: "missing argument 3"},
+		{verb: "withdraw-eth", args: []string{testAccount, "1000"}},
+		{verb: "withdraw-eth", args: []string{testAccount, "ten"}, err: "invalid amount: ten"},
+		{verb: "withdraw-erc20", args: []string{testToken, testAccount, "1000"}},
+		{verb: "withdraw-erc20", args: []string{testToken, "1000"}, err: "invalid address: 1000"},
+		{verb: "claim-token", args: []string{testToken}},
+		{verb: "claim-token", args: nil, err: "missing argument 1"},
+		{verb: "claim-all", args: nil},
+		{verb: "claim-all", args: []string{testToken}, err: "unexpected argument " + testToken},
+		{verb: "set-token-whitelist", args: []string{testToken}},
+		{verb: "set-withdraw-manager", args: []string{testAccount}},
+		{verb: "set-withdraw-manager", args: []string{"0x1234"}, err: "invalid address: 0x1234"},
+		{verb: "grant-role", args: []string{"WITHDRAW_ROLE", testAccount}},
+		{verb: "grant-role", args: []string{testRoleHash, testAccount}},
+		{verb: "grant-role", args: []string{"DEFAULT_ADMIN_ROLE", testAccount}},
+		{verb: "grant-role", args: []string{"0x1234", testAccount}, err: "invalid role: 0x1234"},
+		{verb: "revoke-role", args: []string{"WITHDRAW_ROLE", testAccount}},
+		{verb: "revoke-role", args: []string{"WITHDRAW_ROLE"}, err: "missing argument 2"},
+		{verb: "revoke-role", args: []string{testAccount, testAccount}, err: "invalid role: " + testAccount},
+		{verb: "renounce-role", args: []string{testRoleHash}},
+		{verb: "renounce-role", args: []string{"WITHDRAW_ROLE", testAccount}, err: "unexpected argument " + testAccount},
+		{verb: "transfer-ownership", args: []string{testAccount}},
+		{verb: "transfer-ownership", args: []string{""}, err: "invalid address: "},
+	}
+	for _, tt := range tests {
+		t.Run(tt.verb, func(t *testing.T) {
+			a := &cliArgs{args: cli.Args(tt.args)}
+			call := findTmVerb(t, tt.verb).parse(a)
+			err := a.done()
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				return
+			}
+			require.Nil(t, err)
+			require.NotNil(t, call)
+		})
+	}
+}
+
+func TestCliArgsValues(t *testing.T) {
+	a := &cliArgs{args: cli.Args{
+		"1000000000000000000", "0x10", testAccount, "WITHDRAW_ROLE", testRoleHash, "DEFAULT_ADMIN_ROLE",
+	}}
+	wei, ok := new(big.Int).SetString("1000000000000000000", 10)
+	require.True(t, ok)
+	require.Equal(t, wei, a.amount(0))
+	require.Equal(t, big.NewInt(16), a.amount(1))
+	require.Equal(t, ethc.HexToAddress(testAccount), a.address(2))
+	// a role name is hashed like the contract does, and matches its hex
+	require.Equal(t, [32]byte(ethc.HexToHash(testRoleHash)), a.role(3))
+	require.Equal(t, [32]byte(ethc.HexToHash(testRoleHash)), a.role(4))
+	require.Equal(t, [32]byte{}, a.role(5))
+	require.Nil(t, a.done())
+
+	// the first error is kept
+	a = &cliArgs{args: cli.Args{"-5", "nope"}}
+	require.Equal(t, new(big.Int), a.amount(0))
+	a.address(1)
+	require.EqualError(t, a.done(), "invalid amount: -5")
+}
+
+func TestTxResultJSON(t *testing.T) {
+	from := ethc.HexToAddress(testAccount)
+	contract := ethc.HexToAddress(testToken)
+	receipt := &types.Receipt{
+		TxHash:      ethc.HexToHash("0xabcd"),
+		BlockNumber: big.NewInt(12),
+		GasUsed:     21000,
+	}
+	tests := []struct {
+		name    string
+		receipt *types.Receipt
+		err     error
+		json    string
+	}{
+		{
+			name:    "success",
+			receipt: receipt,
+			json: `{"method":"claimAllTokens","from":"` + from.Hex() + `","contract":"` + contract.Hex() +
+				`","status":"success","txHash":"` + receipt.TxHash.Hex() + `","blockNumber":12,"gasUsed":21000}`,
+		},
+		{
+			name:    "reverted on chain",
+			receipt: receipt,
+			err:     txmgr.ErrTxReverted,
+			json: `{"method":"claimAllTokens","from":"` + from.Hex() + `","contract":"` + contract.Hex() +
+				`","status":"reverted","txHash":"` + receipt.TxHash.Hex() + `","blockNumber":12,"gasUsed":21000,"error":"` +
+				txmgr.ErrTxReverted.Error() + `"}`,
+		},
+		{
+			name: "reverted in simulation",
+			err:  caller.ErrSimulationReverted,
+			json: `{"method":"claimAllTokens","from":"` + from.Hex() + `","contract":"` + contract.Hex() +
+				`","status":"reverted","error":"` + caller.ErrSimulationReverted.Error() + `"}`,
+		},
+		{
+			name: "failed",
+			err:  errors.New("connection refused"),
+			json: `{"method":"claimAllTokens","from":"` + from.Hex() + `","contract":"` + contract.Hex() +
+				`","status":"failed","error":"connection refused"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw, err := json.Marshal(newTxResult("claimAllTokens", from, contract, tt.receipt, tt.err))
+			require.Nil(t, err)
+			require.JSONEq(t, tt.json, string(raw))
+		})
+	}
+}
+
+func TestBatchTxResults(t *testing.T) {
+	from := ethc.HexToAddress(testAccount)
+	contract := ethc.HexToAddress(testToken)
+	methods := []string{"grantRole", "grantRole", "grantRole"}
+	receipts := []*types.Receipt{{TxHash: ethc.HexToHash("0x01"), BlockNumber: big.NewInt(1)}}
+
+	results := batchTxResults(methods, from, contract, receipts, errors.New("nonce too low"))
+	require.Len(t, results, 2)
+	require.Equal(t, TxStatusSuccess, results[0].Status)
+	require.Equal(t, receipts[0].TxHash.Hex(), results[0].TxHash)
+	require.Equal(t, TxStatusFailed, results[1].Status)
+	require.Equal(t, "nonce too low", results[1].Error)
+
+	// the methods never sent are left out
+	results = batchTxResults(methods, from, contract, receipts, nil)
+	require.Len(t, results, 1)
+}
@@ -0,0 +1,219 @@
+package txmgr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	FeeStrategyGeth        = "geth"
+	FeeStrategyLinear      = "linear"
+	FeeStrategyExponential = "exponential"
+	FeeStrategyFeeHistory  = "fee-history"
+
+	// MinReplacementBumpPercent is the minimum increase geth requires on
+	// both the tip and the fee cap to accept a replacement transaction.
+	MinReplacementBumpPercent = 10
+
+	defaultFeeHistoryBlocks = 20
+)
+
+var defaultFallbackGasTipCap = big.NewInt(1500000000)
+
+// ErrFeeCapReached is returned by Fees when the hard caps leave no room for
+// a replacement the node would accept; the attempt already pending is then
+// the last one.
+var ErrFeeCapReached = errors.New("txmgr: fee caps reached")
+
+// FeeSource is the subset of the chain client fee policies read network fee
+// data from.
+type FeeSource interface {
+	SuggestGasTipCap(ctx context.Context) (*big.Int, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*ethereum.FeeHistory, error)
+}
+
+// FeePolicy decides the tip and fee cap of every submission of a
+// transaction.
+type FeePolicy interface {
+	// Fees returns the caps for the next attempt. prev is the previously
+	// signed attempt, nil for the first one; a replacement is always priced
+	// high enough for the node to accept it, unless the hard caps forbid
+	// it, in which case ErrFeeCapReached is returned.
+	Fees(ctx context.Context, prev *types.Transaction) (gasTipCap, gasFeeCap *big.Int, err error)
+}
+
+type FeePolicyConfig struct {
+	// Strategy is one of geth, linear, exponential or fee-history; geth is
+	// used when empty.
+	Strategy string
+	// MaxGasTipCap and MaxGasFeeCap are hard upper bounds, unlimited when
+	// nil or zero.
+	MaxGasTipCap *big.Int
+	MaxGasFeeCap *big.Int
+	// BumpStep is the amount of wei the linear strategy adds per attempt.
+	BumpStep *big.Int
+	// BumpFactor is the multiplier of the exponential strategy.
+	BumpFactor float64
+	// Percentile of the priority fees paid in recent blocks the fee-history
+	// strategy tips at.
+	Percentile       float64
+	FeeHistoryBlocks uint64
+	// FallbackGasTipCap is used when the node has no eth_maxPriorityFeePerGas.
+	FallbackGasTipCap *big.Int
+}
+
+type feePolicy struct {
+	cfg     FeePolicyConfig
+	backend FeeSource
+	bump    func(prev *big.Int) *big.Int
+	tipCap  func(ctx context.Context) (*big.Int, error)
+}
+
+func NewFeePolicy(cfg FeePolicyConfig, backend FeeSource) (FeePolicy, error) {
+	if cfg.FallbackGasTipCap == nil {
+		cfg.FallbackGasTipCap = defaultFallbackGasTipCap
+	}
+	p := &feePolicy{cfg: cfg, backend: backend}
+	p.bump = MinReplacementFee
+	p.tipCap = p.suggestedTipCap
+
+	switch cfg.Strategy {
+	case "", FeeStrategyGeth:
+
+	case FeeStrategyLinear:
+		if cfg.BumpStep == nil || cfg.BumpStep.Sign() <= 0 {
+			return nil, fmt.Errorf("txmgr: linear fee policy needs a positive bump step")
+		}
+		p.bump = func(prev *big.Int) *big.Int {
+			return new(big.Int).Add(prev, cfg.BumpStep)
+		}
+
+	case FeeStrategyExponential:
+		if cfg.BumpFactor <= 1 {
+			return nil, fmt.Errorf("txmgr: exponential fee policy needs a bump factor above 1, got %v", cfg.BumpFactor)
+		}
+		p.bump = func(prev *big.Int) *big.Int {
+			bumped, _ := new(big.Float).Mul(new(big.Float).SetInt(prev), big.NewFloat(cfg.BumpFactor)).Int(nil)
+			return bumped
+		}
+
+	case FeeStrategyFeeHistory:
+		if cfg.Percentile <= 0 || cfg.Percentile > 100 {
+			return nil, fmt.Errorf("txmgr: fee history percentile must be in (0, 100], got %v", cfg.Percentile)
+		}
+		if p.cfg.FeeHistoryBlocks == 0 {
+			p.cfg.FeeHistoryBlocks = defaultFeeHistoryBlocks
+		}
+		p.tipCap = p.feeHistoryTipCap
+
+	default:
+		return nil, fmt.Errorf("txmgr: unknown fee strategy %q", cfg.Strategy)
+	}
+	return p, nil
+}
+
+func (p *feePolicy) Fees(ctx context.Context, prev *types.Transaction) (*big.Int, *big.Int, error) {
+	gasTipCap, err := p.tipCap(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	head, err := p.backend.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	baseFee := head.BaseFee
+	if baseFee == nil {
+		baseFee = new(big.Int)
+	}
+	gasFeeCap := CalcGasFeeCap(baseFee, gasTipCap)
+
+	if prev != nil {
+		gasTipCap = maxBig(gasTipCap, p.bump(prev.GasTipCap()), MinReplacementFee(prev.GasTipCap()))
+		gasFeeCap = maxBig(gasFeeCap, p.bump(prev.GasFeeCap()), MinReplacementFee(prev.GasFeeCap()))
+	}
+
+	gasTipCap = capBig(gasTipCap, p.cfg.MaxGasTipCap)
+	gasFeeCap = capBig(gasFeeCap, p.cfg.MaxGasFeeCap)
+	if gasTipCap.Cmp(gasFeeCap) > 0 {
+		gasTipCap = new(big.Int).Set(gasFeeCap)
+	}
+
+	if prev != nil && (gasTipCap.Cmp(MinReplacementFee(prev.GasTipCap())) < 0 ||
+		gasFeeCap.Cmp(MinReplacementFee(prev.GasFeeCap())) < 0) {
+		return nil, nil, ErrFeeCapReached
+	}
+	return gasTipCap, gasFeeCap, nil
+}
+
+func (p *feePolicy) suggestedTipCap(ctx context.Context) (*big.Int, error) {
+	gasTipCap, err := p.backend.SuggestGasTipCap(ctx)
+	if err != nil {
+		if strings.Contains(err.Error(), "eth_maxPriorityFeePerGas") {
+			log.Info("ContractsCaller eth_maxPriorityFeePerGas is unsupported by current backend, using fallback gasTipCap")
+			return new(big.Int).Set(p.cfg.FallbackGasTipCap), nil
+		}
+		return nil, err
+	}
+	return gasTipCap, nil
+}
+
+// feeHistoryTipCap tips at the median over recent blocks of the configured
+// percentile of priority fees.
+func (p *feePolicy) feeHistoryTipCap(ctx context.Context) (*big.Int, error) {
+	history, err := p.backend.FeeHistory(ctx, p.cfg.FeeHistoryBlocks, nil, []float64{p.cfg.Percentile})
+	if err != nil {
+		return nil, err
+	}
+	var rewards []*big.Int
+	for _, reward := range history.Reward {
+		if len(reward) > 0 && reward[0] != nil {
+			rewards = append(rewards, reward[0])
+		}
+	}
+	if len(rewards) == 0 {
+		return p.suggestedTipCap(ctx)
+	}
+	sort.Slice(rewards, func(i, j int) bool { return rewards[i].Cmp(rewards[j]) < 0 })
+	return new(big.Int).Set(rewards[len(rewards)/2]), nil
+}
+
+// MinReplacementFee returns the smallest fee a node accepts to replace a
+// pending transaction that paid prev.
+func MinReplacementFee(prev *big.Int) *big.Int {
+	bumped := new(big.Int).Mul(prev, big.NewInt(100+MinReplacementBumpPercent))
+	bumped.Add(bumped, big.NewInt(99))
+	return bumped.Div(bumped, big.NewInt(100))
+}
+
+func CalcGasFeeCap(baseFee, gasTipCap *big.Int) *big.Int {
+	return new(big.Int).Add(
+		gasTipCap,
+		new(big.Int).Mul(baseFee, big.NewInt(2)),
+	)
+}
+
+func maxBig(values ...*big.Int) *big.Int {
+	max := values[0]
+	for _, v := range values[1:] {
+		if v.Cmp(max) > 0 {
+			max = v
+		}
+	}
+	return new(big.Int).Set(max)
+}
+
+func capBig(v, limit *big.Int) *big.Int {
+	if limit == nil || limit.Sign() == 0 || v.Cmp(limit) <= 0 {
+		return v
+	}
+	return new(big.Int).Set(limit)
+}
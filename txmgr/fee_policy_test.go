@@ -0,0 +1,155 @@
+package txmgr_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/the-web3/contracts-caller/txmgr"
+)
+
+type mockFeeSource struct {
+	gasTipCap *big.Int
+	tipErr    error
+	baseFee   *big.Int
+	rewards   []int64
+}
+
+func (s *mockFeeSource) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	if s.tipErr != nil {
+		return nil, s.tipErr
+	}
+	return s.gasTipCap, nil
+}
+
+func (s *mockFeeSource) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return &types.Header{BaseFee: s.baseFee}, nil
+}
+
+func (s *mockFeeSource) FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*ethereum.FeeHistory, error) {
+	history := &ethereum.FeeHistory{}
+	for _, reward := range s.rewards {
+		history.Reward = append(history.Reward, []*big.Int{big.NewInt(reward)})
+	}
+	return history, nil
+}
+
+func newFeeSource() *mockFeeSource {
+	return &mockFeeSource{gasTipCap: big.NewInt(100), baseFee: big.NewInt(1000)}
+}
+
+func feeTx(gasTipCap, gasFeeCap int64) *types.Transaction {
+	return types.NewTx(&types.DynamicFeeTx{
+		GasTipCap: big.NewInt(gasTipCap),
+		GasFeeCap: big.NewInt(gasFeeCap),
+	})
+}
+
+func requireFees(t *testing.T, policy txmgr.FeePolicy, prev *types.Transaction, expTip, expFeeCap int64) {
+	gasTipCap, gasFeeCap, err := policy.Fees(context.Background(), prev)
+	require.Nil(t, err)
+	require.Equal(t, big.NewInt(expTip), gasTipCap)
+	require.Equal(t, big.NewInt(expFeeCap), gasFeeCap)
+}
+
+func TestFeePolicyFirstAttemptUsesNetwork(t *testing.T) {
+	policy, err := txmgr.NewFeePolicy(txmgr.FeePolicyConfig{}, newFeeSource())
+	require.Nil(t, err)
+
+	requireFees(t, policy, nil, 100, 2100)
+}
+
+func TestFeePolicyGethBumpsAtLeastTenPercent(t *testing.T) {
+	policy, err := txmgr.NewFeePolicy(txmgr.FeePolicyConfig{}, newFeeSource())
+	require.Nil(t, err)
+
+	// The network suggestion is lower than the previous attempt, which
+	// would be rejected as underpriced without the minimum bump.
+	requireFees(t, policy, feeTx(200, 3000), 220, 3300)
+	requireFees(t, policy, feeTx(9, 2200), 100, 2420)
+}
+
+func TestFeePolicyLinear(t *testing.T) {
+	policy, err := txmgr.NewFeePolicy(txmgr.FeePolicyConfig{
+		Strategy: txmgr.FeeStrategyLinear,
+		BumpStep: big.NewInt(500),
+	}, newFeeSource())
+	require.Nil(t, err)
+
+	requireFees(t, policy, feeTx(200, 3000), 700, 3500)
+	// The minimum replacement bump still wins over a small step.
+	requireFees(t, policy, feeTx(200, 30000), 700, 33000)
+}
+
+func TestFeePolicyExponential(t *testing.T) {
+	policy, err := txmgr.NewFeePolicy(txmgr.FeePolicyConfig{
+		Strategy:   txmgr.FeeStrategyExponential,
+		BumpFactor: 1.5,
+	}, newFeeSource())
+	require.Nil(t, err)
+
+	requireFees(t, policy, feeTx(200, 3000), 300, 4500)
+}
+
+func TestFeePolicyFeeHistoryPercentile(t *testing.T) {
+	source := newFeeSource()
+	source.rewards = []int64{50, 10, 70, 30, 90}
+	policy, err := txmgr.NewFeePolicy(txmgr.FeePolicyConfig{
+		Strategy:   txmgr.FeeStrategyFeeHistory,
+		Percentile: 60,
+	}, source)
+	require.Nil(t, err)
+
+	requireFees(t, policy, nil, 50, 2050)
+}
+
+func TestFeePolicyCapsFees(t *testing.T) {
+	policy, err := txmgr.NewFeePolicy(txmgr.FeePolicyConfig{
+		MaxGasTipCap: big.NewInt(80),
+		MaxGasFeeCap: big.NewInt(1500),
+	}, newFeeSource())
+	require.Nil(t, err)
+
+	requireFees(t, policy, nil, 80, 1500)
+}
+
+func TestFeePolicyStopsReplacingAtCap(t *testing.T) {
+	policy, err := txmgr.NewFeePolicy(txmgr.FeePolicyConfig{
+		MaxGasFeeCap: big.NewInt(3100),
+	}, newFeeSource())
+	require.Nil(t, err)
+
+	_, _, err = policy.Fees(context.Background(), feeTx(200, 3000))
+	require.ErrorIs(t, err, txmgr.ErrFeeCapReached)
+}
+
+func TestFeePolicyFallbackTipCap(t *testing.T) {
+	source := newFeeSource()
+	source.tipErr = errors.New("Method eth_maxPriorityFeePerGas not found")
+	policy, err := txmgr.NewFeePolicy(txmgr.FeePolicyConfig{
+		FallbackGasTipCap: big.NewInt(7),
+	}, source)
+	require.Nil(t, err)
+
+	requireFees(t, policy, nil, 7, 2007)
+}
+
+func TestFeePolicyRejectsBadConfig(t *testing.T) {
+	_, err := txmgr.NewFeePolicy(txmgr.FeePolicyConfig{Strategy: "bogus"}, newFeeSource())
+	require.NotNil(t, err)
+	_, err = txmgr.NewFeePolicy(txmgr.FeePolicyConfig{Strategy: txmgr.FeeStrategyLinear}, newFeeSource())
+	require.NotNil(t, err)
+	_, err = txmgr.NewFeePolicy(txmgr.FeePolicyConfig{Strategy: txmgr.FeeStrategyExponential, BumpFactor: 1}, newFeeSource())
+	require.NotNil(t, err)
+}
+
+func TestMinReplacementFee(t *testing.T) {
+	require.Equal(t, big.NewInt(110), txmgr.MinReplacementFee(big.NewInt(100)))
+	require.Equal(t, big.NewInt(2), txmgr.MinReplacementFee(big.NewInt(1)))
+}
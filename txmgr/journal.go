@@ -0,0 +1,240 @@
+package txmgr
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/ethdb/leveldb"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+)
+
+type TxStatus string
+
+const (
+	TxStatusPending  TxStatus = "pending"
+	TxStatusMined    TxStatus = "mined"
+	TxStatusReverted TxStatus = "reverted"
+	TxStatusFailed   TxStatus = "failed"
+)
+
+var ErrJournalEntryNotFound = errors.New("txmgr: journal entry not found")
+
+// JournalAttempt is one signed submission of a transaction.
+type JournalAttempt struct {
+	TxHash    common.Hash `json:"txHash"`
+	RawTx     []byte      `json:"rawTx"`
+	GasTipCap *big.Int    `json:"gasTipCap"`
+	GasFeeCap *big.Int    `json:"gasFeeCap"`
+	SignedAt  time.Time   `json:"signedAt"`
+}
+
+// JournalEntry collects every attempt made for one nonce.
+type JournalEntry struct {
+	Nonce     uint64            `json:"nonce"`
+	Status    TxStatus          `json:"status"`
+	MinedTx   common.Hash       `json:"minedTx,omitempty"`
+	Attempts  []*JournalAttempt `json:"attempts"`
+	UpdatedAt time.Time         `json:"updatedAt"`
+}
+
+// Transactions decodes the signed attempts, oldest first.
+func (e *JournalEntry) Transactions() ([]*types.Transaction, error) {
+	txs := make([]*types.Transaction, 0, len(e.Attempts))
+	for _, attempt := range e.Attempts {
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalBinary(attempt.RawTx); err != nil {
+			return nil, err
+		}
+		txs = append(txs, tx)
+	}
+	return txs, nil
+}
+
+// sameCall reports whether tx makes the same call as the attempts of e, a
+// replacement rather than a new transaction reusing a released nonce.
+func (e *JournalEntry) sameCall(tx *types.Transaction) bool {
+	if len(e.Attempts) == 0 {
+		return true
+	}
+	first := new(types.Transaction)
+	if err := first.UnmarshalBinary(e.Attempts[0].RawTx); err != nil {
+		return false
+	}
+	var firstTo, to common.Address
+	if first.To() != nil {
+		firstTo = *first.To()
+	}
+	if tx.To() != nil {
+		to = *tx.To()
+	}
+	return firstTo == to && bytes.Equal(first.Data(), tx.Data()) && first.Value().Cmp(tx.Value()) == 0
+}
+
+// Journal durably records signed attempts so in-flight transactions can be
+// resumed after a restart. A journal belongs to a single sending account.
+type Journal interface {
+	// RecordAttempt appends a signed attempt to the entry for its nonce,
+	// starting a fresh entry if the previous one is no longer pending or
+	// was for another call.
+	RecordAttempt(tx *types.Transaction) error
+	// MarkStatus moves the entry for a nonce to its final status.
+	MarkStatus(nonce uint64, status TxStatus, txHash common.Hash) error
+	// Entry returns the entry for a nonce.
+	Entry(nonce uint64) (*JournalEntry, error)
+	// Pending returns the entries still waiting to be mined, by nonce.
+	Pending() ([]*JournalEntry, error)
+	Close() error
+}
+
+var journalPrefix = []byte("txj-")
+
+// KVJournal is a Journal backed by any go-ethereum key-value store.
+type KVJournal struct {
+	db ethdb.KeyValueStore
+	mu sync.Mutex
+}
+
+func NewKVJournal(db ethdb.KeyValueStore) *KVJournal {
+	return &KVJournal{db: db}
+}
+
+// NewLevelDBJournal opens (or creates) an on-disk journal at path.
+func NewLevelDBJournal(path string) (*KVJournal, error) {
+	db, err := leveldb.New(path, 16, 16, "", false)
+	if err != nil {
+		return nil, err
+	}
+	return NewKVJournal(db), nil
+}
+
+// NewMemoryJournal returns a journal that does not survive restarts, useful
+// for tests and dry runs.
+func NewMemoryJournal() *KVJournal {
+	return NewKVJournal(memorydb.New())
+}
+
+func journalKey(nonce uint64) []byte {
+	key := make([]byte, len(journalPrefix)+8)
+	copy(key, journalPrefix)
+	binary.BigEndian.PutUint64(key[len(journalPrefix):], nonce)
+	return key
+}
+
+func (j *KVJournal) RecordAttempt(tx *types.Transaction) error {
+	raw, err := tx.MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	entry, err := j.get(tx.Nonce())
+	if err != nil && err != ErrJournalEntryNotFound {
+		return err
+	}
+	if entry == nil || entry.Status != TxStatusPending || !entry.sameCall(tx) {
+		entry = &JournalEntry{Nonce: tx.Nonce(), Status: TxStatusPending}
+	}
+	for _, attempt := range entry.Attempts {
+		if attempt.TxHash == tx.Hash() {
+			return nil
+		}
+	}
+	now := time.Now()
+	entry.Attempts = append(entry.Attempts, &JournalAttempt{
+		TxHash:    tx.Hash(),
+		RawTx:     raw,
+		GasTipCap: tx.GasTipCap(),
+		GasFeeCap: tx.GasFeeCap(),
+		SignedAt:  now,
+	})
+	entry.UpdatedAt = now
+	return j.put(entry)
+}
+
+func (j *KVJournal) MarkStatus(nonce uint64, status TxStatus, txHash common.Hash) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	entry, err := j.get(nonce)
+	if err != nil {
+		return err
+	}
+	entry.Status = status
+	entry.MinedTx = txHash
+	entry.UpdatedAt = time.Now()
+	return j.put(entry)
+}
+
+func (j *KVJournal) Entry(nonce uint64) (*JournalEntry, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	return j.get(nonce)
+}
+
+func (j *KVJournal) Pending() ([]*JournalEntry, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	it := j.db.NewIterator(journalPrefix, nil)
+	defer it.Release()
+
+	var entries []*JournalEntry
+	for it.Next() {
+		var entry JournalEntry
+		if err := json.Unmarshal(it.Value(), &entry); err != nil {
+			return nil, err
+		}
+		if entry.Status == TxStatusPending {
+			entries = append(entries, &entry)
+		}
+	}
+	if err := it.Error(); err != nil {
+		return nil, err
+	}
+	sort.Slice(entries, func(i, k int) bool { return entries[i].Nonce < entries[k].Nonce })
+	return entries, nil
+}
+
+func (j *KVJournal) Close() error {
+	return j.db.Close()
+}
+
+func (j *KVJournal) get(nonce uint64) (*JournalEntry, error) {
+	key := journalKey(nonce)
+	ok, err := j.db.Has(key)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrJournalEntryNotFound
+	}
+	data, err := j.db.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	var entry JournalEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func (j *KVJournal) put(entry *JournalEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	return j.db.Put(journalKey(entry.Nonce), data)
+}
@@ -0,0 +1,233 @@
+package txmgr_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/the-web3/contracts-caller/txmgr"
+)
+
+func newJournalTx(nonce uint64, gasFeeCap int64) *types.Transaction {
+	return types.NewTx(&types.DynamicFeeTx{
+		Nonce:     nonce,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(gasFeeCap),
+	})
+}
+
+func TestJournalRecordsAttempts(t *testing.T) {
+	journal := txmgr.NewMemoryJournal()
+	defer journal.Close()
+
+	first := newJournalTx(7, 10)
+	second := newJournalTx(7, 11)
+	require.Nil(t, journal.RecordAttempt(first))
+	require.Nil(t, journal.RecordAttempt(second))
+	require.Nil(t, journal.RecordAttempt(second))
+
+	entry, err := journal.Entry(7)
+	require.Nil(t, err)
+	require.Equal(t, txmgr.TxStatusPending, entry.Status)
+	require.Len(t, entry.Attempts, 2)
+	require.Equal(t, first.Hash(), entry.Attempts[0].TxHash)
+	require.Equal(t, big.NewInt(11), entry.Attempts[1].GasFeeCap)
+
+	txs, err := entry.Transactions()
+	require.Nil(t, err)
+	require.Equal(t, second.Hash(), txs[1].Hash())
+}
+
+func TestJournalPendingSkipsFinishedEntries(t *testing.T) {
+	journal := txmgr.NewMemoryJournal()
+	defer journal.Close()
+
+	require.Nil(t, journal.RecordAttempt(newJournalTx(2, 10)))
+	require.Nil(t, journal.RecordAttempt(newJournalTx(1, 10)))
+	require.Nil(t, journal.RecordAttempt(newJournalTx(3, 10)))
+	require.Nil(t, journal.MarkStatus(2, txmgr.TxStatusMined, common.HexToHash("0x02")))
+
+	pending, err := journal.Pending()
+	require.Nil(t, err)
+	require.Len(t, pending, 2)
+	require.Equal(t, uint64(1), pending[0].Nonce)
+	require.Equal(t, uint64(3), pending[1].Nonce)
+
+	_, err = journal.Entry(9)
+	require.Equal(t, txmgr.ErrJournalEntryNotFound, err)
+}
+
+func TestJournalReusedNonceStartsFreshEntry(t *testing.T) {
+	journal := txmgr.NewMemoryJournal()
+	defer journal.Close()
+
+	require.Nil(t, journal.RecordAttempt(newJournalTx(4, 10)))
+	require.Nil(t, journal.MarkStatus(4, txmgr.TxStatusFailed, common.Hash{}))
+	require.Nil(t, journal.RecordAttempt(newJournalTx(4, 20)))
+
+	entry, err := journal.Entry(4)
+	require.Nil(t, err)
+	require.Equal(t, txmgr.TxStatusPending, entry.Status)
+	require.Len(t, entry.Attempts, 1)
+}
+
+func TestJournalReusedNonceForAnotherCallStartsFreshEntry(t *testing.T) {
+	journal := txmgr.NewMemoryJournal()
+	defer journal.Close()
+
+	to := common.HexToAddress("0x01")
+	newCall := func(data []byte, gasFeeCap int64) *types.Transaction {
+		return types.NewTx(&types.DynamicFeeTx{
+			Nonce:     4,
+			To:        &to,
+			Data:      data,
+			GasTipCap: big.NewInt(1),
+			GasFeeCap: big.NewInt(gasFeeCap),
+		})
+	}
+	require.Nil(t, journal.RecordAttempt(newCall([]byte{1}, 10)))
+	require.Nil(t, journal.RecordAttempt(newCall([]byte{1}, 11)))
+	// the nonce was released and taken by another call
+	next := newCall([]byte{2}, 10)
+	require.Nil(t, journal.RecordAttempt(next))
+
+	entry, err := journal.Entry(4)
+	require.Nil(t, err)
+	require.Equal(t, txmgr.TxStatusPending, entry.Status)
+	require.Len(t, entry.Attempts, 1)
+	require.Equal(t, next.Hash(), entry.Attempts[0].TxHash)
+}
+
+func TestLevelDBJournalSurvivesReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal")
+
+	journal, err := txmgr.NewLevelDBJournal(path)
+	require.Nil(t, err)
+	require.Nil(t, journal.RecordAttempt(newJournalTx(5, 10)))
+	require.Nil(t, journal.Close())
+
+	journal, err = txmgr.NewLevelDBJournal(path)
+	require.Nil(t, err)
+	defer journal.Close()
+
+	pending, err := journal.Pending()
+	require.Nil(t, err)
+	require.Len(t, pending, 1)
+	require.Equal(t, uint64(5), pending[0].Nonce)
+}
+
+func TestTxMgrJournalsAttemptsAndMarksMined(t *testing.T) {
+	t.Parallel()
+
+	cfg := configWithNumConfs(1)
+	cfg.Journal = txmgr.NewMemoryJournal()
+	h := newTestHarnessWithConfig(cfg)
+
+	updateGasPrice := func(ctx context.Context) (*types.Transaction, error) {
+		gasTipCap, gasFeeCap := h.gasPricer.sample()
+		return types.NewTx(&types.DynamicFeeTx{
+			Nonce:     1,
+			GasTipCap: gasTipCap,
+			GasFeeCap: gasFeeCap,
+		}), nil
+	}
+
+	sendTx := func(ctx context.Context, tx *types.Transaction) error {
+		if h.gasPricer.shouldMine(tx.GasFeeCap()) {
+			txHash := tx.Hash()
+			h.backend.mine(&txHash, tx.GasFeeCap())
+		}
+		return nil
+	}
+
+	receipt, err := h.mgr.Send(context.Background(), updateGasPrice, sendTx)
+	require.Nil(t, err)
+
+	entry, err := cfg.Journal.Entry(1)
+	require.Nil(t, err)
+	require.Equal(t, txmgr.TxStatusMined, entry.Status)
+	require.Equal(t, receipt.TxHash, entry.MinedTx)
+	require.Len(t, entry.Attempts, 3)
+}
+
+func TestTxMgrResumeMinesPriorAttempt(t *testing.T) {
+	t.Parallel()
+
+	cfg := configWithNumConfs(1)
+	cfg.Journal = txmgr.NewMemoryJournal()
+	h := newTestHarnessWithConfig(cfg)
+
+	// An attempt signed before the restart; it is the one that gets mined.
+	prior := newJournalTx(3, 100)
+	require.Nil(t, cfg.Journal.RecordAttempt(prior))
+
+	updateGasPrice := func(ctx context.Context) (*types.Transaction, error) {
+		gasTipCap, gasFeeCap := h.gasPricer.sample()
+		return types.NewTx(&types.DynamicFeeTx{
+			Nonce:     3,
+			GasTipCap: gasTipCap,
+			GasFeeCap: gasFeeCap,
+		}), nil
+	}
+
+	sendTx := func(ctx context.Context, tx *types.Transaction) error {
+		if tx.Hash() == prior.Hash() {
+			txHash := tx.Hash()
+			h.backend.mine(&txHash, tx.GasFeeCap())
+		}
+		return nil
+	}
+
+	pending, err := cfg.Journal.Pending()
+	require.Nil(t, err)
+	require.Len(t, pending, 1)
+
+	receipt, err := h.mgr.Resume(context.Background(), pending[0], updateGasPrice, sendTx)
+	require.Nil(t, err)
+	require.Equal(t, prior.Hash(), receipt.TxHash)
+
+	pending, err = cfg.Journal.Pending()
+	require.Nil(t, err)
+	require.Empty(t, pending)
+}
+
+func TestTxMgrMarksUnbroadcastAttemptFailed(t *testing.T) {
+	t.Parallel()
+
+	cfg := configWithNumConfs(1)
+	cfg.Journal = txmgr.NewMemoryJournal()
+	h := newTestHarnessWithConfig(cfg)
+
+	updateGasPrice := func(ctx context.Context) (*types.Transaction, error) {
+		gasTipCap, gasFeeCap := h.gasPricer.sample()
+		return types.NewTx(&types.DynamicFeeTx{
+			Nonce:     6,
+			GasTipCap: gasTipCap,
+			GasFeeCap: gasFeeCap,
+		}), nil
+	}
+	sendTx := func(ctx context.Context, tx *types.Transaction) error {
+		return errors.New("connection refused")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	_, err := h.mgr.Send(ctx, updateGasPrice, sendTx)
+	require.ErrorIs(t, err, txmgr.ErrNotBroadcast)
+
+	// a restart does not resume it
+	entry, err := cfg.Journal.Entry(6)
+	require.Nil(t, err)
+	require.Equal(t, txmgr.TxStatusFailed, entry.Status)
+	pending, err := cfg.Journal.Pending()
+	require.Nil(t, err)
+	require.Empty(t, pending)
+}
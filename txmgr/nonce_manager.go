@@ -0,0 +1,188 @@
+package txmgr
+
+import (
+	"context"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// NonceSource is the subset of the chain client the NonceManager needs to
+// learn the account nonce from the network.
+type NonceSource interface {
+	NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error)
+	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+}
+
+// NonceManager hands out sequential nonces for a single account so several
+// transactions can be in flight at once. Nonces that are never broadcast must
+// be given back with Release, otherwise every higher nonce stalls behind the
+// gap.
+type NonceManager struct {
+	backend NonceSource
+	addr    common.Address
+
+	mu       sync.Mutex
+	synced   bool
+	next     uint64
+	inflight map[uint64]struct{}
+	released map[uint64]struct{}
+}
+
+func NewNonceManager(backend NonceSource, addr common.Address) *NonceManager {
+	return &NonceManager{
+		backend:  backend,
+		addr:     addr,
+		inflight: make(map[uint64]struct{}),
+		released: make(map[uint64]struct{}),
+	}
+}
+
+// Next reserves a nonce, preferring the lowest released one so gaps are
+// filled before the account moves further ahead.
+func (n *NonceManager) Next(ctx context.Context) (uint64, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if !n.synced {
+		if err := n.resync(ctx); err != nil {
+			return 0, err
+		}
+	}
+
+	if gaps := sortedNonces(n.released); len(gaps) > 0 {
+		nonce := gaps[0]
+		delete(n.released, nonce)
+		n.inflight[nonce] = struct{}{}
+		return nonce, nil
+	}
+
+	nonce := n.next
+	n.next++
+	n.inflight[nonce] = struct{}{}
+	return nonce, nil
+}
+
+// Confirm marks a nonce as mined.
+func (n *NonceManager) Confirm(nonce uint64) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	delete(n.inflight, nonce)
+}
+
+// Release gives back a nonce whose transaction was never broadcast, so the
+// next caller reuses it. A broadcast transaction may still be mined and
+// keeps its nonce until Resync sees it mined or pending.
+func (n *NonceManager) Release(nonce uint64) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if _, ok := n.inflight[nonce]; !ok {
+		return
+	}
+	delete(n.inflight, nonce)
+	n.released[nonce] = struct{}{}
+
+	// Shrink the head instead of leaving gaps at the top of the range.
+	for n.next > 0 {
+		if _, ok := n.released[n.next-1]; !ok {
+			break
+		}
+		n.next--
+		delete(n.released, n.next)
+	}
+}
+
+// Resync reconciles the local view with the chain: nonces that were mined
+// or are pending in the mempool are forgotten, and nonces above them that
+// are not in flight are reported as gaps to be reused.
+func (n *NonceManager) Resync(ctx context.Context) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return n.resync(ctx)
+}
+
+// Pending returns the nonces currently in flight, in ascending order.
+func (n *NonceManager) Pending() []uint64 {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return sortedNonces(n.inflight)
+}
+
+// Gaps returns the released nonces that are waiting to be reused, in
+// ascending order.
+func (n *NonceManager) Gaps() []uint64 {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return sortedNonces(n.released)
+}
+
+func (n *NonceManager) resync(ctx context.Context) error {
+	latest, err := n.backend.NonceAt(ctx, n.addr, nil)
+	if err != nil {
+		return err
+	}
+	pending, err := n.backend.PendingNonceAt(ctx, n.addr)
+	if err != nil {
+		return err
+	}
+
+	// Nonces below the pending nonce are mined or sitting in the mempool,
+	// handing one of them out again would replace a live transaction.
+	floor := latest
+	if pending > floor {
+		floor = pending
+	}
+	for nonce := range n.inflight {
+		if nonce < floor {
+			delete(n.inflight, nonce)
+		}
+	}
+	for nonce := range n.released {
+		if nonce < floor {
+			delete(n.released, nonce)
+		}
+	}
+
+	if len(n.inflight) == 0 {
+		n.next = floor
+		n.released = make(map[uint64]struct{})
+		n.synced = true
+		return nil
+	}
+
+	if pending > n.next {
+		log.Warn("ContractsCaller pending nonce ahead of local view, another sender may share the wallet",
+			"address", n.addr, "pending", pending, "next", n.next)
+	}
+	if n.next < floor {
+		n.next = floor
+	}
+	for nonce := floor; nonce < n.next; nonce++ {
+		if _, ok := n.inflight[nonce]; ok {
+			continue
+		}
+		if _, ok := n.released[nonce]; !ok {
+			log.Warn("ContractsCaller nonce gap detected", "address", n.addr, "nonce", nonce)
+			n.released[nonce] = struct{}{}
+		}
+	}
+	n.synced = true
+	return nil
+}
+
+func sortedNonces(set map[uint64]struct{}) []uint64 {
+	nonces := make([]uint64, 0, len(set))
+	for nonce := range set {
+		nonces = append(nonces, nonce)
+	}
+	sort.Slice(nonces, func(i, j int) bool { return nonces[i] < nonces[j] })
+	return nonces
+}
@@ -0,0 +1,228 @@
+package txmgr_test
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/the-web3/contracts-caller/txmgr"
+)
+
+var testWallet = common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")
+
+type mockNonceSource struct {
+	mu      sync.Mutex
+	latest  uint64
+	pending uint64
+}
+
+func (s *mockNonceSource) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.latest, nil
+}
+
+func (s *mockNonceSource) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.pending, nil
+}
+
+func (s *mockNonceSource) set(latest, pending uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.latest = latest
+	s.pending = pending
+}
+
+func nextNonce(t *testing.T, nm *txmgr.NonceManager) uint64 {
+	nonce, err := nm.Next(context.Background())
+	require.Nil(t, err)
+	return nonce
+}
+
+func TestNonceManagerStartsAtPendingNonce(t *testing.T) {
+	source := &mockNonceSource{latest: 3, pending: 5}
+	nm := txmgr.NewNonceManager(source, testWallet)
+
+	require.Equal(t, uint64(5), nextNonce(t, nm))
+	require.Equal(t, uint64(6), nextNonce(t, nm))
+	require.Equal(t, []uint64{5, 6}, nm.Pending())
+}
+
+func TestNonceManagerConcurrentNoncesAreUnique(t *testing.T) {
+	source := &mockNonceSource{}
+	nm := txmgr.NewNonceManager(source, testWallet)
+
+	const n = 50
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		nonces = make(map[uint64]struct{})
+	)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			nonce := nextNonce(t, nm)
+			mu.Lock()
+			nonces[nonce] = struct{}{}
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	require.Len(t, nonces, n)
+	for i := uint64(0); i < n; i++ {
+		require.Contains(t, nonces, i)
+	}
+}
+
+func TestNonceManagerReleasedNonceIsReused(t *testing.T) {
+	source := &mockNonceSource{}
+	nm := txmgr.NewNonceManager(source, testWallet)
+
+	require.Equal(t, uint64(0), nextNonce(t, nm))
+	require.Equal(t, uint64(1), nextNonce(t, nm))
+	require.Equal(t, uint64(2), nextNonce(t, nm))
+
+	nm.Release(1)
+	require.Equal(t, []uint64{1}, nm.Gaps())
+	require.Equal(t, uint64(1), nextNonce(t, nm))
+	require.Equal(t, uint64(3), nextNonce(t, nm))
+}
+
+func TestNonceManagerReleaseHeadShrinksRange(t *testing.T) {
+	source := &mockNonceSource{}
+	nm := txmgr.NewNonceManager(source, testWallet)
+
+	require.Equal(t, uint64(0), nextNonce(t, nm))
+	require.Equal(t, uint64(1), nextNonce(t, nm))
+
+	nm.Release(1)
+	require.Empty(t, nm.Gaps())
+	require.Equal(t, uint64(1), nextNonce(t, nm))
+}
+
+func TestNonceManagerResyncDetectsGap(t *testing.T) {
+	source := &mockNonceSource{}
+	nm := txmgr.NewNonceManager(source, testWallet)
+
+	for i := 0; i < 4; i++ {
+		nextNonce(t, nm)
+	}
+
+	// Nonce 1 was confirmed but later dropped by a reorg, leaving 2 and 3
+	// stuck behind it.
+	nm.Confirm(0)
+	nm.Confirm(1)
+	source.set(1, 1)
+
+	require.Nil(t, nm.Resync(context.Background()))
+	require.Equal(t, []uint64{1}, nm.Gaps())
+	require.Equal(t, []uint64{2, 3}, nm.Pending())
+	require.Equal(t, uint64(1), nextNonce(t, nm))
+	require.Equal(t, uint64(4), nextNonce(t, nm))
+}
+
+func TestNonceManagerResyncForgetsMinedNonces(t *testing.T) {
+	source := &mockNonceSource{}
+	nm := txmgr.NewNonceManager(source, testWallet)
+
+	nextNonce(t, nm)
+	nextNonce(t, nm)
+	source.set(2, 2)
+
+	require.Nil(t, nm.Resync(context.Background()))
+	require.Empty(t, nm.Pending())
+	require.Empty(t, nm.Gaps())
+	require.Equal(t, uint64(2), nextNonce(t, nm))
+}
+
+func TestNonceManagerResyncSkipsPendingNonces(t *testing.T) {
+	source := &mockNonceSource{}
+	nm := txmgr.NewNonceManager(source, testWallet)
+
+	for i := 0; i < 3; i++ {
+		nextNonce(t, nm)
+	}
+
+	// 0 and 1 were broadcast and sit in the mempool, a Release of 1 after
+	// a receipt timeout must not hand it out again.
+	nm.Release(1)
+	source.set(0, 2)
+
+	require.Nil(t, nm.Resync(context.Background()))
+	require.Empty(t, nm.Gaps())
+	require.Equal(t, []uint64{2}, nm.Pending())
+	require.Equal(t, uint64(3), nextNonce(t, nm))
+}
+
+func TestTxMgrParallelSendsWithNonceManager(t *testing.T) {
+	t.Parallel()
+
+	h := newTestHarness()
+	nm := txmgr.NewNonceManager(&mockNonceSource{}, testWallet)
+
+	send := func() (*types.Receipt, uint64, error) {
+		nonce, err := nm.Next(context.Background())
+		if err != nil {
+			return nil, 0, err
+		}
+		gasPricer := newGasPricer(2)
+		updateGasPrice := func(ctx context.Context) (*types.Transaction, error) {
+			gasTipCap, gasFeeCap := gasPricer.sample()
+			return types.NewTx(&types.DynamicFeeTx{
+				Nonce:     nonce,
+				GasTipCap: gasTipCap,
+				GasFeeCap: gasFeeCap,
+			}), nil
+		}
+		sendTx := func(ctx context.Context, tx *types.Transaction) error {
+			if gasPricer.shouldMine(tx.GasFeeCap()) {
+				txHash := tx.Hash()
+				h.backend.mine(&txHash, tx.GasFeeCap())
+			}
+			return nil
+		}
+		receipt, err := h.mgr.Send(context.Background(), updateGasPrice, sendTx)
+		if err != nil {
+			nm.Release(nonce)
+			return nil, nonce, err
+		}
+		nm.Confirm(nonce)
+		return receipt, nonce, nil
+	}
+
+	const n = 3
+	var wg sync.WaitGroup
+	receipts := make([]*types.Receipt, n)
+	nonces := make([]uint64, n)
+	errs := make([]error, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			receipts[i], nonces[i], errs[i] = send()
+		}(i)
+	}
+	wg.Wait()
+
+	seen := make(map[uint64]struct{})
+	for i := 0; i < n; i++ {
+		require.Nil(t, errs[i])
+		require.NotNil(t, receipts[i])
+		seen[nonces[i]] = struct{}{}
+	}
+	require.Len(t, seen, n)
+	require.Empty(t, nm.Pending())
+}
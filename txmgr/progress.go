@@ -0,0 +1,41 @@
+package txmgr
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// TxStage is a step of a transaction on its way to a receipt.
+type TxStage string
+
+const (
+	// TxStageSimulated is reported by callers that simulate a call before
+	// they Send it.
+	TxStageSimulated TxStage = "simulated"
+	TxStageSigned    TxStage = "signed"
+	TxStageBroadcast TxStage = "broadcast"
+	// TxStageMined is reported once a receipt is seen, before the
+	// transaction has its confirmations.
+	TxStageMined TxStage = "mined"
+)
+
+// ProgressFunc is told each stage a transaction reaches. Fee bumps report
+// signed and broadcast again with the hash of the new attempt.
+type ProgressFunc func(stage TxStage, txHash common.Hash)
+
+type progressKey struct{}
+
+// WithProgress returns a context that makes Send report the stages of the
+// transaction it sends to fn.
+func WithProgress(ctx context.Context, fn ProgressFunc) context.Context {
+	return context.WithValue(ctx, progressKey{}, fn)
+}
+
+// ReportProgress tells the ProgressFunc of ctx, if any, that a transaction
+// reached stage.
+func ReportProgress(ctx context.Context, stage TxStage, txHash common.Hash) {
+	if fn, ok := ctx.Value(progressKey{}).(ProgressFunc); ok {
+		fn(stage, txHash)
+	}
+}
@@ -0,0 +1,98 @@
+package txmgr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// ErrTxReverted matches every RevertError with errors.Is.
+var ErrTxReverted = errors.New("transaction reverted")
+
+// RevertError is returned by Send, together with the receipt, when the
+// transaction was mined but failed.
+type RevertError struct {
+	Receipt *types.Receipt
+	Tx      *types.Transaction
+	// Data is the revert data obtained by replaying the call at the mined
+	// block, empty when the backend cannot replay or returned none.
+	Data []byte
+	// Reason is the decoded revert reason, filled in by callers that know
+	// the ABI of the target contract.
+	Reason string
+}
+
+func (e *RevertError) Error() string {
+	msg := fmt.Sprintf("txmgr: transaction %s reverted in block %v", e.Receipt.TxHash, e.Receipt.BlockNumber)
+	switch {
+	case e.Reason != "":
+		msg += ": " + e.Reason
+	case len(e.Data) > 0:
+		msg += ": revert data " + hexutil.Encode(e.Data)
+	}
+	return msg
+}
+
+func (e *RevertError) Is(target error) bool {
+	return target == ErrTxReverted
+}
+
+// CallReplayer is implemented by backends able to run eth_call; when the
+// ReceiptSource implements it, reverted transactions are replayed to fetch
+// their revert data.
+type CallReplayer interface {
+	CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error)
+}
+
+// RevertData extracts the revert data carried by a JSON-RPC error, as
+// returned by eth_call and eth_estimateGas.
+func RevertData(err error) []byte {
+	var dataErr rpc.DataError
+	if !errors.As(err, &dataErr) {
+		return nil
+	}
+	switch data := dataErr.ErrorData().(type) {
+	case string:
+		decoded, err := hexutil.Decode(data)
+		if err != nil {
+			return nil
+		}
+		return decoded
+	case []byte:
+		return data
+	default:
+		return nil
+	}
+}
+
+func (m *SimpleTxManager) revertError(ctx context.Context, tx *types.Transaction, receipt *types.Receipt) *RevertError {
+	revertErr := &RevertError{Receipt: receipt, Tx: tx}
+	replayer, ok := m.backend.(CallReplayer)
+	if !ok {
+		return revertErr
+	}
+
+	from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		log.Debug("ContractsCaller unable to recover sender of reverted transaction", "txHash", tx.Hash(), "err", err)
+	}
+	msg := ethereum.CallMsg{
+		From:  from,
+		To:    tx.To(),
+		Gas:   tx.Gas(),
+		Value: tx.Value(),
+		Data:  tx.Data(),
+	}
+	_, err = replayer.CallContract(ctx, msg, receipt.BlockNumber)
+	if err != nil {
+		revertErr.Data = RevertData(err)
+	}
+	return revertErr
+}
@@ -2,9 +2,10 @@ package txmgr
 
 import (
 	"context"
-	"math/big"
+	"errors"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -12,6 +13,26 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 )
 
+// ErrNotBroadcast matches the error of a Send none of whose attempts
+// reached the network, so the nonce of the transaction is still free.
+var ErrNotBroadcast = errors.New("transaction never broadcast")
+
+type notBroadcastError struct {
+	err error
+}
+
+func (e *notBroadcastError) Error() string {
+	return e.err.Error()
+}
+
+func (e *notBroadcastError) Is(target error) bool {
+	return target == ErrNotBroadcast
+}
+
+func (e *notBroadcastError) Unwrap() error {
+	return e.err
+}
+
 type UpdateGasPriceFunc = func(ctx context.Context) (*types.Transaction, error)
 
 type SendTransactionFunc = func(ctx context.Context, tx *types.Transaction) error
@@ -21,10 +42,15 @@ type Config struct {
 	ReceiptQueryInterval      time.Duration
 	NumConfirmations          uint64
 	SafeAbortNonceTooLowCount uint64
+
+	// Journal, when set, records every signed attempt so in-flight
+	// transactions can be resumed after a restart.
+	Journal Journal
 }
 
 type TxManager interface {
 	Send(ctx context.Context, updateGasPrice UpdateGasPriceFunc, sendTxn SendTransactionFunc) (*types.Receipt, error)
+	Resume(ctx context.Context, entry *JournalEntry, updateGasPrice UpdateGasPriceFunc, sendTxn SendTransactionFunc) (*types.Receipt, error)
 }
 
 type ReceiptSource interface {
@@ -49,47 +75,64 @@ func NewSimpleTxManager(cfg Config, backend ReceiptSource) *SimpleTxManager {
 }
 
 func (m *SimpleTxManager) Send(ctx context.Context, updateGasPrice UpdateGasPriceFunc, sendTx SendTransactionFunc) (*types.Receipt, error) {
+	return m.send(ctx, nil, updateGasPrice, sendTx)
+}
+
+// Resume picks up a journaled transaction after a restart. Every previously
+// signed attempt is rebroadcast and watched, since any of them may still be
+// mined, while new attempts keep bumping the fees as Send would.
+func (m *SimpleTxManager) Resume(ctx context.Context, entry *JournalEntry, updateGasPrice UpdateGasPriceFunc, sendTx SendTransactionFunc) (*types.Receipt, error) {
+	prior, err := entry.Transactions()
+	if err != nil {
+		return nil, err
+	}
+	log.Info("ContractsCaller resuming journaled transaction", "nonce", entry.Nonce, "attempts", len(prior))
+	return m.send(ctx, prior, updateGasPrice, sendTx)
+}
+
+func (m *SimpleTxManager) send(ctx context.Context, prior []*types.Transaction, updateGasPrice UpdateGasPriceFunc, sendTx SendTransactionFunc) (*types.Receipt, error) {
 	var wg sync.WaitGroup
 	defer wg.Wait()
 	ctxc, cancel := context.WithCancel(ctx)
 	defer cancel()
 
 	sendState := NewSendState(m.cfg.SafeAbortNonceTooLowCount)
-
-	receiptChan := make(chan *types.Receipt, 1)
-	sendTxAsync := func() {
-		defer wg.Done()
-
-		tx, err := updateGasPrice(ctxc)
-		if err != nil {
-			if err == context.Canceled || strings.Contains(err.Error(), "context canceled") {
-				return
-			}
-			log.Error("ContractsCaller update txn gas price fail", "err", err)
-			cancel()
-			return
-		}
-
+	// prior attempts of a resumed transaction may have reached the network
+	var broadcast atomic.Bool
+	broadcast.Store(len(prior) > 0)
+	var signed atomic.Pointer[types.Transaction]
+
+	type minedTx struct {
+		tx      *types.Transaction
+		receipt *types.Receipt
+	}
+	receiptChan := make(chan minedTx, 1)
+	publishAndWait := func(tx *types.Transaction) {
 		txHash := tx.Hash()
 		nonce := tx.Nonce()
 		gasTipCap := tx.GasTipCap()
 		gasFeeCap := tx.GasFeeCap()
 		log.Debug("ContractsCaller publishing transaction", "txHash", txHash, "nonce", nonce, "gasTipCap", gasTipCap, "gasFeeCap", gasFeeCap)
 
-		err = sendTx(ctxc, tx)
+		err := sendTx(ctxc, tx)
 		sendState.ProcessSendError(err)
+		if err == nil || strings.Contains(err.Error(), "already known") {
+			broadcast.Store(true)
+		}
 		if err != nil {
 			if err == context.Canceled || strings.Contains(err.Error(), "context canceled") {
 				return
 			}
 			log.Error("ContractsCaller unable to publish transaction", "err", err)
 			if sendState.ShouldAbortImmediately() {
+				m.markJournal(nonce, TxStatusFailed, common.Hash{})
 				cancel()
 			}
 			return
 		}
 
 		log.Debug("ContractsCaller transaction published successfully", "hash", txHash, "nonce", nonce, "gasTipCap", gasTipCap, "gasFeeCap", gasFeeCap)
+		ReportProgress(ctx, TxStageBroadcast, txHash)
 
 		receipt, err := waitMined(
 			ctxc, m.backend, tx, m.cfg.ReceiptQueryInterval,
@@ -99,8 +142,13 @@ func (m *SimpleTxManager) Send(ctx context.Context, updateGasPrice UpdateGasPric
 			log.Debug("ContractsCaller send tx failed", "hash", txHash, "nonce", nonce, "gasTipCap", gasTipCap, "gasFeeCap", gasFeeCap, "err", err)
 		}
 		if receipt != nil {
+			if receipt.Status == types.ReceiptStatusFailed {
+				m.markJournal(nonce, TxStatusReverted, txHash)
+			} else {
+				m.markJournal(nonce, TxStatusMined, txHash)
+			}
 			select {
-			case receiptChan <- receipt:
+			case receiptChan <- minedTx{tx: tx, receipt: receipt}:
 				log.Trace("ContractsCaller send tx succeeded", "hash", txHash,
 					"nonce", nonce, "gasTipCap", gasTipCap,
 					"gasFeeCap", gasFeeCap)
@@ -109,6 +157,42 @@ func (m *SimpleTxManager) Send(ctx context.Context, updateGasPrice UpdateGasPric
 		}
 	}
 
+	sendTxAsync := func() {
+		defer wg.Done()
+
+		tx, err := updateGasPrice(ctxc)
+		if err != nil {
+			if err == context.Canceled || strings.Contains(err.Error(), "context canceled") {
+				return
+			}
+			if errors.Is(err, ErrFeeCapReached) {
+				// an unchanged re-signed attempt would be rejected as an
+				// underpriced replacement, keep waiting on the pending one
+				log.Warn("ContractsCaller fee caps reached, waiting on the pending transaction")
+				return
+			}
+			log.Error("ContractsCaller update txn gas price fail", "err", err)
+			cancel()
+			return
+		}
+		signed.Store(tx)
+		if m.cfg.Journal != nil {
+			if err := m.cfg.Journal.RecordAttempt(tx); err != nil {
+				log.Error("ContractsCaller unable to journal transaction", "txHash", tx.Hash(), "err", err)
+			}
+		}
+		ReportProgress(ctx, TxStageSigned, tx.Hash())
+		publishAndWait(tx)
+	}
+
+	for _, tx := range prior {
+		wg.Add(1)
+		go func(tx *types.Transaction) {
+			defer wg.Done()
+			publishAndWait(tx)
+		}(tx)
+	}
+
 	wg.Add(1)
 	go sendTxAsync()
 
@@ -125,14 +209,36 @@ func (m *SimpleTxManager) Send(ctx context.Context, updateGasPrice UpdateGasPric
 			go sendTxAsync()
 
 		case <-ctxc.Done():
+			// no attempt may be journaled or published once this returns
+			cancel()
+			wg.Wait()
+			if !broadcast.Load() {
+				// the nonce is free again, a restart must not resume it
+				if tx := signed.Load(); tx != nil {
+					m.markJournal(tx.Nonce(), TxStatusFailed, common.Hash{})
+				}
+				return nil, &notBroadcastError{ctxc.Err()}
+			}
 			return nil, ctxc.Err()
 
-		case receipt := <-receiptChan:
-			return receipt, nil
+		case mined := <-receiptChan:
+			if mined.receipt.Status == types.ReceiptStatusFailed {
+				return mined.receipt, m.revertError(ctx, mined.tx, mined.receipt)
+			}
+			return mined.receipt, nil
 		}
 	}
 }
 
+func (m *SimpleTxManager) markJournal(nonce uint64, status TxStatus, txHash common.Hash) {
+	if m.cfg.Journal == nil {
+		return
+	}
+	if err := m.cfg.Journal.MarkStatus(nonce, status, txHash); err != nil && err != ErrJournalEntryNotFound {
+		log.Error("ContractsCaller unable to update transaction journal", "nonce", nonce, "status", status, "err", err)
+	}
+}
+
 func WaitMined(
 	ctx context.Context,
 	backend ReceiptSource,
@@ -156,6 +262,7 @@ func waitMined(
 	defer queryTicker.Stop()
 
 	txHash := tx.Hash()
+	reportedMined := false
 
 	for {
 		receipt, err := backend.TransactionReceipt(ctx, txHash)
@@ -164,6 +271,10 @@ func waitMined(
 			if sendState != nil {
 				sendState.TxMined(txHash)
 			}
+			if !reportedMined {
+				ReportProgress(ctx, TxStageMined, txHash)
+				reportedMined = true
+			}
 
 			txHeight := receipt.BlockNumber.Uint64()
 			tipHeight, err := backend.BlockNumber(ctx)
@@ -204,10 +315,3 @@ func waitMined(
 		}
 	}
 }
-
-func CalcGasFeeCap(baseFee, gasTipCap *big.Int) *big.Int {
-	return new(big.Int).Add(
-		gasTipCap,
-		new(big.Int).Mul(baseFee, big.NewInt(2)),
-	)
-}
@@ -10,7 +10,9 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/the-web3/contracts-caller/txmgr"
@@ -94,6 +96,7 @@ func (g *gasPricer) sample() (*big.Int, *big.Int) {
 type minedTxInfo struct {
 	gasFeeCap   *big.Int
 	blockNumber uint64
+	reverted    bool
 }
 
 type mockBackend struct {
@@ -102,6 +105,8 @@ type mockBackend struct {
 	blockHeight uint64
 
 	minedTxs map[common.Hash]minedTxInfo
+
+	revertData []byte
 }
 
 func newMockBackend() *mockBackend {
@@ -123,6 +128,36 @@ func (b *mockBackend) mine(txHash *common.Hash, gasFeeCap *big.Int) {
 	}
 }
 
+func (b *mockBackend) mineReverted(txHash common.Hash, revertData []byte) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.blockHeight++
+	b.minedTxs[txHash] = minedTxInfo{
+		gasFeeCap:   new(big.Int),
+		blockNumber: b.blockHeight,
+		reverted:    true,
+	}
+	b.revertData = revertData
+}
+
+type revertDataError struct {
+	data string
+}
+
+func (e *revertDataError) Error() string          { return "execution reverted" }
+func (e *revertDataError) ErrorData() interface{} { return e.data }
+
+func (b *mockBackend) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if b.revertData == nil {
+		return nil, nil
+	}
+	return nil, &revertDataError{data: hexutil.Encode(b.revertData)}
+}
+
 func (b *mockBackend) BlockNumber(ctx context.Context) (uint64, error) {
 	b.mu.RLock()
 	defer b.mu.RUnlock()
@@ -143,8 +178,13 @@ func (b *mockBackend) TransactionReceipt(
 		return nil, nil
 	}
 
+	status := types.ReceiptStatusSuccessful
+	if txInfo.reverted {
+		status = types.ReceiptStatusFailed
+	}
 	return &types.Receipt{
 		TxHash:      txHash,
+		Status:      status,
 		GasUsed:     txInfo.gasFeeCap.Uint64(),
 		BlockNumber: big.NewInt(int64(txInfo.blockNumber)),
 	}, nil
@@ -180,6 +220,89 @@ func TestTxMgrConfirmAtMinGasPrice(t *testing.T) {
 	require.Equal(t, gasPricer.expGasFeeCap().Uint64(), receipt.GasUsed)
 }
 
+// TestTxMgrReportsProgress asserts that Send reports every attempt it signs
+// and broadcasts, and the one that is mined.
+func TestTxMgrReportsProgress(t *testing.T) {
+	t.Parallel()
+
+	h := newTestHarness()
+
+	gasPricer := newGasPricer(2)
+
+	updateGasPrice := func(ctx context.Context) (*types.Transaction, error) {
+		gasTipCap, gasFeeCap := gasPricer.sample()
+		return types.NewTx(&types.DynamicFeeTx{
+			GasTipCap: gasTipCap,
+			GasFeeCap: gasFeeCap,
+		}), nil
+	}
+
+	sendTx := func(ctx context.Context, tx *types.Transaction) error {
+		if gasPricer.shouldMine(tx.GasFeeCap()) {
+			txHash := tx.Hash()
+			h.backend.mine(&txHash, tx.GasFeeCap())
+		}
+		return nil
+	}
+
+	var mu sync.Mutex
+	var stages []txmgr.TxStage
+	ctx := txmgr.WithProgress(context.Background(), func(stage txmgr.TxStage, txHash common.Hash) {
+		mu.Lock()
+		defer mu.Unlock()
+		stages = append(stages, stage)
+	})
+	receipt, err := h.mgr.Send(ctx, updateGasPrice, sendTx)
+	require.Nil(t, err)
+	require.NotNil(t, receipt)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Equal(t, []txmgr.TxStage{
+		txmgr.TxStageSigned, txmgr.TxStageBroadcast,
+		txmgr.TxStageSigned, txmgr.TxStageBroadcast,
+		txmgr.TxStageMined,
+	}, stages)
+}
+
+func TestTxMgrWaitsOnPendingTxAtFeeCap(t *testing.T) {
+	t.Parallel()
+
+	h := newTestHarness()
+
+	var mu sync.Mutex
+	var signed []*types.Transaction
+	updateGasPrice := func(ctx context.Context) (*types.Transaction, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if len(signed) > 0 {
+			return nil, txmgr.ErrFeeCapReached
+		}
+		tx := types.NewTx(&types.DynamicFeeTx{
+			GasTipCap: big.NewInt(5),
+			GasFeeCap: big.NewInt(17),
+		})
+		signed = append(signed, tx)
+		return tx, nil
+	}
+	sendTx := func(ctx context.Context, tx *types.Transaction) error {
+		return nil
+	}
+
+	// the first attempt is mined after two resubmission timeouts
+	go func() {
+		time.Sleep(2500 * time.Millisecond)
+		mu.Lock()
+		txHash := signed[0].Hash()
+		mu.Unlock()
+		h.backend.mine(&txHash, big.NewInt(17))
+	}()
+	receipt, err := h.mgr.Send(context.Background(), updateGasPrice, sendTx)
+	require.Nil(t, err)
+	require.Equal(t, signed[0].Hash(), receipt.TxHash)
+	require.Len(t, signed, 1)
+}
+
 func TestTxMgrNeverConfirmCancel(t *testing.T) {
 	t.Parallel()
 
@@ -203,6 +326,7 @@ func TestTxMgrNeverConfirmCancel(t *testing.T) {
 
 	receipt, err := h.mgr.Send(ctx, updateGasPrice, sendTx)
 	require.Equal(t, err, context.DeadlineExceeded)
+	require.NotErrorIs(t, err, txmgr.ErrNotBroadcast)
 	require.Nil(t, receipt)
 }
 
@@ -257,7 +381,8 @@ func TestTxMgrBlocksOnFailingRpcCalls(t *testing.T) {
 	defer cancel()
 
 	receipt, err := h.mgr.Send(ctx, updateGasPrice, sendTx)
-	require.Equal(t, err, context.DeadlineExceeded)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.ErrorIs(t, err, txmgr.ErrNotBroadcast)
 	require.Nil(t, receipt)
 }
 
@@ -363,6 +488,36 @@ func TestTxMgrDoesntAbortNonceTooLowAfterMiningTx(t *testing.T) {
 	require.Equal(t, h.gasPricer.expGasFeeCap().Uint64(), receipt.GasUsed)
 }
 
+func TestTxMgrReturnsRevertErrorWithReplayData(t *testing.T) {
+	t.Parallel()
+
+	h := newTestHarness()
+	revertData := []byte{0xe2, 0x51, 0x7d, 0x3f, 0x01}
+
+	updateGasPrice := func(ctx context.Context) (*types.Transaction, error) {
+		gasTipCap, gasFeeCap := h.gasPricer.sample()
+		return types.NewTx(&types.DynamicFeeTx{
+			GasTipCap: gasTipCap,
+			GasFeeCap: gasFeeCap,
+		}), nil
+	}
+
+	sendTx := func(ctx context.Context, tx *types.Transaction) error {
+		h.backend.mineReverted(tx.Hash(), revertData)
+		return nil
+	}
+
+	receipt, err := h.mgr.Send(context.Background(), updateGasPrice, sendTx)
+	require.NotNil(t, receipt)
+	require.Equal(t, types.ReceiptStatusFailed, receipt.Status)
+	require.True(t, errors.Is(err, txmgr.ErrTxReverted))
+
+	var revertErr *txmgr.RevertError
+	require.True(t, errors.As(err, &revertErr))
+	require.Equal(t, revertData, revertErr.Data)
+	require.Equal(t, receipt.TxHash, revertErr.Tx.Hash())
+}
+
 func TestWaitMinedReturnsReceiptOnFirstSuccess(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,132 @@
+package challenger
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/the-web3/contracts-caller/bindings"
+	"github.com/the-web3/contracts-caller/caller"
+	common2 "github.com/the-web3/contracts-caller/common"
+	"github.com/the-web3/contracts-caller/ethereumcli"
+)
+
+const whitelistArgsUsage = "[file-or-url, --token-whitelist by default]"
+
+// WhitelistCommand is `whitelist plan|apply`, reconciling the token
+// whitelist with a managed list once, the way the service does every loop.
+func WhitelistCommand() cli.Command {
+	return cli.Command{
+		Name:  "whitelist",
+		Usage: "Reconcile the token whitelist with a managed list",
+		Subcommands: []cli.Command{
+			{
+				Name:      "plan",
+				Usage:     "Print the tokens that would be added and the extra whitelisted tokens, without a wallet",
+				ArgsUsage: whitelistArgsUsage,
+				Action:    runWhitelistPlan,
+			},
+			{
+				Name:      "apply",
+				Usage:     "Add every missing token with the caller wallet",
+				ArgsUsage: whitelistArgsUsage,
+				Action:    runWhitelistApply,
+			},
+		},
+	}
+}
+
+// whitelistSource reads the list argument before anything is dialed.
+func whitelistSource(cliCtx *cli.Context, cfg Config) (string, error) {
+	args := &cliArgs{args: cliCtx.Args()}
+	var source string
+	if len(args.args) > 0 {
+		source = args.get(0)
+	}
+	if err := args.done(); err != nil {
+		return "", fmt.Errorf("%s: %w, usage: %s %s", cliCtx.Command.Name, err, cliCtx.Command.Name, whitelistArgsUsage)
+	}
+	if source == "" {
+		source = cfg.TokenWhitelist
+	}
+	if source == "" {
+		return "", fmt.Errorf("no token list given, pass a file or URL or set --token-whitelist")
+	}
+	return source, nil
+}
+
+func runWhitelistPlan(cliCtx *cli.Context) error {
+	cfg, err := NewConfig(cliCtx)
+	if err != nil {
+		return err
+	}
+	source, err := whitelistSource(cliCtx, cfg)
+	if err != nil {
+		return err
+	}
+	contractAddress, err := common2.ParseAddress(cfg.TreasureManagerContractAddress)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	desired, err := caller.LoadTokenList(ctx, source)
+	if err != nil {
+		return err
+	}
+	chainClient, err := ethereumcli.EthClientWithTimeout(ctx, cfg.ChainRpcUrl)
+	if err != nil {
+		return err
+	}
+	defer chainClient.Close()
+	tm, err := bindings.NewTreasureManagerCaller(contractAddress, chainClient)
+	if err != nil {
+		return err
+	}
+
+	plan, err := caller.PlanTokenWhitelist(ctx, tm, desired)
+	if err != nil {
+		return err
+	}
+	return printJSON(plan)
+}
+
+func runWhitelistApply(cliCtx *cli.Context) error {
+	cfg, err := NewConfig(cliCtx)
+	if err != nil {
+		return err
+	}
+	source, err := whitelistSource(cliCtx, cfg)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	desired, err := caller.LoadTokenList(ctx, source)
+	if err != nil {
+		return err
+	}
+	cCaller, closeCaller, err := newContractCaller(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer closeCaller()
+
+	plan, err := caller.PlanTokenWhitelist(ctx, &cCaller.TreasureManagerContract.TreasureManagerCaller, desired)
+	if err != nil {
+		return err
+	}
+	receipts, applyErr := cCaller.ApplyTokenWhitelist(ctx, plan)
+	methods := make([]string, len(plan.Missing))
+	for i := range methods {
+		methods[i] = "setTokenWhiteList"
+	}
+	results := batchTxResults(methods, cCaller.WalletAddr, cCaller.Cfg.TreasureManagerAddr, receipts, applyErr)
+	if err := printJSON(struct {
+		Plan    *caller.WhitelistPlan `json:"plan"`
+		Results []TxResult            `json:"results"`
+	}{plan, results}); err != nil {
+		return err
+	}
+	return applyErr
+}
@@ -0,0 +1,278 @@
+package challenger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/urfave/cli"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	ethc "github.com/ethereum/go-ethereum/common"
+
+	"github.com/the-web3/contracts-caller/bindings"
+	"github.com/the-web3/contracts-caller/caller"
+	common2 "github.com/the-web3/contracts-caller/common"
+	"github.com/the-web3/contracts-caller/ethereumcli"
+	"github.com/the-web3/contracts-caller/flags"
+)
+
+const withdrawRequestArgsUsage = "<token|ETH> <to> <amount>"
+
+// WithdrawCommand is `withdraw request|approve|reject|list|propose|execute`,
+// withdrawing from the treasure within --withdrawal-policy.
+func WithdrawCommand() cli.Command {
+	return cli.Command{
+		Name:  "withdraw",
+		Usage: "Request, approve and execute withdrawals within the withdrawal policy",
+		Subcommands: []cli.Command{
+			{
+				Name:      "request",
+				Usage:     "Record a withdrawal the policy allows, awaiting approval when over the threshold",
+				ArgsUsage: withdrawRequestArgsUsage,
+				Flags:     []cli.Flag{flags.WithdrawAsFlag, flags.WithdrawReasonFlag},
+				Action:    runWithdrawRequest,
+			},
+			{
+				Name:      "approve",
+				Usage:     "Approve a withdrawal requested by someone else",
+				ArgsUsage: "<id>",
+				Flags:     []cli.Flag{flags.WithdrawAsFlag},
+				Action:    runWithdrawApprove,
+			},
+			{
+				Name:      "reject",
+				Usage:     "Reject a withdrawal that was not sent yet",
+				ArgsUsage: "<id>",
+				Flags:     []cli.Flag{flags.WithdrawAsFlag, flags.WithdrawReasonFlag},
+				Action:    runWithdrawReject,
+			},
+			{
+				Name:   "list",
+				Usage:  "Print every withdrawal, oldest first",
+				Action: runWithdrawList,
+			},
+			{
+				Name:      "propose",
+				Usage:     "Record an approved withdrawal as a proposal, with --approval-threshold set",
+				ArgsUsage: "<id>",
+				Action:    runWithdrawPropose,
+			},
+			{
+				Name:      "execute",
+				Usage:     "Send an approved withdrawal with the caller wallet once the policy still allows it, through its proposal with --approval-threshold set",
+				ArgsUsage: "<id>",
+				Action:    runWithdrawExecute,
+			},
+		},
+	}
+}
+
+func openWithdrawalStore(cfg Config) (caller.WithdrawalStore, error) {
+	if cfg.WithdrawalsDB == "" {
+		return nil, errors.New("no --withdrawals-db configured")
+	}
+	return caller.NewLevelDBWithdrawalStore(cfg.WithdrawalsDB)
+}
+
+func loadWithdrawalPolicy(cfg Config) (*caller.WithdrawalPolicy, error) {
+	if cfg.WithdrawalPolicy == "" {
+		return nil, errors.New("no --withdrawal-policy configured")
+	}
+	return caller.LoadWithdrawalPolicy(cfg.WithdrawalPolicy)
+}
+
+// withdrawalIDArg reads the id a subcommand acts on.
+func withdrawalIDArg(cliCtx *cli.Context) (string, error) {
+	args := &cliArgs{args: cliCtx.Args()}
+	id := args.get(0)
+	if err := args.done(); err != nil {
+		return "", fmt.Errorf("%s: %w, usage: %s <id>", cliCtx.Command.Name, err, cliCtx.Command.Name)
+	}
+	return id, nil
+}
+
+func runWithdrawRequest(cliCtx *cli.Context) error {
+	args := &cliArgs{args: cliCtx.Args()}
+	token := args.get(0)
+	to, amount := args.address(1), args.amount(2)
+	if err := args.done(); err != nil {
+		return fmt.Errorf("request: %w, usage: request %s", err, withdrawRequestArgsUsage)
+	}
+	cfg, err := NewConfig(cliCtx)
+	if err != nil {
+		return err
+	}
+	policy, err := loadWithdrawalPolicy(cfg)
+	if err != nil {
+		return err
+	}
+	contractAddress, err := common2.ParseAddress(cfg.TreasureManagerContractAddress)
+	if err != nil {
+		return err
+	}
+	store, err := openWithdrawalStore(cfg)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+	ctx := context.Background()
+	chainClient, err := ethereumcli.EthClientWithTimeout(ctx, cfg.ChainRpcUrl)
+	if err != nil {
+		return err
+	}
+	defer chainClient.Close()
+	tm, err := bindings.NewTreasureManagerCaller(contractAddress, chainClient)
+	if err != nil {
+		return err
+	}
+
+	tokenAddress, err := withdrawalToken(ctx, tm, token)
+	if err != nil {
+		return err
+	}
+	w, err := caller.RequestWithdrawal(ctx, tm, policy, store, &caller.WithdrawalRequest{
+		Token:       tokenAddress,
+		To:          to,
+		Amount:      amount,
+		RequestedBy: cliCtx.String(flags.WithdrawAsFlag.Name),
+		Reason:      cliCtx.String(flags.WithdrawReasonFlag.Name),
+	})
+	if err != nil {
+		return err
+	}
+	return printJSON(w)
+}
+
+// withdrawalToken resolves ETH to the ethAddress of the contract.
+func withdrawalToken(ctx context.Context, tm *bindings.TreasureManagerCaller, token string) (ethc.Address, error) {
+	if strings.EqualFold(token, caller.WithdrawalPolicyETH) {
+		return tm.EthAddress(&bind.CallOpts{Context: ctx})
+	}
+	return common2.ParseAddress(token)
+}
+
+func runWithdrawApprove(cliCtx *cli.Context) error {
+	return updateWithdrawal(cliCtx, func(store caller.WithdrawalStore, id string) (*caller.Withdrawal, error) {
+		return caller.ApproveWithdrawal(store, id, cliCtx.String(flags.WithdrawAsFlag.Name))
+	})
+}
+
+func runWithdrawReject(cliCtx *cli.Context) error {
+	return updateWithdrawal(cliCtx, func(store caller.WithdrawalStore, id string) (*caller.Withdrawal, error) {
+		return caller.RejectWithdrawal(store, id, cliCtx.String(flags.WithdrawAsFlag.Name), cliCtx.String(flags.WithdrawReasonFlag.Name))
+	})
+}
+
+func updateWithdrawal(cliCtx *cli.Context, update func(store caller.WithdrawalStore, id string) (*caller.Withdrawal, error)) error {
+	id, err := withdrawalIDArg(cliCtx)
+	if err != nil {
+		return err
+	}
+	cfg, err := NewConfig(cliCtx)
+	if err != nil {
+		return err
+	}
+	store, err := openWithdrawalStore(cfg)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	w, err := update(store, id)
+	if err != nil {
+		return err
+	}
+	return printJSON(w)
+}
+
+func runWithdrawList(cliCtx *cli.Context) error {
+	cfg, err := NewConfig(cliCtx)
+	if err != nil {
+		return err
+	}
+	store, err := openWithdrawalStore(cfg)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	withdrawals, err := store.List()
+	if err != nil {
+		return err
+	}
+	if withdrawals == nil {
+		withdrawals = []*caller.Withdrawal{}
+	}
+	return printJSON(withdrawals)
+}
+
+func runWithdrawPropose(cliCtx *cli.Context) error {
+	return withWithdrawalCaller(cliCtx, func(ctx context.Context, cCaller *caller.ContractCaller, policy *caller.WithdrawalPolicy, store caller.WithdrawalStore, proposals caller.ProposalStore, id string) error {
+		if proposals == nil {
+			return errors.New("withdrawals are only proposed with --approval-threshold set")
+		}
+		_, p, err := cCaller.ProposeWithdrawal(ctx, policy, store, proposals, id)
+		if err != nil {
+			return err
+		}
+		return printJSON(p)
+	})
+}
+
+func runWithdrawExecute(cliCtx *cli.Context) error {
+	return withWithdrawalCaller(cliCtx, func(ctx context.Context, cCaller *caller.ContractCaller, policy *caller.WithdrawalPolicy, store caller.WithdrawalStore, proposals caller.ProposalStore, id string) error {
+		var w *caller.Withdrawal
+		var execErr error
+		if proposals != nil {
+			w, execErr = cCaller.ExecuteWithdrawalProposal(ctx, policy, store, proposals, id)
+		} else {
+			w, execErr = cCaller.ExecuteWithdrawal(ctx, policy, store, id)
+		}
+		if w != nil {
+			if err := printJSON(w); err != nil {
+				return err
+			}
+		}
+		return execErr
+	})
+}
+
+// withWithdrawalCaller opens what sending the withdrawal of the id argument
+// needs. The proposal store is only opened with --approval-threshold set,
+// and nil otherwise.
+func withWithdrawalCaller(cliCtx *cli.Context, run func(ctx context.Context, cCaller *caller.ContractCaller, policy *caller.WithdrawalPolicy, store caller.WithdrawalStore, proposals caller.ProposalStore, id string) error) error {
+	id, err := withdrawalIDArg(cliCtx)
+	if err != nil {
+		return err
+	}
+	cfg, err := NewConfig(cliCtx)
+	if err != nil {
+		return err
+	}
+	policy, err := loadWithdrawalPolicy(cfg)
+	if err != nil {
+		return err
+	}
+	store, err := openWithdrawalStore(cfg)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+	var proposals caller.ProposalStore
+	if cfg.ApprovalThreshold > 0 {
+		if proposals, err = openProposalStore(cfg); err != nil {
+			return err
+		}
+		defer proposals.Close()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cCaller, closeCaller, err := newContractCaller(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer closeCaller()
+	return run(ctx, cCaller, policy, store, proposals, id)
+}